@@ -0,0 +1,98 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sjy-dv/coltt/diskv"
+)
+
+// backup and restore of the diskv store of a data directory.
+// The store must not be opened by a running server, a live server is
+// backed up through diskv.DB.Backup from inside the process.
+//
+//	backup -mode backup -dir ./data_dir -file coltt.tar
+//	backup -mode restore -dir ./restored_dir -file coltt.tar
+var (
+	mode    string
+	dirPath string
+	file    string
+)
+
+func main() {
+	flag.StringVar(&mode, "mode", "backup", "backup or restore")
+	flag.StringVar(&dirPath, "dir", "./data_dir", "diskv directory")
+	flag.StringVar(&file, "file", "coltt-backup.tar", "backup file")
+	flag.Parse()
+
+	var err error
+	switch mode {
+	case "backup":
+		err = backup()
+	case "restore":
+		err = restore()
+	default:
+		log.Error().Msgf("unknown mode : %s", mode)
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("%s failed", mode)
+		os.Exit(1)
+	}
+	log.Info().Msgf("%s complete, dir: %s file: %s", mode, dirPath, file)
+}
+
+func backup() error {
+	if _, err := os.Stat(dirPath); err != nil {
+		return err
+	}
+	options := diskv.DefaultOptions
+	options.DirPath = dirPath
+	db, err := diskv.Open(options)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if err := db.Backup(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(file)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func restore() error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return diskv.Restore(f, dirPath)
+}
@@ -0,0 +1,86 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sjy-dv/coltt/diskv"
+	"github.com/sjy-dv/coltt/pkg/wal"
+)
+
+// verify and repair of the diskv files of a data directory.
+// The store must not be opened by a running server.
+//
+//	walcheck -mode verify -dir ./data_dir
+//	walcheck -mode repair -dir ./data_dir
+var (
+	mode    string
+	dirPath string
+)
+
+func main() {
+	flag.StringVar(&mode, "mode", "verify", "verify or repair")
+	flag.StringVar(&dirPath, "dir", "./data_dir", "diskv directory")
+	flag.Parse()
+
+	var (
+		report *wal.VerifyReport
+		err    error
+	)
+	switch mode {
+	case "verify":
+		report, err = diskv.Verify(dirPath)
+	case "repair":
+		report, err = diskv.Repair(dirPath)
+	default:
+		log.Error().Msgf("unknown mode : %s", mode)
+		os.Exit(1)
+	}
+	if report != nil {
+		printReport(report)
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("%s failed", mode)
+		os.Exit(1)
+	}
+	if mode == "verify" && report.Corrupted() {
+		log.Error().Msgf("%s has corrupted files, run with -mode repair", dirPath)
+		os.Exit(2)
+	}
+	log.Info().Msgf("%s complete, dir: %s", mode, dirPath)
+}
+
+func printReport(report *wal.VerifyReport) {
+	for _, seg := range report.Segments {
+		for _, c := range seg.Corruptions {
+			log.Warn().Err(c.Err).Msgf("%s: corrupt chunk at block %d offset %d",
+				seg.Path, c.Position.BlockNumber, c.Position.ChunkOffset)
+		}
+		switch {
+		case seg.Truncated:
+			log.Info().Msgf("%s: truncated from %d to %d bytes", seg.Path, seg.Size, seg.ValidSize)
+		case seg.Quarantined:
+			log.Info().Msgf("%s: quarantined", seg.Path)
+		default:
+			log.Info().Msgf("%s: %d records, %d damaged regions", seg.Path, seg.Records, len(seg.Corruptions))
+		}
+	}
+}
@@ -17,12 +17,15 @@
 
 package core
 
+import "time"
+
 var (
-	ErrCollectionNotFound = "collection: %s not found"
-	panicr                = "panic %v"
-	ErrCollectionExists   = "collection: %s is already exists"
-	ErrCollectionNotLoad  = "collection: %s is not loaded in memory"
-	ErrAlreadyRelease     = "collection: %s is already release"
+	ErrCollectionNotFound  = "collection: %s not found"
+	panicr                 = "panic %v"
+	ErrCollectionExists    = "collection: %s is already exists"
+	ErrCollectionNotLoad   = "collection: %s is not loaded in memory"
+	ErrAlreadyRelease      = "collection: %s is already release"
+	ErrVectorFieldNotFound = "collection: %s has no vector field %s"
 )
 
 const (
@@ -36,11 +39,26 @@ const (
 	BQ_QUANTIZATION   = "binaryQuantization"
 )
 
+// ef scaling per SearchProfile, relative to the collection default ef
+const (
+	// highRecallEfFactor: HIGH_RECALL searches with ef = default ef * 4
+	highRecallEfFactor = 4
+	// balancedEfFactor: BALANCED searches with ef = default ef * 2
+	balancedEfFactor = 2
+	// lowLatencyEfDivisor: LOW_LATENCY searches with ef = max(1, default ef / 2)
+	lowLatencyEfDivisor = 2
+)
+
+// fused vector field search collects topK * fusionCandidateFactor per field
+const fusionCandidateFactor = 2
+
 var (
-	diskRule0   = "%s_archive"
-	diskRule1   = "%s_%d" // save data segments
-	diskRule2   = "%s_"   // find all collection segments data
-	diskColList = "collections"
+	diskRule0      = "%s_archive"
+	diskRule1      = "\xffdataset/%d/%s/%d" // save data segments, see datasetKey
+	diskRule2      = "\xffdataset/%d/%s/"   // find all collection segments data, see datasetPrefix
+	legacyDiskRule = "%s_"                  // data segments saved before diskRule2, moved on load
+	diskColList    = "collections"
+	fieldGraphRule = "%s@%s" // collection@field, snapshot name of a vector field graph
 )
 
 var (
@@ -48,8 +66,24 @@ var (
 	f8QuantizationRule   = "./data_dir/%s.f8.raw"
 	bf16QuantizationRule = "./data_dir/%s.bf16.raw"
 	f16QuantizationRule  = "./data_dir/%s.f16.raw"
+	pqQuantizationRule   = "./data_dir/%s.pq.raw"
+	bqQuantizationRule   = "./data_dir/%s.bq.raw"
 )
 
 var (
 	indexRule = "./data_dir/%s.bin"
+	ttlRule   = "./data_dir/%s.ttl"
 )
+
+// changes retained per followed collection for Subscribe to resume from,
+// kept for changefeedIdle after the last subscriber leaves
+const (
+	changefeedCapacity = 10000
+	changefeedIdle     = 5 * time.Minute
+)
+
+// expired datasets are hidden from search until the reaper removes them
+const expiryReapInterval = time.Minute
+
+// datasets a commit log rebuild reads per snapshot
+const rebuildBatchSize = 1000
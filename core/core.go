@@ -20,19 +20,33 @@ package core
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/sjy-dv/coltt/core/vectorindex"
 	"github.com/sjy-dv/coltt/diskv"
 	"github.com/sjy-dv/coltt/gen/protoc/v3/coreproto"
 	"github.com/sjy-dv/coltt/gen/protoc/v3/diskproto"
+	"github.com/sjy-dv/coltt/pkg/changefeed"
+	"github.com/sjy-dv/coltt/pkg/expiry"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type Core struct {
-	DataStore *autoMap[*vectorindex.Hnsw]
-	CommitLog *diskv.DB
+	DataStore    *autoMap[*vectorindex.Hnsw]
+	VectorFields *autoMap[map[string]*vectorindex.Hnsw]
+	Rebuilds     *autoMap[*rebuildState]
+	Expiries     *autoMap[*expiry.Table]
+	CommitLog    *diskv.DB
+	Feed         *changefeed.Feed
+	stopReaper   chan struct{}
+
+	// graph writes hold the read lock, a rebuild takes the write lock to swap graphs
+	rebuildLock sync.RWMutex
+	// collection name -> *sync.Mutex, serializes the dataset writes of a collection
+	writeLocks sync.Map
 }
 
 func NewCore() (*Core, error) {
@@ -47,13 +61,21 @@ func NewCore() (*Core, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Core{
-		DataStore: NewAutoMap[*vectorindex.Hnsw](),
-		CommitLog: diskdb,
-	}, nil
+	core := &Core{
+		DataStore:    NewAutoMap[*vectorindex.Hnsw](),
+		VectorFields: NewAutoMap[map[string]*vectorindex.Hnsw](),
+		Rebuilds:     NewAutoMap[*rebuildState](),
+		Expiries:     NewAutoMap[*expiry.Table](),
+		CommitLog:    diskdb,
+		Feed:         changefeed.New(changefeedCapacity, changefeedIdle),
+		stopReaper:   make(chan struct{}),
+	}
+	go core.expiryReaper()
+	return core, nil
 }
 
 func (crpc *Core) Close() {
+	close(crpc.stopReaper)
 	if err := crpc.CommitLog.Close(); err != nil {
 		log.Error().Err(err).Msg("diskv :> It did not shut down properly ")
 	} else {
@@ -94,27 +116,35 @@ func (crpc *Core) CreateCollection(ctx context.Context,
 			c <- failFn(fmt.Sprintf(ErrCollectionExists, req.GetCollectionName()))
 			return
 		}
-		distFn, distFnName := protoDistHelper(req.GetDistance())
-		searchAlgo, searchOpts := protoSearchAlgoHelper(req.GetCollectionConfig().GetSearchAlgorithm())
+		if err := vectorFieldsValidHelper(req); err != nil {
+			c <- failFn(err.Error())
+			return
+		}
 
 		// save config
-		diskCol := diskproto.Collection{
-			CollectionName:            req.GetCollectionName(),
-			LevelMultiplier:           req.CollectionConfig.GetLevelMultiplier(),
-			Ef:                        req.CollectionConfig.GetEf(),
-			EfConstruction:            req.CollectionConfig.GetEfConstruction(),
-			M:                         req.CollectionConfig.GetM(),
-			MMax:                      req.CollectionConfig.GetMMax(),
-			MMax0:                     req.CollectionConfig.GetMMax0(),
-			HeuristicExtendCandidates: req.CollectionConfig.GetHeuristicExtendCandidates(),
-			HeuristicKeepPruned:       req.CollectionConfig.GetHeuristicKeepPruned(),
-			SearchAlgorithm:           searchAlgo,
-			VectorDimension:           req.GetVectorDimension(),
-			Distance:                  distFnName,
-			Quantization:              "None", // after update
+		diskCol := archiveCollectionHelper(req.GetCollectionName(), req.GetCollectionConfig(),
+			req.GetVectorDimension(), req.GetDistance(),
+			req.GetCompressionHelper(), req.GetQuantizationConfig())
+		diskCol.DefaultTtlSeconds = req.GetDefaultTtlSeconds()
+		hnsw, err := newHnswHelper(diskCol, req.GetCollectionConfig())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		fields := make(map[string]*vectorindex.Hnsw, len(req.GetVectorFields()))
+		for _, field := range req.GetVectorFields() {
+			fieldCol := archiveCollectionHelper(field.GetName(), field.GetCollectionConfig(),
+				field.GetVectorDimension(), field.GetDistance(),
+				field.GetCompressionHelper(), field.GetQuantizationConfig())
+			fields[field.GetName()], err = newHnswHelper(fieldCol, field.GetCollectionConfig())
+			if err != nil {
+				c <- failFn(err.Error())
+				return
+			}
+			diskCol.VectorFields = append(diskCol.VectorFields, fieldCol)
 		}
 
-		diskBytes, err := proto.Marshal(&diskCol)
+		diskBytes, err := proto.Marshal(diskCol)
 		if err != nil {
 			c <- failFn(err.Error())
 			return
@@ -125,10 +155,9 @@ func (crpc *Core) CreateCollection(ctx context.Context,
 			c <- failFn(err.Error())
 			return
 		}
-		hnsw := vectorindex.NewHnsw(uint(req.GetVectorDimension()),
-			distFn,
-			searchOpts)
 		crpc.DataStore.Set(req.GetCollectionName(), hnsw)
+		crpc.VectorFields.Set(req.GetCollectionName(), fields)
+		crpc.Expiries.Set(req.GetCollectionName(), expiry.NewTable(time.Duration(req.GetDefaultTtlSeconds())*time.Second))
 		err = indexdb.CreateIndex(req.GetCollectionName())
 		if err != nil {
 			crpc.diskClear(req.GetCollectionName())
@@ -180,9 +209,12 @@ func (crpc *Core) DropCollection(ctx context.Context, req *coreproto.CollectionN
 			c <- successFn()
 			return
 		}
+		unlock := crpc.lockCollectionHelper(req.GetCollectionName())
+		defer unlock()
 		crpc.diskClear(req.GetCollectionName())
 		crpc.removeCollection(req.GetCollectionName())
 		stateDestroyHelper(req.GetCollectionName())
+		crpc.Feed.Drop(req.GetCollectionName())
 		c <- successFn()
 	}()
 	res := <-c
@@ -222,20 +254,10 @@ func (crpc *Core) CollectionInfof(ctx context.Context, req *coreproto.Collection
 			c <- failFn(fmt.Sprintf(ErrCollectionNotLoad, req.GetCollectionName()))
 			return
 		}
-		hnsw := crpc.DataStore.Get(req.GetCollectionName())
-
 		c <- reply{
 			Result: &coreproto.CollectionMsg{
 				Status: true,
-				Info: &coreproto.CollectionInfo{
-					CollectionName:    req.GetCollectionName(),
-					CollectionConfig:  reverseConfigHelper(hnsw.Config()),
-					VectorDimension:   hnsw.Dim(),
-					CollectionSize:    fmt.Sprintf("%d bytes", hnsw.BytesSize()),
-					CollectionLength:  uint64(hnsw.Len()),
-					Distance:          reverseprotoDistHelper(hnsw.Distance()),
-					CompressionHelper: coreproto.Quantization_None,
-				},
+				Info:   crpc.collectionInfoHelper(req.GetCollectionName()),
 			},
 		}
 	}()
@@ -272,19 +294,10 @@ func (crpc *Core) LoadCollection(ctx context.Context, req *coreproto.CollectionN
 			return
 		}
 		if alreadyLoadCollection(req.GetCollectionName()) {
-			hnsw := crpc.DataStore.Get(req.GetCollectionName())
 			c <- reply{
 				Result: &coreproto.CollectionMsg{
 					Status: true,
-					Info: &coreproto.CollectionInfo{
-						CollectionName:    req.GetCollectionName(),
-						CollectionConfig:  reverseConfigHelper(hnsw.Config()),
-						VectorDimension:   hnsw.Dim(),
-						CollectionSize:    fmt.Sprintf("%d bytes", hnsw.BytesSize()),
-						CollectionLength:  uint64(hnsw.Len()),
-						Distance:          reverseprotoDistHelper(hnsw.Distance()),
-						CompressionHelper: coreproto.Quantization_None,
-					},
+					Info:   crpc.collectionInfoHelper(req.GetCollectionName()),
 				},
 			}
 			return
@@ -301,12 +314,28 @@ func (crpc *Core) LoadCollection(ctx context.Context, req *coreproto.CollectionN
 			c <- failFn(err.Error())
 			return
 		}
-		err = crpc.snapShotHelper(req.GetCollectionName(), dp.GetVectorDimension(),
-			reversesingleprotoDistHelper(dp.GetDistance()), reverseSearchAlgoHelper(dp.GetSearchAlgorithm()))
+		hnsw, err := crpc.snapShotHelper(req.GetCollectionName(), &dp)
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
+		fields := make(map[string]*vectorindex.Hnsw, len(dp.GetVectorFields()))
+		for _, field := range dp.GetVectorFields() {
+			fields[field.GetCollectionName()], err = crpc.snapShotHelper(
+				fieldGraphName(req.GetCollectionName(), field.GetCollectionName()), field)
+			if err != nil {
+				c <- failFn(err.Error())
+				return
+			}
+		}
+		crpc.DataStore.Set(req.GetCollectionName(), hnsw)
+		crpc.VectorFields.Set(req.GetCollectionName(), fields)
+		err = crpc.expiryLoadHelper(req.GetCollectionName(), &dp)
+		if err != nil {
+			crpc.memFree(req.GetCollectionName())
+			c <- failFn(err.Error())
+			return
+		}
 		err = indexLoadHelper(req.GetCollectionName())
 		if err != nil {
 			crpc.memFree(req.GetCollectionName())
@@ -314,19 +343,10 @@ func (crpc *Core) LoadCollection(ctx context.Context, req *coreproto.CollectionN
 			return
 		}
 		stateTrueHelper(req.GetCollectionName())
-		hnsw := crpc.DataStore.Get(req.GetCollectionName())
 		c <- reply{
 			Result: &coreproto.CollectionMsg{
 				Status: true,
-				Info: &coreproto.CollectionInfo{
-					CollectionName:    req.GetCollectionName(),
-					CollectionConfig:  reverseConfigHelper(hnsw.Config()),
-					VectorDimension:   hnsw.Dim(),
-					CollectionSize:    fmt.Sprintf("%d bytes", hnsw.BytesSize()),
-					CollectionLength:  uint64(hnsw.Len()),
-					Distance:          reverseprotoDistHelper(hnsw.Distance()),
-					CompressionHelper: coreproto.Quantization_None,
-				},
+				Info:   crpc.collectionInfoHelper(req.GetCollectionName()),
 			},
 		}
 	}()
@@ -372,18 +392,32 @@ func (crpc *Core) ReleaseCollection(ctx context.Context, req *coreproto.Collecti
 			}
 			return
 		}
-		err := crpc.createSnapshotHelper(req.GetCollectionName())
+		err := crpc.createSnapshotHelper(req.GetCollectionName(), crpc.DataStore.Get(req.GetCollectionName()))
 		if err != nil {
 			c <- failFn(err.Error())
 			crpc.memFree(req.GetCollectionName())
 			return
 		}
+		for field, hnsw := range crpc.VectorFields.Get(req.GetCollectionName()) {
+			err = crpc.createSnapshotHelper(fieldGraphName(req.GetCollectionName(), field), hnsw)
+			if err != nil {
+				c <- failFn(err.Error())
+				crpc.memFree(req.GetCollectionName())
+				return
+			}
+		}
 		err = indexSaveHelper(req.GetCollectionName())
 		if err != nil {
 			c <- failFn(err.Error())
 			crpc.memFree(req.GetCollectionName())
 			return
 		}
+		err = crpc.expirySaveHelper(req.GetCollectionName())
+		if err != nil {
+			c <- failFn(err.Error())
+			crpc.memFree(req.GetCollectionName())
+			return
+		}
 		stateFalseHelper(req.GetCollectionName())
 		c <- reply{
 			Result: &coreproto.ResponseWithMessage{
@@ -395,6 +429,183 @@ func (crpc *Core) ReleaseCollection(ctx context.Context, req *coreproto.Collecti
 	return res.Result, res.Error
 }
 
+func (crpc *Core) UpdateCollectionConfig(ctx context.Context, req *coreproto.CollectionConfigUpdate) (
+	*coreproto.CollectionMsg, error) {
+	type reply struct {
+		Result *coreproto.CollectionMsg
+		Error  error
+	}
+	c := make(chan reply, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				c <- reply{
+					Error: fmt.Errorf(panicr, r),
+				}
+			}
+		}()
+		failFn := func(errMsg string) reply {
+			return reply{
+				Result: &coreproto.CollectionMsg{
+					Status: false,
+					Error:  errorWrap(errMsg),
+				},
+			}
+		}
+		err := collectionStatusHelper(req.GetCollectionName())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		loadcfg, err := crpc.CommitLog.Get([]byte(fmt.Sprintf(diskRule0, req.GetCollectionName())))
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		dp := diskproto.Collection{}
+		err = proto.Unmarshal(loadcfg, &dp)
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		target, err := archiveTargetHelper(&dp, req.GetVectorField())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		hnsw, err := crpc.vectorGraphHelper(req.GetCollectionName(), req.GetVectorField())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		opts := make([]vectorindex.HnswOption, 0, 4)
+		if req.Ef != nil {
+			if req.GetEf() <= 0 {
+				c <- failFn(fmt.Sprintf("ef must be greater than 0, but got %d", req.GetEf()))
+				return
+			}
+			opts = append(opts, vectorindex.HnswEf(int(req.GetEf())))
+			target.Ef = req.GetEf()
+		}
+		if req.SearchAlgorithm != nil {
+			searchAlgo, searchOpts := protoSearchAlgoHelper(req.GetSearchAlgorithm())
+			opts = append(opts, searchOpts)
+			target.SearchAlgorithm = searchAlgo
+		}
+		if req.HeuristicExtendCandidates != nil {
+			opts = append(opts, vectorindex.HnswHeuristicExtendCandidates(req.GetHeuristicExtendCandidates()))
+			target.HeuristicExtendCandidates = req.GetHeuristicExtendCandidates()
+		}
+		if req.HeuristicKeepPruned != nil {
+			opts = append(opts, vectorindex.HnswHeuristicKeepPruned(req.GetHeuristicKeepPruned()))
+			target.HeuristicKeepPruned = req.GetHeuristicKeepPruned()
+		}
+		diskBytes, err := proto.Marshal(&dp)
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		err = crpc.CommitLog.Put([]byte(fmt.Sprintf(diskRule0, req.GetCollectionName())), diskBytes)
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		hnsw.UpdateConfig(opts...)
+		c <- reply{
+			Result: &coreproto.CollectionMsg{
+				Status: true,
+				Info:   crpc.collectionInfoHelper(req.GetCollectionName()),
+			},
+		}
+	}()
+	res := <-c
+	return res.Result, res.Error
+}
+
+func (crpc *Core) RebuildIndex(ctx context.Context, req *coreproto.RebuildIndexRequest) (
+	*coreproto.ResponseWithMessage, error) {
+	type reply struct {
+		Result *coreproto.ResponseWithMessage
+		Error  error
+	}
+	c := make(chan reply, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				c <- reply{
+					Error: fmt.Errorf(panicr, r),
+				}
+			}
+		}()
+		failFn := func(errMsg string) reply {
+			return reply{
+				Result: &coreproto.ResponseWithMessage{
+					Status: false,
+					Error:  errorWrap(errMsg),
+				},
+			}
+		}
+		err := collectionStatusHelper(req.GetCollectionName())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		prev, err := crpc.vectorGraphHelper(req.GetCollectionName(), req.GetVectorField())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		loadcfg, err := crpc.CommitLog.Get([]byte(fmt.Sprintf(diskRule0, req.GetCollectionName())))
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		dp := diskproto.Collection{}
+		err = proto.Unmarshal(loadcfg, &dp)
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		target, err := archiveTargetHelper(&dp, req.GetVectorField())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		config := req.GetCollectionConfig()
+		if config == nil {
+			config = reverseConfigHelper(prev.Config())
+		}
+		archiveLayoutHelper(target, config)
+		next, err := newHnswHelper(target, config)
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+
+		crpc.rebuildLock.Lock()
+		if state := crpc.Rebuilds.Get(req.GetCollectionName()); state != nil && state.running.Load() {
+			crpc.rebuildLock.Unlock()
+			c <- failFn(fmt.Sprintf("collection: %s is already rebuilding", req.GetCollectionName()))
+			return
+		}
+		state := newRebuildState(req.GetVectorField())
+		crpc.Rebuilds.Set(req.GetCollectionName(), state)
+		crpc.rebuildLock.Unlock()
+
+		go crpc.rebuildHelper(req, config, prev, next, state)
+		c <- reply{
+			Result: &coreproto.ResponseWithMessage{
+				Status:  true,
+				Message: "rebuild started, progress is reported by CollectionInfof",
+			},
+		}
+	}()
+	res := <-c
+	return res.Result, res.Error
+}
+
 func (crpc *Core) Insert(ctx context.Context, req *coreproto.DatasetChange) (
 	*coreproto.Response, error) {
 	type reply struct {
@@ -424,7 +635,9 @@ func (crpc *Core) Insert(ctx context.Context, req *coreproto.DatasetChange) (
 			c <- failFn(err.Error())
 			return
 		}
-		valid := crpc.chkValidDimensionality(req.GetCollectionName(), int32(len(req.GetVector())))
+		unlock := crpc.lockCollectionHelper(req.GetCollectionName())
+		defer unlock()
+		valid := crpc.chkDatasetVectorsHelper(req)
 		if valid != nil {
 			c <- failFn(valid.Error())
 			return
@@ -435,8 +648,7 @@ func (crpc *Core) Insert(ctx context.Context, req *coreproto.DatasetChange) (
 			c <- failFn(err.Error())
 			return
 		}
-		hnsw := crpc.DataStore.Get(req.GetCollectionName())
-		err = hnsw.Insert(autoId, req.GetVector(), cloneMap, hnsw.RandomLevel())
+		err = crpc.insertVectorsHelper(req.GetCollectionName(), autoId, req, cloneMap)
 		if err != nil {
 			c <- failFn(err.Error())
 			return
@@ -446,16 +658,19 @@ func (crpc *Core) Insert(ctx context.Context, req *coreproto.DatasetChange) (
 		diskkv.Metadata = req.GetMetadata()
 		diskkv.UserSpecificId = req.GetId()
 		diskkv.Vector = req.GetVector()
+		diskkv.Vectors = diskVectorsHelper(req)
+		diskkv.ExpireAt = crpc.expireAtHelper(req.GetCollectionName(), req.GetTtlSeconds())
 		diskb, err := proto.Marshal(&diskkv)
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
-		err = crpc.CommitLog.Put([]byte(fmt.Sprintf(diskRule1, req.GetCollectionName(), autoId)), diskb)
+		err = crpc.putDatasetHelper(req.GetCollectionName(), autoId, diskb, diskkv.ExpireAt)
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
+		crpc.publishChangeHelper(req.GetCollectionName(), changefeed.OpInsert, req)
 		c <- reply{
 			Result: &coreproto.Response{Status: true},
 		}
@@ -498,7 +713,9 @@ func (crpc *Core) Update(ctx context.Context, req *coreproto.DatasetChange) (
 			c <- failFn(err.Error(), false)
 			return
 		}
-		valid := crpc.chkValidDimensionality(req.GetCollectionName(), int32(len(req.GetVector())))
+		unlock := crpc.lockCollectionHelper(req.GetCollectionName())
+		defer unlock()
+		valid := crpc.chkDatasetVectorsHelper(req)
 		if valid != nil {
 			c <- failFn(valid.Error(), false)
 			return
@@ -508,18 +725,17 @@ func (crpc *Core) Update(ctx context.Context, req *coreproto.DatasetChange) (
 			c <- failFn("", true)
 			return
 		}
-		hnsw := crpc.DataStore.Get(req.GetCollectionName())
-		vertex, err := hnsw.GetVertex(getId[0])
+		metadata, err := crpc.vertexMetadataHelper(req.GetCollectionName(), getId[0])
 		if err != nil {
 			c <- failFn(err.Error(), false)
 			return
 		}
-		err = indexdb.indexes[req.GetCollectionName()].Remove(getId[0], vertex.Metadata())
+		err = indexdb.indexes[req.GetCollectionName()].Remove(getId[0], metadata)
 		if err != nil {
 			c <- failFn(err.Error(), false)
 			return
 		}
-		err = hnsw.Remove(getId[0])
+		err = crpc.removeVectorsHelper(req.GetCollectionName(), getId[0])
 		if err != nil {
 			c <- failFn(err.Error(), false)
 			return
@@ -529,7 +745,7 @@ func (crpc *Core) Update(ctx context.Context, req *coreproto.DatasetChange) (
 			c <- failFn(err.Error(), false)
 			return
 		}
-		err = hnsw.Insert(getId[0], req.GetVector(), req.GetMetadata().AsMap(), hnsw.RandomLevel())
+		err = crpc.insertVectorsHelper(req.GetCollectionName(), getId[0], req, req.GetMetadata().AsMap())
 		if err != nil {
 			c <- failFn(err.Error(), false)
 			return
@@ -539,16 +755,19 @@ func (crpc *Core) Update(ctx context.Context, req *coreproto.DatasetChange) (
 		diskkv.Metadata = req.GetMetadata()
 		diskkv.UserSpecificId = req.GetId()
 		diskkv.Vector = req.GetVector()
+		diskkv.Vectors = diskVectorsHelper(req)
+		diskkv.ExpireAt = crpc.expireAtHelper(req.GetCollectionName(), req.GetTtlSeconds())
 		diskb, err := proto.Marshal(&diskkv)
 		if err != nil {
 			c <- failFn(err.Error(), false)
 			return
 		}
-		err = crpc.CommitLog.Put([]byte(fmt.Sprintf(diskRule1, req.GetCollectionName(), getId[0])), diskb)
+		err = crpc.putDatasetHelper(req.GetCollectionName(), getId[0], diskb, diskkv.ExpireAt)
 		if err != nil {
 			c <- failFn(err.Error(), false)
 			return
 		}
+		crpc.publishChangeHelper(req.GetCollectionName(), changefeed.OpUpdate, req)
 		c <- reply{
 			Result: &coreproto.Response{
 				Status: true,
@@ -598,32 +817,39 @@ func (crpc *Core) Delete(ctx context.Context, req *coreproto.DatasetChange) (
 			c <- failFn(err.Error())
 			return
 		}
+		unlock := crpc.lockCollectionHelper(req.GetCollectionName())
+		defer unlock()
 		getId := indexdb.indexes[req.GetCollectionName()].PureSearch(map[string]string{"_id": req.GetId()})
 		if len(getId) == 0 {
 			c <- successFn()
 			return
 		}
-		hnsw := crpc.DataStore.Get(req.GetCollectionName())
-		vertex, err := hnsw.GetVertex(getId[0])
+		metadata, err := crpc.vertexMetadataHelper(req.GetCollectionName(), getId[0])
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
-		err = indexdb.indexes[req.GetCollectionName()].Remove(getId[0], vertex.Metadata())
+		err = indexdb.indexes[req.GetCollectionName()].Remove(getId[0], metadata)
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
-		err = hnsw.Remove(getId[0])
+		err = crpc.removeVectorsHelper(req.GetCollectionName(), getId[0])
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
-		err = crpc.CommitLog.Delete([]byte(fmt.Sprintf(diskRule1, req.GetCollectionName(), getId[0])))
+		err = crpc.CommitLog.Delete(datasetKey(req.GetCollectionName(), getId[0]))
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
+		crpc.Expiries.Get(req.GetCollectionName()).Del(getId[0])
+		crpc.Feed.Publish(req.GetCollectionName(), changefeed.Event{
+			Op:       changefeed.OpDelete,
+			Id:       req.GetId(),
+			Metadata: metadata,
+		})
 		c <- successFn()
 	}()
 	res := <-c
@@ -660,13 +886,7 @@ func (crpc *Core) VectorSearch(ctx context.Context, req *coreproto.SearchRequest
 			c <- failFn(err.Error())
 			return
 		}
-		valid := crpc.chkValidDimensionality(req.GetCollectionName(), int32(len(req.GetVector())))
-		if valid != nil {
-			c <- failFn(valid.Error())
-			return
-		}
-		hnsw := crpc.DataStore.Get(req.GetCollectionName())
-		candidates, err := hnsw.Search(context.TODO(), req.GetVector(), uint(req.GetTopK()))
+		candidates, err := crpc.vectorCandidatesHelper(req, uint(req.GetTopK()), nil)
 		if err != nil {
 			c <- failFn(err.Error())
 			return
@@ -680,7 +900,7 @@ func (crpc *Core) VectorSearch(ctx context.Context, req *coreproto.SearchRequest
 				c <- failFn(err.Error())
 				return
 			}
-			n.Score = scoreHelper(candidate.Score, hnsw.Distance())
+			n.Score = candidate.Score
 			resultSet = append(resultSet, n)
 		}
 		c <- reply{
@@ -727,9 +947,13 @@ func (crpc *Core) FilterSearch(ctx context.Context, req *coreproto.SearchRequest
 
 		candidates := indexdb.indexes[req.GetCollectionName()].PureSearch(req.GetFilter())
 		resultSet := make([]*coreproto.Candidates, 0, req.GetTopK())
+		live := crpc.liveHelper(req.GetCollectionName())
 
 		for _, id := range candidates {
-			data, err := crpc.CommitLog.Get([]byte(fmt.Sprintf(diskRule1, req.GetCollectionName(), id)))
+			if live != nil && !live(id) {
+				continue
+			}
+			data, err := crpc.CommitLog.Get(datasetKey(req.GetCollectionName(), id))
 			if err != nil {
 				c <- failFn(err.Error())
 				return
@@ -786,36 +1010,16 @@ func (crpc *Core) HybridSearch(ctx context.Context, req *coreproto.SearchRequest
 			c <- failFn(err.Error())
 			return
 		}
-		valid := crpc.chkValidDimensionality(req.GetCollectionName(), int32(len(req.GetVector())))
-		if valid != nil {
-			c <- failFn(valid.Error())
-			return
-		}
-		hnsw := crpc.DataStore.Get(req.GetCollectionName())
-		candidates, err := hnsw.Search(context.TODO(), req.GetVector(), uint(req.GetTopK()*3))
+		// the planner picks exact scoring, filtered traversal or a post-filter
+		// from the share of the rows matching the filter
+		plan := crpc.filterPlanHelper(req)
+		candidates, err := crpc.vectorCandidatesHelper(req, uint(req.GetTopK()), plan)
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
-		vid := make([]uint64, 0, len(candidates))
-		for _, cc := range candidates {
-			vid = append(vid, cc.Id)
-		}
-		//
-		mergeCandidates := indexdb.indexes[req.GetCollectionName()].SearchWitCandidates(vid, req.GetFilter())
-
-		// find for in for => O(n^2)
-		// in map => space complexity is grow but fast O(n)
 		resultSet := make([]*coreproto.Candidates, 0, req.GetTopK())
-		pos := 1
-		chkmap := make(map[uint64]bool)
-		for _, mc := range mergeCandidates {
-			chkmap[mc] = true
-		}
 		for _, candidate := range candidates {
-			if pos >= int(req.GetTopK()) {
-				break
-			}
 			n := new(coreproto.Candidates)
 			n.Id = candidate.Metadata["_id"].(string)
 			n.Metadata, err = structpb.NewStruct(candidate.Metadata)
@@ -823,14 +1027,14 @@ func (crpc *Core) HybridSearch(ctx context.Context, req *coreproto.SearchRequest
 				c <- failFn(err.Error())
 				return
 			}
-			n.Score = scoreHelper(candidate.Score, hnsw.Distance())
+			n.Score = candidate.Score
 			resultSet = append(resultSet, n)
-			pos++
 		}
 		c <- reply{
 			Result: &coreproto.SearchResponse{
 				Status:     true,
 				Candidates: resultSet,
+				Explain:    explainHelper(plan, int(req.GetTopK())),
 			},
 		}
 	}()
@@ -0,0 +1,114 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/sjy-dv/coltt/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/coltt/pkg/changefeed"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Subscribe streams the insert, update and delete events of a collection.
+// A client resumes after a reconnect by passing the position of the last
+// event it received, the stream fails when that position is no longer held.
+func (crpc *Core) Subscribe(req *coreproto.SubscribeRequest, stream grpc.ServerStreamingServer[coreproto.ChangeEvent]) error {
+	if !hasCollection(req.GetCollectionName()) {
+		return fmt.Errorf(ErrCollectionNotFound, req.GetCollectionName())
+	}
+	sub, err := crpc.Feed.Subscribe(req.GetCollectionName(), req.GetFromPosition())
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+	for {
+		event, err := sub.Next(stream.Context())
+		if err != nil {
+			return err
+		}
+		msg, err := changeEventHelper(event, req.GetWithVector())
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (xx *Core) publishChangeHelper(collectionName string, op changefeed.Op, req *coreproto.DatasetChange) {
+	event := changefeed.Event{
+		Op:       op,
+		Id:       req.GetId(),
+		Metadata: req.GetMetadata().AsMap(),
+		Vector:   req.GetVector(),
+	}
+	if len(req.GetVectors()) > 0 {
+		event.Vectors = make(map[string][]float32, len(req.GetVectors()))
+		for field, vector := range req.GetVectors() {
+			event.Vectors[field] = vector.GetVector()
+		}
+	}
+	xx.Feed.Publish(collectionName, event)
+}
+
+// publishExpiredHelper reports a dataset removed by the expiry reaper
+func (xx *Core) publishExpiredHelper(collectionName string, metadata map[string]interface{}) {
+	event := changefeed.Event{
+		Op:       changefeed.OpDelete,
+		Metadata: metadata,
+	}
+	if id, ok := metadata["_id"]; ok {
+		event.Id = fmt.Sprint(id)
+	}
+	xx.Feed.Publish(collectionName, event)
+}
+
+func changeEventHelper(event changefeed.Event, withVector bool) (*coreproto.ChangeEvent, error) {
+	msg := &coreproto.ChangeEvent{
+		Position: event.Seq,
+		Id:       event.Id,
+	}
+	switch event.Op {
+	case changefeed.OpInsert:
+		msg.ChangeType = coreproto.IndexChangeTypes_INSERT
+	case changefeed.OpUpdate:
+		msg.ChangeType = coreproto.IndexChangeTypes_UPDATE
+	case changefeed.OpDelete:
+		msg.ChangeType = coreproto.IndexChangeTypes_DELETE
+	}
+	if event.Metadata != nil {
+		metadata, err := structpb.NewStruct(event.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		msg.Metadata = metadata
+	}
+	if withVector {
+		msg.Vector = event.Vector
+		if len(event.Vectors) > 0 {
+			msg.Vectors = make(map[string]*coreproto.NamedVector, len(event.Vectors))
+			for field, vector := range event.Vectors {
+				msg.Vectors[field] = &coreproto.NamedVector{Vector: vector}
+			}
+		}
+	}
+	return msg, nil
+}
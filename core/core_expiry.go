@@ -0,0 +1,161 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package core
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/sjy-dv/coltt/gen/protoc/v3/diskproto"
+	"github.com/sjy-dv/coltt/pkg/expiry"
+)
+
+// putDatasetHelper writes the dataset to the commit log, an expiring dataset
+// is written with the remaining ttl so diskv drops it on its own as well.
+// A dataset already expired is deleted instead, the reaper drops it from the graphs.
+func (xx *Core) putDatasetHelper(collectionName string, id uint64, data []byte, expireAt int64) error {
+	key := datasetKey(collectionName, id)
+	var err error
+	if expireAt > 0 {
+		if ttl := time.Until(time.Unix(0, expireAt)); ttl > 0 {
+			err = xx.CommitLog.PutWithTTL(key, data, ttl)
+		} else {
+			err = xx.CommitLog.Delete(key)
+		}
+	} else {
+		err = xx.CommitLog.Put(key, data)
+	}
+	if err != nil {
+		return err
+	}
+	xx.Expiries.Get(collectionName).Set(id, expireAt)
+	return nil
+}
+
+func (xx *Core) expireAtHelper(collectionName string, ttlSeconds uint64) int64 {
+	return xx.Expiries.Get(collectionName).ExpireAt(time.Duration(ttlSeconds) * time.Second)
+}
+
+func (xx *Core) expiredHelper(collectionName string) map[uint64]struct{} {
+	table := xx.Expiries.Get(collectionName)
+	if table == nil {
+		return nil
+	}
+	return table.Expired(time.Now().UnixNano())
+}
+
+// liveHelper returns the filter hiding expired datasets from a search,
+// nil when the collection has no dataset with a ttl
+func (xx *Core) liveHelper(collectionName string) func(id uint64) bool {
+	table := xx.Expiries.Get(collectionName)
+	if table == nil || table.Len() == 0 {
+		return nil
+	}
+	now := time.Now().UnixNano()
+	return func(id uint64) bool {
+		return !table.IsExpired(id, now)
+	}
+}
+
+func (xx *Core) expirySaveHelper(collectionName string) error {
+	data, err := xx.Expiries.Get(collectionName).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fmt.Sprintf(ttlRule, collectionName), data, 0644)
+}
+
+// expiryLoadHelper restores the expiry table, collections saved before ttl
+// support have no table file and start with an empty one
+func (xx *Core) expiryLoadHelper(collectionName string, dp *diskproto.Collection) error {
+	table := expiry.NewTable(time.Duration(dp.GetDefaultTtlSeconds()) * time.Second)
+	data, err := os.ReadFile(fmt.Sprintf(ttlRule, collectionName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		if err := table.UnmarshalBinary(data); err != nil {
+			return err
+		}
+	}
+	xx.Expiries.Set(collectionName, table)
+	return nil
+}
+
+// reapExpiredHelper removes expired datasets from the bitmap index, every
+// vector graph and the commit log. Each dataset is checked again under the
+// collection write lock, an update may have extended its ttl meanwhile.
+func (xx *Core) reapExpiredHelper(collectionName string) error {
+	if !alreadyLoadCollection(collectionName) {
+		return nil
+	}
+	for id := range xx.expiredHelper(collectionName) {
+		if err := xx.reapDatasetHelper(collectionName, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (xx *Core) reapDatasetHelper(collectionName string, id uint64) error {
+	unlock := xx.lockCollectionHelper(collectionName)
+	defer unlock()
+	table := xx.Expiries.Get(collectionName)
+	if table == nil || !table.IsExpired(id, time.Now().UnixNano()) {
+		return nil
+	}
+	metadata, err := xx.vertexMetadataHelper(collectionName, id)
+	if err == nil {
+		if err := indexdb.indexes[collectionName].Remove(id, metadata); err != nil {
+			return err
+		}
+	}
+	if err := xx.removeVectorsHelper(collectionName, id); err != nil {
+		return err
+	}
+	if err := xx.CommitLog.Delete(datasetKey(collectionName, id)); err != nil {
+		return err
+	}
+	table.Del(id)
+	if metadata != nil {
+		xx.publishExpiredHelper(collectionName, metadata)
+	}
+	return nil
+}
+
+func (xx *Core) expiryReaper() {
+	ticker := time.NewTicker(expiryReapInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-xx.stopReaper:
+			return
+		case <-ticker.C:
+			for _, col := range loadedCollectionsHelper() {
+				if err := xx.reapExpiredHelper(col); err != nil {
+					log.Error().Err(err).Msgf("collection: %s reap expired datasets failed", col)
+				}
+			}
+			if err := xx.CommitLog.DeleteExpiredKeys(expiryReapInterval / 2); err != nil {
+				log.Error().Err(err).Msg("diskv :> delete expired keys failed")
+			}
+		}
+	}
+}
@@ -20,15 +20,23 @@ package core
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"os"
+	"strconv"
+	"sync"
 
+	"github.com/rs/zerolog/log"
 	"github.com/sjy-dv/coltt/core/vectorindex"
+	"github.com/sjy-dv/coltt/diskv"
+	"github.com/sjy-dv/coltt/edge"
 	"github.com/sjy-dv/coltt/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/coltt/gen/protoc/v3/diskproto"
 	"github.com/sjy-dv/coltt/pkg/distance"
 	"github.com/sjy-dv/coltt/pkg/index"
 	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
 )
 
 func errorWrap(errMsg string) *coreproto.Error {
@@ -39,22 +47,132 @@ func errorWrap(errMsg string) *coreproto.Error {
 }
 
 func (xx *Core) diskClear(collectionName string) {
-	//delete disk config
-	configKey := fmt.Sprintf(diskRule0, collectionName)
 	xx.DataStore.Del(collectionName)
-	xx.CommitLog.Delete([]byte(configKey))
-	xx.CommitLog.AscendKeys([]byte(fmt.Sprintf(diskRule2, collectionName)),
-		true, func(k []byte) (bool, error) {
-			err := xx.CommitLog.Delete(k)
-			if err != nil {
-				// after code
+	xx.VectorFields.Del(collectionName)
+	xx.Rebuilds.Del(collectionName)
+	xx.Expiries.Del(collectionName)
+	os.Remove(fmt.Sprintf(ttlRule, collectionName))
+	if err := xx.datasetClearHelper(collectionName); err != nil {
+		log.Error().Err(err).Msgf("collection: %s clear disk datasets failed", collectionName)
+	}
+}
+
+// datasetClearHelper deletes every data segment of the collection with a
+// single prefix tombstone, then its disk config (diskRule0).
+func (xx *Core) datasetClearHelper(collectionName string) error {
+	if err := xx.CommitLog.DeletePrefix(datasetPrefix(collectionName)); err != nil {
+		return err
+	}
+	return xx.CommitLog.Delete([]byte(fmt.Sprintf(diskRule0, collectionName)))
+}
+
+// datasetPrefix is the key prefix of the data segments of a collection.
+// The \xff lead byte can't start a valid UTF-8 collection name, and the name
+// length tells apart collections whose names prefix each other, so the prefix
+// of a collection holds its data segments only.
+func datasetPrefix(collectionName string) []byte {
+	return []byte(fmt.Sprintf(diskRule2, len(collectionName), collectionName))
+}
+
+// datasetKey is the key of a data segment of the collection
+func datasetKey(collectionName string, id uint64) []byte {
+	return []byte(fmt.Sprintf(diskRule1, len(collectionName), collectionName, id))
+}
+
+// migrateDatasetKeysHelper moves the data segments saved under the
+// legacyDiskRule keys to datasetKey, in batches of rebuildBatchSize.
+// The ttl of an expiring segment is kept.
+func (xx *Core) migrateDatasetKeysHelper(collectionName string) error {
+	legacy := []byte(fmt.Sprintf(legacyDiskRule, collectionName))
+	seek := legacy
+	for seek != nil {
+		keys, after, err := xx.legacyDatasetKeysHelper(legacy, seek, rebuildBatchSize)
+		if err != nil {
+			return err
+		}
+		batch := xx.CommitLog.NewBatch(diskv.DefaultBatchOptions)
+		for _, key := range keys {
+			id, _ := strconv.ParseUint(string(key[len(legacy):]), 10, 64)
+			if err := moveDatasetKeyHelper(batch, key, datasetKey(collectionName, id)); err != nil {
+				_ = batch.Rollback()
+				return err
 			}
-			return true, nil
-		})
+		}
+		if err := batch.Commit(); err != nil {
+			return err
+		}
+		seek = after
+	}
+	return nil
+}
+
+// legacyDatasetKeysHelper returns up to n legacy data segment keys from seek
+// on, and the key to seek the next batch from, nil after the last one
+func (xx *Core) legacyDatasetKeysHelper(legacy, seek []byte, n int) ([][]byte, []byte, error) {
+	iter, err := xx.CommitLog.NewIterator(diskv.IteratorOptions{Prefix: legacy})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer iter.Close()
+	keys := make([][]byte, 0, n)
+	for iter.Seek(seek); iter.Valid(); iter.Next() {
+		if len(keys) == n {
+			return keys, bytes.Clone(iter.Key()), nil
+		}
+		// archive key and the keys of other collections sharing the prefix
+		if isDatasetKey(legacy, iter.Key()) {
+			keys = append(keys, bytes.Clone(iter.Key()))
+		}
+	}
+	return keys, nil, iter.Err()
+}
+
+func moveDatasetKeyHelper(batch *diskv.Batch, from, to []byte) error {
+	data, err := batch.Get(from)
+	if errors.Is(err, diskv.ErrKeyNotFound) {
+		// expired since it was listed
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	ttl, err := batch.TTL(from)
+	if errors.Is(err, diskv.ErrKeyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if ttl < 0 {
+		err = batch.Put(to, data)
+	} else {
+		err = batch.PutWithTTL(to, data, ttl)
+	}
+	if err != nil {
+		return err
+	}
+	return batch.Delete(from)
+}
+
+// isDatasetKey reports whether a key under the legacyDiskRule prefix of a
+// collection is one of its data segments, not its archive key or a key of
+// another collection
+func isDatasetKey(prefix, key []byte) bool {
+	_, err := strconv.ParseUint(string(key[len(prefix):]), 10, 64)
+	return err == nil
+}
+
+// lockCollectionHelper takes the write lock of the collection and returns its unlock
+func (xx *Core) lockCollectionHelper(collectionName string) func() {
+	mu, _ := xx.writeLocks.LoadOrStore(collectionName, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
 }
 
 func (xx *Core) memFree(collectionName string) {
 	xx.DataStore.Del(collectionName)
+	xx.VectorFields.Del(collectionName)
+	xx.Expiries.Del(collectionName)
 
 	indexdb.indexLock.Lock()
 	delete(indexdb.indexes, collectionName)
@@ -65,29 +183,36 @@ func (xx *Core) memFree(collectionName string) {
 	stateManager.auth.authLock.Unlock()
 }
 
-func (xx *Core) createSnapshotHelper(collectionName string) error {
+func (xx *Core) createSnapshotHelper(graphName string, index *vectorindex.Hnsw) error {
 	var buf bytes.Buffer
-	index := xx.DataStore.Get(collectionName)
 	err := index.Commit(&buf, true)
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(fmt.Sprintf(noQuantizationRule, collectionName), buf.Bytes(), 0644)
+	return os.WriteFile(fmt.Sprintf(snapshotRuleHelper(index.Quantization()), graphName), buf.Bytes(), 0644)
 }
 
-func (xx *Core) snapShotHelper(collectionName string, dim uint32, dist distance.Space, searchOpts vectorindex.HnswOption) error {
-	data, err := os.ReadFile(fmt.Sprintf(noQuantizationRule, collectionName))
+func (xx *Core) snapShotHelper(graphName string, dp *diskproto.Collection) (*vectorindex.Hnsw, error) {
+	data, err := os.ReadFile(fmt.Sprintf(snapshotRuleHelper(dp.GetQuantization()), graphName))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	buf := bytes.NewBuffer(data)
-	hnsw := vectorindex.NewHnsw(uint(dim), dist, searchOpts)
+	opts := []vectorindex.HnswOption{}
+	quantizer, err := quantizerHelper(dp)
+	if err != nil {
+		return nil, err
+	}
+	if quantizer != nil {
+		opts = append(opts, vectorindex.HnswQuantization(quantizer))
+	}
+	hnsw := vectorindex.NewHnsw(uint(dp.GetVectorDimension()), reversesingleprotoDistHelper(dp.GetDistance()), opts...)
 	err = hnsw.Load(buf, true)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	xx.DataStore.Set(collectionName, hnsw)
-	return nil
+	hnsw.UpdateConfig(archiveConfigHelper(dp)...)
+	return hnsw, nil
 }
 
 func protoDistHelper(dist coreproto.Distance) (distance.Space, string) {
@@ -111,6 +236,105 @@ func reversesingleprotoDistHelper(dist string) distance.Space {
 	return distance.NewEuclidean()
 }
 
+func protoQuantizationHelper(quantization coreproto.Quantization) string {
+	switch quantization {
+	case coreproto.Quantization_F16:
+		return F16_QUANTIZATION
+	case coreproto.Quantization_BF16:
+		return BF16_QUANTIZATION
+	case coreproto.Quantization_F8:
+		return F8_QUANTIZATION
+	case coreproto.Quantization_PQ:
+		return PQ_QUANTIZATION
+	case coreproto.Quantization_BQ:
+		return BQ_QUANTIZATION
+	}
+	return NONE_QAUNTIZATION
+}
+
+func reverseQuantizationHelper(quantization string) coreproto.Quantization {
+	switch quantization {
+	case F16_QUANTIZATION:
+		return coreproto.Quantization_F16
+	case BF16_QUANTIZATION:
+		return coreproto.Quantization_BF16
+	case F8_QUANTIZATION:
+		return coreproto.Quantization_F8
+	case PQ_QUANTIZATION:
+		return coreproto.Quantization_PQ
+	case BQ_QUANTIZATION:
+		return coreproto.Quantization_BQ
+	}
+	return coreproto.Quantization_None
+}
+
+// quantizerHelper builds the vertex quantizer saved in the collection archive, nil for raw vectors
+func quantizerHelper(dp *diskproto.Collection) (vectorindex.Quantizer, error) {
+	switch dp.GetQuantization() {
+	case F16_QUANTIZATION:
+		return vectorindex.NewFloat16Quantizer(int(dp.GetVectorDimension())), nil
+	case BF16_QUANTIZATION:
+		return vectorindex.NewBFloat16Quantizer(int(dp.GetVectorDimension())), nil
+	case F8_QUANTIZATION:
+		return vectorindex.NewFloat8Quantizer(int(dp.GetVectorDimension())), nil
+	case PQ_QUANTIZATION:
+		return vectorindex.NewProductQuantizer(int(dp.GetVectorDimension()),
+			int(dp.GetPqSubspaces()), int(dp.GetPqCentroids()), int(dp.GetPqTrainSize()))
+	case BQ_QUANTIZATION:
+		return vectorindex.NewBinaryQuantizer(int(dp.GetVectorDimension())), nil
+	}
+	return nil, nil
+}
+
+func snapshotRuleHelper(quantization string) string {
+	switch quantization {
+	case F16_QUANTIZATION:
+		return f16QuantizationRule
+	case BF16_QUANTIZATION:
+		return bf16QuantizationRule
+	case F8_QUANTIZATION:
+		return f8QuantizationRule
+	case PQ_QUANTIZATION:
+		return pqQuantizationRule
+	case BQ_QUANTIZATION:
+		return bqQuantizationRule
+	}
+	return noQuantizationRule
+}
+
+// rerankFetchHelper reads the raw vector of a vertex back from the commit log,
+// datasets deleted or expired since the search are reported as not found
+func (xx *Core) rerankFetchHelper(collectionName, field string) func(id uint64) (edge.Vector, error) {
+	return func(id uint64) (edge.Vector, error) {
+		data, err := xx.CommitLog.Get(datasetKey(collectionName, id))
+		if errors.Is(err, diskv.ErrKeyNotFound) {
+			return nil, vectorindex.ItemNotFoundError
+		}
+		if err != nil {
+			return nil, err
+		}
+		dec := diskproto.Dataset{}
+		if err := proto.Unmarshal(data, &dec); err != nil {
+			return nil, err
+		}
+		if field != "" {
+			return dec.GetVectors()[field].GetVector(), nil
+		}
+		return dec.GetVector(), nil
+	}
+}
+
+func (xx *Core) searchOptionsHelper(req *coreproto.SearchRequest, field string, hnsw *vectorindex.Hnsw) []vectorindex.HnswSearchOption {
+	opts := []vectorindex.HnswSearchOption{
+		vectorindex.SearchEf(searchEfHelper(req, hnsw.Config().Ef)),
+	}
+	if req.GetRerankCandidates() > 0 && hnsw.Quantization() != NONE_QAUNTIZATION {
+		opts = append(opts, vectorindex.SearchRerank(int(req.GetRerankCandidates()),
+			xx.rerankFetchHelper(req.GetCollectionName(), field)))
+	}
+	return opts
+}
+
 func protoSearchAlgoHelper(algo coreproto.SearchAlgorithm) (string, vectorindex.HnswOption) {
 	if algo == coreproto.SearchAlgorithm_Simple {
 		return "simple", vectorindex.HnswSearchAlgorithm(vectorindex.HnswSearchSimple)
@@ -125,6 +349,65 @@ func reverseSearchAlgoHelper(alg string) vectorindex.HnswOption {
 	return vectorindex.HnswSearchAlgorithm(vectorindex.HnswSearchHeuristic)
 }
 
+func protoConfigHelper(config *coreproto.HnswConfig) []vectorindex.HnswOption {
+	opts := make([]vectorindex.HnswOption, 0)
+	if config.GetEf() > 0 {
+		opts = append(opts, vectorindex.HnswEf(int(config.GetEf())))
+	}
+	if config.GetEfConstruction() > 0 {
+		opts = append(opts, vectorindex.HnswEfConstruction(int(config.GetEfConstruction())))
+	}
+	if config.GetM() > 0 {
+		opts = append(opts, vectorindex.HnswM(int(config.GetM())))
+	}
+	if config.GetMMax() > 0 {
+		opts = append(opts, vectorindex.HnswMmax(int(config.GetMMax())))
+	}
+	if config.GetMMax0() > 0 {
+		opts = append(opts, vectorindex.HnswMmax0(int(config.GetMMax0())))
+	}
+	if config.GetLevelMultiplier() > 0 {
+		opts = append(opts, vectorindex.HnswLevelMultiplier(config.GetLevelMultiplier()))
+	}
+	if config != nil {
+		opts = append(opts,
+			vectorindex.HnswHeuristicExtendCandidates(config.GetHeuristicExtendCandidates()),
+			vectorindex.HnswHeuristicKeepPruned(config.GetHeuristicKeepPruned()))
+	}
+	return opts
+}
+
+// archiveConfigHelper returns the search settings of the archive, applied over
+// the snapshot header on load. The heuristic flags are not in the header, and
+// UpdateCollectionConfig archives ef and the search algorithm without writing
+// a new snapshot.
+func archiveConfigHelper(dp *diskproto.Collection) []vectorindex.HnswOption {
+	opts := []vectorindex.HnswOption{
+		reverseSearchAlgoHelper(dp.GetSearchAlgorithm()),
+		vectorindex.HnswHeuristicExtendCandidates(dp.GetHeuristicExtendCandidates()),
+		vectorindex.HnswHeuristicKeepPruned(dp.GetHeuristicKeepPruned()),
+	}
+	if dp.GetEf() > 0 {
+		opts = append(opts, vectorindex.HnswEf(int(dp.GetEf())))
+	}
+	return opts
+}
+
+func searchEfHelper(req *coreproto.SearchRequest, defaultEf int) int {
+	if req.GetEf() > 0 {
+		return int(req.GetEf())
+	}
+	switch req.GetProfile() {
+	case coreproto.SearchProfile_HIGH_RECALL:
+		return defaultEf * highRecallEfFactor
+	case coreproto.SearchProfile_BALANCED:
+		return defaultEf * balancedEfFactor
+	case coreproto.SearchProfile_LOW_LATENCY:
+		return max(1, defaultEf/lowLatencyEfDivisor)
+	}
+	return defaultEf
+}
+
 func reverseConfigHelper(config vectorindex.ProtoConfig) *coreproto.HnswConfig {
 	return &coreproto.HnswConfig{
 		SearchAlgorithm: func() coreproto.SearchAlgorithm {
@@ -235,16 +518,18 @@ func collectionStatusHelper(collectionName string) error {
 }
 
 func (xx *Core) rollbackForConsistentHelper(collectionName string, commitId uint64, metadata map[string]interface{}) {
-	if err := xx.CommitLog.Delete([]byte(fmt.Sprintf(diskRule1, collectionName, commitId))); err != nil {
+	if err := xx.CommitLog.Delete(datasetKey(collectionName, commitId)); err != nil {
 		// using log after
 	}
-	hnsw := xx.DataStore.Get(collectionName)
-	if err := hnsw.Remove(commitId); err != nil {
+	if err := xx.removeVectorsHelper(collectionName, commitId); err != nil {
 		//
 	}
 	if err := indexdb.indexes[collectionName].Remove(commitId, metadata); err != nil {
 		//
 	}
+	if table := xx.Expiries.Get(collectionName); table != nil {
+		table.Del(commitId)
+	}
 }
 
 func scoreHelper(score float32, dist string) float32 {
@@ -338,6 +623,9 @@ func (xx *Core) RegistCollectionStManager() error {
 		return err
 	}
 	for _, col := range data {
+		if err := xx.migrateDatasetKeysHelper(col); err != nil {
+			return err
+		}
 		stateRegistHelper(col)
 	}
 	return nil
@@ -354,8 +642,11 @@ func (xx *Core) exitSnapshot() error {
 	return nil
 }
 
-func (xx *Core) chkValidDimensionality(collectionName string, dim int32) error {
-	collection := xx.DataStore.Get(collectionName)
+func (xx *Core) chkValidDimensionality(collectionName, field string, dim int32) error {
+	collection, err := xx.vectorGraphHelper(collectionName, field)
+	if err != nil {
+		return err
+	}
 	if collection.Dim() != uint32(dim) {
 		return fmt.Errorf("Err Collection %s expects %d dimensions, but has %d dimensions", collectionName, collection.Dim(), dim)
 	}
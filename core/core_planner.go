@@ -0,0 +1,71 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package core
+
+import (
+	roaring "github.com/RoaringBitmap/roaring/v2/roaring64"
+	"github.com/sjy-dv/coltt/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/coltt/pkg/planner"
+)
+
+// filterPlan is the plan of a filtered search with the rows its filter matches
+type filterPlan struct {
+	planner.Plan
+	matched *roaring.Bitmap
+}
+
+// filterPlanHelper plans the search from the filter bitmap, nil without a filter.
+// The matching rows count the expired datasets not reaped yet, the search
+// itself hides them.
+func (xx *Core) filterPlanHelper(req *coreproto.SearchRequest) *filterPlan {
+	if len(req.GetFilter()) == 0 {
+		return nil
+	}
+	matched := indexdb.indexes[req.GetCollectionName()].FilterBitmap(req.GetFilter())
+	return &filterPlan{
+		Plan:    planner.Choose(matched.GetCardinality(), xx.datasetCountHelper(req.GetCollectionName()), true),
+		matched: matched,
+	}
+}
+
+// datasetCountHelper estimates the datasets of the collection by its largest graph,
+// a dataset may leave out some of the vector fields
+func (xx *Core) datasetCountHelper(collectionName string) uint64 {
+	count := xx.DataStore.Get(collectionName).Len()
+	for _, hnsw := range xx.VectorFields.Get(collectionName) {
+		count = max(count, hnsw.Len())
+	}
+	return uint64(count)
+}
+
+func explainHelper(plan *filterPlan, k int) *coreproto.QueryPlan {
+	if plan == nil {
+		return nil
+	}
+	explain := &coreproto.QueryPlan{
+		Strategy:    plan.Strategy.String(),
+		Filtered:    plan.Filtered,
+		Total:       plan.Total,
+		Selectivity: plan.Selectivity,
+		Detail:      plan.String(),
+	}
+	if plan.Strategy != planner.Exact {
+		explain.Fetch = uint64(plan.Fetch(k))
+	}
+	return explain
+}
@@ -0,0 +1,275 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sjy-dv/coltt/core/vectorindex"
+	"github.com/sjy-dv/coltt/diskv"
+	"github.com/sjy-dv/coltt/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/coltt/gen/protoc/v3/diskproto"
+	"google.golang.org/protobuf/proto"
+)
+
+// rebuildState tracks a background graph rebuild. Graph writes made while
+// the new graph is built are recorded in touched and replayed before the swap.
+type rebuildState struct {
+	field     string
+	running   atomic.Bool
+	processed atomic.Uint64
+	total     atomic.Uint64
+
+	lock    sync.Mutex
+	touched map[uint64]struct{}
+	err     string
+}
+
+func newRebuildState(field string) *rebuildState {
+	state := &rebuildState{
+		field:   field,
+		touched: make(map[uint64]struct{}),
+	}
+	state.running.Store(true)
+	return state
+}
+
+func (xx *rebuildState) touch(id uint64) {
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+	xx.touched[id] = struct{}{}
+}
+
+func (xx *rebuildState) fail(err error) {
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+	xx.err = err.Error()
+	xx.running.Store(false)
+}
+
+func (xx *rebuildState) progress() *coreproto.RebuildProgress {
+	xx.lock.Lock()
+	defer xx.lock.Unlock()
+	return &coreproto.RebuildProgress{
+		Running:     xx.running.Load(),
+		VectorField: xx.field,
+		Processed:   xx.processed.Load(),
+		Total:       xx.total.Load(),
+		Error:       xx.err,
+	}
+}
+
+// rebuildTouchHelper records a graph write for a running rebuild, callers hold rebuildLock.RLock
+func (xx *Core) rebuildTouchHelper(collectionName string, id uint64) {
+	state := xx.Rebuilds.Get(collectionName)
+	if state != nil && state.running.Load() {
+		state.touch(id)
+	}
+}
+
+func (xx *Core) rebuildProgressHelper(collectionName string) *coreproto.RebuildProgress {
+	state := xx.Rebuilds.Get(collectionName)
+	if state == nil {
+		return nil
+	}
+	return state.progress()
+}
+
+// archiveTargetHelper returns the archive entry of the default vector or of a named vector field
+func archiveTargetHelper(dp *diskproto.Collection, field string) (*diskproto.Collection, error) {
+	if field == "" {
+		return dp, nil
+	}
+	for _, fieldCol := range dp.GetVectorFields() {
+		if fieldCol.GetCollectionName() == field {
+			return fieldCol, nil
+		}
+	}
+	return nil, fmt.Errorf(ErrVectorFieldNotFound, dp.GetCollectionName(), field)
+}
+
+// archiveLayoutHelper copies the hnsw parameters of a rebuilt graph into its archive entry
+func archiveLayoutHelper(target *diskproto.Collection, config *coreproto.HnswConfig) {
+	searchAlgo, _ := protoSearchAlgoHelper(config.GetSearchAlgorithm())
+	target.LevelMultiplier = config.GetLevelMultiplier()
+	target.Ef = config.GetEf()
+	target.EfConstruction = config.GetEfConstruction()
+	target.M = config.GetM()
+	target.MMax = config.GetMMax()
+	target.MMax0 = config.GetMMax0()
+	target.HeuristicExtendCandidates = config.GetHeuristicExtendCandidates()
+	target.HeuristicKeepPruned = config.GetHeuristicKeepPruned()
+	target.SearchAlgorithm = searchAlgo
+}
+
+func (xx *Core) rebuildHelper(req *coreproto.RebuildIndexRequest, config *coreproto.HnswConfig,
+	prev, next *vectorindex.Hnsw, state *rebuildState) {
+	defer func() {
+		if r := recover(); r != nil {
+			state.fail(fmt.Errorf(panicr, r))
+		}
+	}()
+	var err error
+	if req.GetSource() == coreproto.RebuildSource_COMMIT_LOG {
+		err = xx.rebuildFromCommitLogHelper(req.GetCollectionName(), req.GetVectorField(), prev, next, state)
+	} else {
+		err = rebuildFromGraphHelper(prev, next, state)
+	}
+	if err != nil {
+		state.fail(err)
+		return
+	}
+
+	xx.rebuildLock.Lock()
+	defer xx.rebuildLock.Unlock()
+
+	current, err := xx.vectorGraphHelper(req.GetCollectionName(), req.GetVectorField())
+	if !alreadyLoadCollection(req.GetCollectionName()) || err != nil || current != prev {
+		state.fail(errors.New("collection was released while rebuilding"))
+		return
+	}
+	// writes made during the build are read back from the live graph
+	for id := range state.touched {
+		if err := next.Remove(id); err != nil && !errors.Is(err, vectorindex.ItemNotFoundError) {
+			state.fail(err)
+			return
+		}
+		vector, metadata, err := prev.VertexVector(id)
+		if err != nil {
+			continue
+		}
+		if err := next.Insert(id, vector, metadata, next.RandomLevel()); err != nil {
+			state.fail(err)
+			return
+		}
+	}
+
+	loadcfg, err := xx.CommitLog.Get([]byte(fmt.Sprintf(diskRule0, req.GetCollectionName())))
+	if err != nil {
+		state.fail(err)
+		return
+	}
+	dp := diskproto.Collection{}
+	if err := proto.Unmarshal(loadcfg, &dp); err != nil {
+		state.fail(err)
+		return
+	}
+	target, err := archiveTargetHelper(&dp, req.GetVectorField())
+	if err != nil {
+		state.fail(err)
+		return
+	}
+	archiveLayoutHelper(target, config)
+	diskBytes, err := proto.Marshal(&dp)
+	if err != nil {
+		state.fail(err)
+		return
+	}
+	if err := xx.CommitLog.Put([]byte(fmt.Sprintf(diskRule0, req.GetCollectionName())), diskBytes); err != nil {
+		state.fail(err)
+		return
+	}
+
+	if req.GetVectorField() == "" {
+		xx.DataStore.Set(req.GetCollectionName(), next)
+	} else {
+		// readers range over the field map without a lock, swap a copy
+		fields := xx.VectorFields.Get(req.GetCollectionName())
+		swapped := make(map[string]*vectorindex.Hnsw, len(fields))
+		for name, hnsw := range fields {
+			swapped[name] = hnsw
+		}
+		swapped[req.GetVectorField()] = next
+		xx.VectorFields.Set(req.GetCollectionName(), swapped)
+	}
+	state.running.Store(false)
+}
+
+func rebuildFromGraphHelper(prev, next *vectorindex.Hnsw, state *rebuildState) error {
+	ids := prev.Ids()
+	state.total.Store(uint64(len(ids)))
+	for _, id := range ids {
+		vector, metadata, err := prev.VertexVector(id)
+		if err == nil {
+			if err := next.Insert(id, vector, metadata, next.RandomLevel()); err != nil {
+				return err
+			}
+		}
+		state.processed.Add(1)
+	}
+	return nil
+}
+
+// rebuildFromCommitLogHelper reads the datasets of the collection in batches,
+// each from a fresh snapshot of the commit log, so the build doesn't keep
+// the data files a merge replaces open for longer than a batch
+func (xx *Core) rebuildFromCommitLogHelper(collectionName, field string, prev, next *vectorindex.Hnsw, state *rebuildState) error {
+	state.total.Store(uint64(prev.Len()))
+	prefix := datasetPrefix(collectionName)
+	// writes after a batch is read are replayed from the touched ids
+	seek := prefix
+	for seek != nil {
+		datasets, after, err := xx.datasetBatchHelper(prefix, seek, rebuildBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, dec := range datasets {
+			vector := dec.GetVector()
+			if field != "" {
+				vector = dec.GetVectors()[field].GetVector()
+			}
+			if len(vector) == 0 {
+				continue
+			}
+			if err := next.Insert(dec.GetCollectionUniqueId(), vector, dec.GetMetadata().AsMap(), next.RandomLevel()); err != nil {
+				return err
+			}
+			// total is the graph length at start, the commit log may grow meanwhile
+			if processed := state.processed.Add(1); processed > state.total.Load() {
+				state.total.Store(processed)
+			}
+		}
+		seek = after
+	}
+	return nil
+}
+
+// datasetBatchHelper reads up to n datasets of the prefix from seek on, it
+// returns the key to seek the next batch from, nil after the last one
+func (xx *Core) datasetBatchHelper(prefix, seek []byte, n int) ([]*diskproto.Dataset, []byte, error) {
+	iter, err := xx.CommitLog.NewIterator(diskv.IteratorOptions{Prefix: prefix})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer iter.Close()
+	datasets := make([]*diskproto.Dataset, 0, n)
+	for iter.Seek(seek); iter.Valid(); iter.Next() {
+		if len(datasets) == n {
+			return datasets, append([]byte(nil), iter.Key()...), nil
+		}
+		dec := &diskproto.Dataset{}
+		if err := proto.Unmarshal(iter.Value(), dec); err != nil {
+			return nil, nil, err
+		}
+		datasets = append(datasets, dec)
+	}
+	return datasets, nil, iter.Err()
+}
@@ -58,3 +58,15 @@ func alreadyLoadCollection(collectionName string) bool {
 	defer stateManager.auth.authLock.RUnlock()
 	return stateManager.auth.collections[collectionName]
 }
+
+func loadedCollectionsHelper() []string {
+	stateManager.auth.authLock.RLock()
+	defer stateManager.auth.authLock.RUnlock()
+	collections := make([]string, 0, len(stateManager.auth.collections))
+	for col, ok := range stateManager.auth.collections {
+		if ok {
+			collections = append(collections, col)
+		}
+	}
+	return collections
+}
@@ -0,0 +1,318 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/sjy-dv/coltt/core/vectorindex"
+	"github.com/sjy-dv/coltt/gen/protoc/v3/coreproto"
+	"github.com/sjy-dv/coltt/gen/protoc/v3/diskproto"
+	"github.com/sjy-dv/coltt/pkg/planner"
+)
+
+// fieldGraphName is the snapshot name of a named vector field graph
+func fieldGraphName(collectionName, field string) string {
+	return fmt.Sprintf(fieldGraphRule, collectionName, field)
+}
+
+func vectorFieldsValidHelper(req *coreproto.CollectionSpec) error {
+	if req.GetVectorDimension() == 0 {
+		if len(req.GetVectorFields()) == 0 {
+			return fmt.Errorf("collection: %s needs vector_dimension or vector_fields", req.GetCollectionName())
+		}
+		if req.GetCompressionHelper() != coreproto.Quantization_None {
+			return fmt.Errorf("collection: %s compression_helper needs vector_dimension", req.GetCollectionName())
+		}
+	}
+	seen := make(map[string]bool, len(req.GetVectorFields()))
+	for _, field := range req.GetVectorFields() {
+		if field.GetName() == "" {
+			return fmt.Errorf("collection: %s vector field name is empty", req.GetCollectionName())
+		}
+		if seen[field.GetName()] {
+			return fmt.Errorf("collection: %s vector field %s is duplicated", req.GetCollectionName(), field.GetName())
+		}
+		if field.GetVectorDimension() == 0 {
+			return fmt.Errorf("collection: %s vector field %s needs vector_dimension", req.GetCollectionName(), field.GetName())
+		}
+		seen[field.GetName()] = true
+	}
+	return nil
+}
+
+func archiveCollectionHelper(name string, config *coreproto.HnswConfig, dim uint32, dist coreproto.Distance,
+	quantization coreproto.Quantization, quantizationConfig *coreproto.QuantizationConfig) *diskproto.Collection {
+	searchAlgo, _ := protoSearchAlgoHelper(config.GetSearchAlgorithm())
+	_, distFnName := protoDistHelper(dist)
+	return &diskproto.Collection{
+		CollectionName:            name,
+		LevelMultiplier:           config.GetLevelMultiplier(),
+		Ef:                        config.GetEf(),
+		EfConstruction:            config.GetEfConstruction(),
+		M:                         config.GetM(),
+		MMax:                      config.GetMMax(),
+		MMax0:                     config.GetMMax0(),
+		HeuristicExtendCandidates: config.GetHeuristicExtendCandidates(),
+		HeuristicKeepPruned:       config.GetHeuristicKeepPruned(),
+		SearchAlgorithm:           searchAlgo,
+		VectorDimension:           dim,
+		Distance:                  distFnName,
+		Quantization:              protoQuantizationHelper(quantization),
+		PqSubspaces:               quantizationConfig.GetPqSubspaces(),
+		PqCentroids:               quantizationConfig.GetPqCentroids(),
+		PqTrainSize:               quantizationConfig.GetPqTrainSize(),
+	}
+}
+
+// newHnswHelper builds an empty graph for the archived collection or vector field
+func newHnswHelper(dp *diskproto.Collection, config *coreproto.HnswConfig) (*vectorindex.Hnsw, error) {
+	quantizer, err := quantizerHelper(dp)
+	if err != nil {
+		return nil, err
+	}
+	hnswOpts := append(protoConfigHelper(config), reverseSearchAlgoHelper(dp.GetSearchAlgorithm()))
+	if quantizer != nil {
+		hnswOpts = append(hnswOpts, vectorindex.HnswQuantization(quantizer))
+	}
+	return vectorindex.NewHnsw(uint(dp.GetVectorDimension()),
+		reversesingleprotoDistHelper(dp.GetDistance()),
+		hnswOpts...), nil
+}
+
+// vectorGraphHelper returns the graph of a named vector field, the default graph for an empty name
+func (xx *Core) vectorGraphHelper(collectionName, field string) (*vectorindex.Hnsw, error) {
+	if field == "" {
+		return xx.DataStore.Get(collectionName), nil
+	}
+	hnsw, ok := xx.VectorFields.Get(collectionName)[field]
+	if !ok {
+		return nil, fmt.Errorf(ErrVectorFieldNotFound, collectionName, field)
+	}
+	return hnsw, nil
+}
+
+func (xx *Core) chkDatasetVectorsHelper(req *coreproto.DatasetChange) error {
+	if len(req.GetVector()) == 0 && len(req.GetVectors()) == 0 {
+		return fmt.Errorf("Err Collection %s dataset has no vector", req.GetCollectionName())
+	}
+	if len(req.GetVector()) > 0 || xx.DataStore.Get(req.GetCollectionName()).Dim() > 0 {
+		if err := xx.chkValidDimensionality(req.GetCollectionName(), "", int32(len(req.GetVector()))); err != nil {
+			return err
+		}
+	}
+	for field, vector := range req.GetVectors() {
+		if err := xx.chkValidDimensionality(req.GetCollectionName(), field, int32(len(vector.GetVector()))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (xx *Core) insertVectorsHelper(collectionName string, id uint64, req *coreproto.DatasetChange, metadata map[string]interface{}) error {
+	xx.rebuildLock.RLock()
+	defer xx.rebuildLock.RUnlock()
+	xx.rebuildTouchHelper(collectionName, id)
+
+	if len(req.GetVector()) > 0 {
+		hnsw := xx.DataStore.Get(collectionName)
+		if err := hnsw.Insert(id, req.GetVector(), metadata, hnsw.RandomLevel()); err != nil {
+			return err
+		}
+	}
+	for field, vector := range req.GetVectors() {
+		hnsw, err := xx.vectorGraphHelper(collectionName, field)
+		if err != nil {
+			return err
+		}
+		if err := hnsw.Insert(id, vector.GetVector(), metadata, hnsw.RandomLevel()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeVectorsHelper drops the vertex from every graph of the collection, graphs without it are skipped
+func (xx *Core) removeVectorsHelper(collectionName string, id uint64) error {
+	xx.rebuildLock.RLock()
+	defer xx.rebuildLock.RUnlock()
+	xx.rebuildTouchHelper(collectionName, id)
+
+	graphs := []*vectorindex.Hnsw{xx.DataStore.Get(collectionName)}
+	for _, hnsw := range xx.VectorFields.Get(collectionName) {
+		graphs = append(graphs, hnsw)
+	}
+	for _, hnsw := range graphs {
+		if err := hnsw.Remove(id); err != nil && !errors.Is(err, vectorindex.ItemNotFoundError) {
+			return err
+		}
+	}
+	return nil
+}
+
+// vertexMetadataHelper finds the metadata of a vertex, a dataset holds at least one vector
+func (xx *Core) vertexMetadataHelper(collectionName string, id uint64) (map[string]interface{}, error) {
+	if vertex, err := xx.DataStore.Get(collectionName).GetVertex(id); err == nil {
+		return vertex.Metadata(), nil
+	}
+	for _, hnsw := range xx.VectorFields.Get(collectionName) {
+		if vertex, err := hnsw.GetVertex(id); err == nil {
+			return vertex.Metadata(), nil
+		}
+	}
+	return nil, vectorindex.ItemNotFoundError
+}
+
+func diskVectorsHelper(req *coreproto.DatasetChange) map[string]*diskproto.NamedVector {
+	if len(req.GetVectors()) == 0 {
+		return nil
+	}
+	vectors := make(map[string]*diskproto.NamedVector, len(req.GetVectors()))
+	for field, vector := range req.GetVectors() {
+		vectors[field] = &diskproto.NamedVector{Vector: vector.GetVector()}
+	}
+	return vectors
+}
+
+func (xx *Core) collectionInfoHelper(collectionName string) *coreproto.CollectionInfo {
+	hnsw := xx.DataStore.Get(collectionName)
+	fields := xx.VectorFields.Get(collectionName)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	vectorFields := make([]*coreproto.VectorField, 0, len(names))
+	for _, name := range names {
+		field := fields[name]
+		vectorFields = append(vectorFields, &coreproto.VectorField{
+			Name:              name,
+			CollectionConfig:  reverseConfigHelper(field.Config()),
+			VectorDimension:   field.Dim(),
+			Distance:          reverseprotoDistHelper(field.Distance()),
+			CompressionHelper: reverseQuantizationHelper(field.Quantization()),
+		})
+	}
+	return &coreproto.CollectionInfo{
+		CollectionName:    collectionName,
+		CollectionConfig:  reverseConfigHelper(hnsw.Config()),
+		VectorDimension:   hnsw.Dim(),
+		CollectionSize:    fmt.Sprintf("%d bytes", hnsw.BytesSize()),
+		CollectionLength:  uint64(hnsw.Len()),
+		Distance:          reverseprotoDistHelper(hnsw.Distance()),
+		CompressionHelper: reverseQuantizationHelper(hnsw.Quantization()),
+		VectorFields:      vectorFields,
+		Rebuild:           xx.rebuildProgressHelper(collectionName),
+		DefaultTtlSeconds: uint64(xx.Expiries.Get(collectionName).DefaultTTL().Seconds()),
+	}
+}
+
+// vectorCandidatesHelper searches the requested vector field, or fuses the
+// field_queries by the weighted sum of their scores. Scores are returned on
+// the 0-100 scale of scoreHelper, best first. A plan limits the candidates
+// to the rows matching its filter.
+func (xx *Core) vectorCandidatesHelper(req *coreproto.SearchRequest, k uint, plan *filterPlan) (vectorindex.SearchResult, error) {
+	if len(req.GetFieldQueries()) == 0 {
+		return xx.fieldCandidatesHelper(req, req.GetVectorField(), req.GetVector(), k, plan)
+	}
+	fused := make(map[uint64]*vectorindex.SearchResultItem)
+	for _, query := range req.GetFieldQueries() {
+		weight := query.GetWeight()
+		if weight == 0 {
+			weight = 1
+		}
+		candidates, err := xx.fieldCandidatesHelper(req, query.GetVectorField(), query.GetVector(), k*fusionCandidateFactor, plan)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range candidates {
+			item, ok := fused[candidate.Id]
+			if !ok {
+				item = &vectorindex.SearchResultItem{Id: candidate.Id, Metadata: candidate.Metadata}
+				fused[candidate.Id] = item
+			}
+			item.Score += weight * candidate.Score
+		}
+	}
+	result := make(vectorindex.SearchResult, 0, len(fused))
+	for _, item := range fused {
+		result = append(result, *item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+	if uint(len(result)) > k {
+		result = result[:k]
+	}
+	return result, nil
+}
+
+func (xx *Core) fieldCandidatesHelper(req *coreproto.SearchRequest, field string, vector []float32, k uint, plan *filterPlan) (vectorindex.SearchResult, error) {
+	if err := xx.chkValidDimensionality(req.GetCollectionName(), field, int32(len(vector))); err != nil {
+		return nil, err
+	}
+	hnsw, err := xx.vectorGraphHelper(req.GetCollectionName(), field)
+	if err != nil {
+		return nil, err
+	}
+	// expired datasets stay in the graph until they are reaped, the search skips them
+	allow := xx.liveHelper(req.GetCollectionName())
+	fetch := k
+	if plan != nil {
+		switch plan.Strategy {
+		case planner.FilteredANN:
+			if live := allow; live != nil {
+				allow = func(id uint64) bool {
+					return plan.matched.Contains(id) && live(id)
+				}
+			} else {
+				allow = plan.matched.Contains
+			}
+		case planner.PostFilter:
+			fetch = uint(plan.Fetch(int(k)))
+		}
+	}
+	opts := xx.searchOptionsHelper(req, field, hnsw)
+	if allow != nil {
+		opts = append(opts, vectorindex.SearchFilter(allow))
+	}
+	var candidates vectorindex.SearchResult
+	if plan != nil && plan.Strategy == planner.Exact {
+		candidates, err = hnsw.SearchExact(context.TODO(), vector, k, plan.matched.ToArray(), opts...)
+	} else {
+		candidates, err = hnsw.Search(context.TODO(), vector, fetch, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := candidates[:0]
+	for _, candidate := range candidates {
+		if uint(len(result)) == k {
+			break
+		}
+		if plan != nil && !plan.matched.Contains(candidate.Id) {
+			continue
+		}
+		candidate.Score = scoreHelper(candidate.Score, hnsw.Distance())
+		result = append(result, candidate)
+	}
+	return result, nil
+}
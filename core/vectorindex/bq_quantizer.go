@@ -0,0 +1,120 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vectorindex
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"math/bits"
+
+	"github.com/sjy-dv/coltt/edge"
+	"github.com/sjy-dv/coltt/pkg/distance"
+)
+
+var _ Quantizer = &BinaryQuantizer{}
+
+// BinaryQuantizer keeps only the sign of every component (1 bit per dimension).
+// The distance is the angle estimated from the hamming distance of the sign
+// bits, 1 - cos(pi * hamming / dim), so it stays comparable to cosine-dot.
+type BinaryQuantizer struct {
+	dim int
+}
+
+func NewBinaryQuantizer(dim int) *BinaryQuantizer {
+	return &BinaryQuantizer{dim: dim}
+}
+
+func (q *BinaryQuantizer) Name() string {
+	return "binaryQuantization"
+}
+
+func (q *BinaryQuantizer) TrainSize() int {
+	return 0
+}
+
+func (q *BinaryQuantizer) Trained() bool {
+	return true
+}
+
+func (q *BinaryQuantizer) Train(samples []edge.Vector) error {
+	return nil
+}
+
+func (q *BinaryQuantizer) Encode(v edge.Vector) []byte {
+	code := make([]byte, q.CodeSize(len(v)))
+	for i, x := range v {
+		if x > 0 {
+			code[i>>3] |= 1 << (i & 7)
+		}
+	}
+	return code
+}
+
+func (q *BinaryQuantizer) Decode(code []byte) edge.Vector {
+	out := make(edge.Vector, q.dim)
+	unit := float32(1 / math.Sqrt(float64(q.dim)))
+	for i := range out {
+		if code[i>>3]&(1<<(i&7)) != 0 {
+			out[i] = unit
+		} else {
+			out[i] = -unit
+		}
+	}
+	return out
+}
+
+func (q *BinaryQuantizer) QueryDistancer(query edge.Vector, dist distance.Space) func(code []byte) float32 {
+	qcode := q.Encode(query)
+	return func(code []byte) float32 {
+		return q.angular(hamming(qcode, code))
+	}
+}
+
+func (q *BinaryQuantizer) angular(h int) float32 {
+	return float32(1 - math.Cos(math.Pi*float64(h)/float64(q.dim)))
+}
+
+func hamming(x, y []byte) int {
+	dist := 0
+	i := 0
+	for ; i+8 <= len(x); i += 8 {
+		dist += bits.OnesCount64(binary.LittleEndian.Uint64(x[i:]) ^ binary.LittleEndian.Uint64(y[i:]))
+	}
+	for ; i < len(x); i++ {
+		dist += bits.OnesCount8(x[i] ^ y[i])
+	}
+	return dist
+}
+
+func (q *BinaryQuantizer) CodeSize(dim int) int {
+	return (dim + 7) / 8
+}
+
+func (q *BinaryQuantizer) Save(w io.Writer) error {
+	return binary.Write(w, binary.BigEndian, int32(q.dim))
+}
+
+func (q *BinaryQuantizer) Load(r io.Reader) error {
+	var dim int32
+	if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
+		return err
+	}
+	q.dim = int(dim)
+	return nil
+}
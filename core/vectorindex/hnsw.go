@@ -44,20 +44,25 @@ type Hnsw struct {
 	dim       uint
 	bytesSize uint64
 	distancer distance.Space
-	config    *hnswConfig
+	config    unsafe.Pointer
 
 	len        uint64
 	vertices   [VERTICES_MAP_SHARD_COUNT]map[uint64]*hnswVertex
 	verticesMu [VERTICES_MAP_SHARD_COUNT]*sync.RWMutex
 
 	entrypoint unsafe.Pointer
+
+	// quantizeMu guards the switch from raw vectors to codes when a
+	// quantizer is trained, every graph operation holds the read lock.
+	quantizeMu sync.RWMutex
+	training   uint32
 }
 
 func NewHnsw(dim uint, distancer distance.Space, option ...HnswOption) *Hnsw {
 	index := &Hnsw{
 		dim:       dim,
 		distancer: distancer,
-		config:    newHnswConfig(option),
+		config:    unsafe.Pointer(newHnswConfig(option)),
 
 		len:        0,
 		entrypoint: nil,
@@ -72,7 +77,7 @@ func NewHnsw(dim uint, distancer distance.Space, option ...HnswOption) *Hnsw {
 }
 
 func (xx *Hnsw) Info() string {
-	return fmt.Sprintf("HNSW(dim: %d, distancer: %s, config={%s})", xx.dim, xx.distancer.Type(), xx.config)
+	return fmt.Sprintf("HNSW(dim: %d, distancer: %s, config={%s})", xx.dim, xx.distancer.Type(), xx.loadConfig())
 }
 
 func (xx *Hnsw) Dim() uint32 {
@@ -84,30 +89,78 @@ func (xx *Hnsw) Len() int {
 }
 
 func (xx *Hnsw) Config() ProtoConfig {
+	config := xx.loadConfig()
 	return ProtoConfig{
-		SearchAlgorithm:           strings.ToLower(xx.config.searchAlgorithm.String()),
-		LevelMultiplier:           xx.config.levelMultiplier,
-		Ef:                        xx.config.ef,
-		EfConstruction:            xx.config.efConstruction,
-		M:                         xx.config.m,
-		MMax:                      xx.config.mMax,
-		MMax0:                     xx.config.mMax0,
-		HeuristicExtendCandidates: xx.config.heuristicExtendCandidates,
-		HeuristicKeepPruned:       xx.config.heuristicKeepPruned,
+		SearchAlgorithm:           strings.ToLower(config.searchAlgorithm.String()),
+		LevelMultiplier:           config.levelMultiplier,
+		Ef:                        config.ef,
+		EfConstruction:            config.efConstruction,
+		M:                         config.m,
+		MMax:                      config.mMax,
+		MMax0:                     config.mMax0,
+		HeuristicExtendCandidates: config.heuristicExtendCandidates,
+		HeuristicKeepPruned:       config.heuristicKeepPruned,
+	}
+}
+
+// UpdateConfig applies search-time options (ef, search algorithm and
+// heuristic flags) to a live index. The graph layout parameters (m, mMax,
+// mMax0, efConstruction, levelMultiplier) are kept, since changing them
+// requires a rebuild.
+func (xx *Hnsw) UpdateConfig(options ...HnswOption) {
+	for {
+		current := atomic.LoadPointer(&xx.config)
+		next := *(*hnswConfig)(current)
+		for _, option := range options {
+			option.apply(&next)
+		}
+		prev := (*hnswConfig)(current)
+		next.levelMultiplier = prev.levelMultiplier
+		next.efConstruction = prev.efConstruction
+		next.m = prev.m
+		next.mMax = prev.mMax
+		next.mMax0 = prev.mMax0
+		next.quantizer = prev.quantizer
+		if atomic.CompareAndSwapPointer(&xx.config, current, unsafe.Pointer(&next)) {
+			return
+		}
 	}
 }
 
+func (xx *Hnsw) loadConfig() *hnswConfig {
+	return (*hnswConfig)(atomic.LoadPointer(&xx.config))
+}
+
 func (xx *Hnsw) Distance() string {
 	return xx.distancer.Type()
 }
 
+// Quantization returns the name of the vertex quantizer, "none" for raw vectors.
+func (xx *Hnsw) Quantization() string {
+	if quantizer := xx.loadConfig().quantizer; quantizer != nil {
+		return quantizer.Name()
+	}
+	return "none"
+}
+
 func (xx *Hnsw) Insert(id uint64, value edge.Vector, metadata Metadata, vertexLevel int) error {
+	if err := xx.insert(id, value, metadata, vertexLevel); err != nil {
+		return err
+	}
+	return xx.trainQuantizer()
+}
+
+func (xx *Hnsw) insert(id uint64, value edge.Vector, metadata Metadata, vertexLevel int) error {
+	xx.quantizeMu.RLock()
+	defer xx.quantizeMu.RUnlock()
+
+	config := xx.loadConfig()
 	if xx.distancer.Type() == "cosine-dot" {
 		value = Normalize(value)
 	}
 	var vertex *hnswVertex
 	if (*hnswVertex)(atomic.LoadPointer(&xx.entrypoint)) == nil {
-		vertex = newHnswVertex(id, value, metadata, 0)
+		vertex = xx.newVertex(id, value, metadata, 0)
 		if err := xx.storeVertex(vertex); err != nil {
 			return err
 		}
@@ -117,31 +170,32 @@ func (xx *Hnsw) Insert(id uint64, value edge.Vector, metadata Metadata, vertexLe
 			vertex.setLevel(vertexLevel)
 		}
 	} else {
-		vertex = newHnswVertex(id, value, metadata, vertexLevel)
+		vertex = xx.newVertex(id, value, metadata, vertexLevel)
 		if err := xx.storeVertex(vertex); err != nil {
 			return err
 		}
 	}
 
+	distFn := xx.queryDistancer(value)
 	entrypoint := (*hnswVertex)(atomic.LoadPointer(&xx.entrypoint))
-	minDistance := xx.distancer.Distance(vertex.vector, entrypoint.vector)
+	minDistance := distFn(entrypoint)
 	for l := entrypoint.level; l > vertex.level; l-- {
-		entrypoint, minDistance = xx.greedyClosestNeighbor(vertex.vector, entrypoint, minDistance, l)
+		entrypoint, minDistance = xx.greedyClosestNeighbor(distFn, entrypoint, minDistance, l)
 	}
 
 	for l := gomath.MinInt(entrypoint.level, vertex.level); l >= 0; l-- {
-		neighbors := xx.searchLevel(vertex.vector, entrypoint, xx.config.efConstruction, l)
+		neighbors := xx.searchLevel(distFn, entrypoint, config.efConstruction, l)
 
-		switch xx.config.searchAlgorithm {
+		switch config.searchAlgorithm {
 		case HnswSearchSimple:
-			neighbors = xx.selectNeighbors(neighbors, xx.config.m)
+			neighbors = xx.selectNeighbors(neighbors, config.m)
 		case HnswSearchHeuristic:
-			neighbors = xx.selectNeighborsHeuristic(vertex.vector, neighbors, xx.config.m, l, xx.config.heuristicExtendCandidates, xx.config.heuristicKeepPruned)
+			neighbors = xx.selectNeighborsHeuristic(distFn, neighbors, config.m, l, config.heuristicExtendCandidates, config.heuristicKeepPruned, nil)
 		}
 
-		mMax := xx.config.mMax
+		mMax := config.mMax
 		if l == 0 {
-			mMax = xx.config.mMax0
+			mMax = config.mMax0
 		}
 
 		for neighbors.Len() > 0 {
@@ -167,12 +221,15 @@ func (xx *Hnsw) Insert(id uint64, value edge.Vector, metadata Metadata, vertexLe
 }
 
 func (xx *Hnsw) Get(id uint64) (edge.Vector, error) {
+	xx.quantizeMu.RLock()
+	defer xx.quantizeMu.RUnlock()
+
 	m, mu := xx.getVerticesShard(id)
 	mu.RLock()
 	defer mu.RUnlock()
 
 	if vertex, exists := m[id]; exists {
-		return vertex.vector, nil
+		return xx.vertexVector(vertex), nil
 	}
 	return nil, ItemNotFoundError
 }
@@ -188,7 +245,37 @@ func (xx *Hnsw) GetVertex(id uint64) (*hnswVertex, error) {
 	return nil, ItemNotFoundError
 }
 
+// Ids returns the id of every vertex in the graph
+func (xx *Hnsw) Ids() []uint64 {
+	ids := make([]uint64, 0, xx.Len())
+	for i := range xx.vertices {
+		xx.verticesMu[i].RLock()
+		for id := range xx.vertices[i] {
+			ids = append(ids, id)
+		}
+		xx.verticesMu[i].RUnlock()
+	}
+	return ids
+}
+
+// VertexVector returns the vector and metadata of a vertex, the vector of a
+// quantized vertex is decoded from its code.
+func (xx *Hnsw) VertexVector(id uint64) (edge.Vector, Metadata, error) {
+	xx.quantizeMu.RLock()
+	defer xx.quantizeMu.RUnlock()
+
+	vertex, err := xx.GetVertex(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return xx.vertexVector(vertex), vertex.Metadata(), nil
+}
+
 func (xx *Hnsw) Remove(id uint64) error {
+	xx.quantizeMu.RLock()
+	defer xx.quantizeMu.RUnlock()
+
+	config := xx.loadConfig()
 	vertex, err := xx.removeVertex(id)
 	if err != nil {
 		return err
@@ -217,9 +304,9 @@ func (xx *Hnsw) Remove(id uint64) error {
 	}
 
 	for l := vertex.level; l >= 0; l-- {
-		mMax := xx.config.mMax
+		mMax := config.mMax
 		if l == 0 {
-			mMax = xx.config.mMax0
+			mMax = config.mMax0
 		}
 
 		vertex.edgeMutexes[l].RLock()
@@ -240,7 +327,12 @@ func (xx *Hnsw) Remove(id uint64) error {
 	return nil
 }
 
-func (xx *Hnsw) Search(ctx context.Context, query edge.Vector, k uint) (SearchResult, error) {
+func (xx *Hnsw) Search(ctx context.Context, query edge.Vector, k uint, options ...HnswSearchOption) (SearchResult, error) {
+	xx.quantizeMu.RLock()
+	defer xx.quantizeMu.RUnlock()
+
+	config := xx.loadConfig()
+	searchConfig := newHnswSearchConfig(config, options)
 	if xx.distancer.Type() == "cosine-dot" {
 		query = Normalize(query)
 	}
@@ -250,19 +342,31 @@ func (xx *Hnsw) Search(ctx context.Context, query edge.Vector, k uint) (SearchRe
 		return make(SearchResult, 0), nil
 	}
 
-	minDistance := xx.distancer.Distance(query, entrypoint.vector)
+	topK := k
+	rerank := searchConfig.fetch != nil && config.quantizer != nil
+	if rerank {
+		k = uint(gomath.MaxInt(int(k), searchConfig.rerank))
+	}
+
+	distFn := xx.queryDistancer(query)
+	minDistance := distFn(entrypoint)
 	for l := entrypoint.level; l > 0; l-- {
-		entrypoint, minDistance = xx.greedyClosestNeighbor(query, entrypoint, minDistance, l)
+		entrypoint, minDistance = xx.greedyClosestNeighbor(distFn, entrypoint, minDistance, l)
 	}
 
-	ef := gomath.MaxInt(xx.config.ef, int(k))
-	neighbors := xx.searchLevel(query, entrypoint, ef, 0)
+	ef := gomath.MaxInt(searchConfig.ef, int(k))
+	var neighbors PriorityQueue
+	if searchConfig.allow != nil {
+		neighbors = xx.searchLevelFiltered(distFn, entrypoint, ef, 0, searchConfig.allow)
+	} else {
+		neighbors = xx.searchLevel(distFn, entrypoint, ef, 0)
+	}
 
-	switch xx.config.searchAlgorithm {
+	switch config.searchAlgorithm {
 	case HnswSearchSimple:
 		neighbors = xx.selectNeighbors(neighbors, int(k))
 	case HnswSearchHeuristic:
-		neighbors = xx.selectNeighborsHeuristic(query, neighbors, int(k), 0, xx.config.heuristicExtendCandidates, xx.config.heuristicKeepPruned)
+		neighbors = xx.selectNeighborsHeuristic(distFn, neighbors, int(k), 0, config.heuristicExtendCandidates, config.heuristicKeepPruned, searchConfig.allow)
 	}
 
 	n := gomath.MinInt(int(k), neighbors.Len())
@@ -274,11 +378,69 @@ func (xx *Hnsw) Search(ctx context.Context, query edge.Vector, k uint) (SearchRe
 		result[i].Score = item.Priority()
 	}
 
+	if rerank {
+		return xx.rerank(query, result, int(topK), searchConfig.fetch)
+	}
+	return result, nil
+}
+
+// SearchExact scores the vertices of ids without the graph, unknown ids are skipped.
+// Quantized vertices are scored by their codes as in Search, SearchRerank and
+// SearchFilter apply alike.
+func (xx *Hnsw) SearchExact(ctx context.Context, query edge.Vector, k uint, ids []uint64, options ...HnswSearchOption) (SearchResult, error) {
+	xx.quantizeMu.RLock()
+	defer xx.quantizeMu.RUnlock()
+
+	config := xx.loadConfig()
+	searchConfig := newHnswSearchConfig(config, options)
+	if xx.distancer.Type() == "cosine-dot" {
+		query = Normalize(query)
+	}
+
+	topK := k
+	rerank := searchConfig.fetch != nil && config.quantizer != nil
+	if rerank {
+		k = uint(gomath.MaxInt(int(k), searchConfig.rerank))
+	}
+
+	distFn := xx.queryDistancer(query)
+	neighbors := NewMaxPriorityQueue()
+	for i, id := range ids {
+		if i%1024 == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		m, mu := xx.getVerticesShard(id)
+		mu.RLock()
+		vertex, exists := m[id]
+		mu.RUnlock()
+		if !exists || vertex.isDeleted() || (searchConfig.allow != nil && !searchConfig.allow(id)) {
+			continue
+		}
+		distance := distFn(vertex)
+		if uint(neighbors.Len()) < k || distance < neighbors.Peek().Priority() {
+			neighbors.Push(NewPriorityQueueItem(distance, vertex))
+			if uint(neighbors.Len()) > k {
+				neighbors.Pop()
+			}
+		}
+	}
+
+	result := make(SearchResult, neighbors.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		item := neighbors.Pop()
+		result[i].Id = item.Value().(*hnswVertex).Id()
+		result[i].Metadata = item.Value().(*hnswVertex).Metadata()
+		result[i].Score = item.Priority()
+	}
+
+	if rerank {
+		return xx.rerank(query, result, int(topK), searchConfig.fetch)
+	}
 	return result, nil
 }
 
 func (xx *Hnsw) RandomLevel() int {
-	return gomath.Floor(gomath.RandomExponential(xx.config.levelMultiplier))
+	return gomath.Floor(gomath.RandomExponential(xx.loadConfig().levelMultiplier))
 }
 
 func (xx *Hnsw) getVerticesShard(id uint64) (map[uint64]*hnswVertex, *sync.RWMutex) {
@@ -317,7 +479,7 @@ func (xx *Hnsw) removeVertex(id uint64) (*hnswVertex, error) {
 	return nil, ItemNotFoundError
 }
 
-func (xx *Hnsw) greedyClosestNeighbor(query edge.Vector, entrypoint *hnswVertex, minDistance float32, level int) (*hnswVertex, float32) {
+func (xx *Hnsw) greedyClosestNeighbor(distFn vertexDistancer, entrypoint *hnswVertex, minDistance float32, level int) (*hnswVertex, float32) {
 	for {
 		var closestNeighbor *hnswVertex
 
@@ -326,7 +488,7 @@ func (xx *Hnsw) greedyClosestNeighbor(query edge.Vector, entrypoint *hnswVertex,
 			if neighbor.isDeleted() {
 				continue
 			}
-			if distance := xx.distancer.Distance(query, neighbor.vector); distance < minDistance {
+			if distance := distFn(neighbor); distance < minDistance {
 				minDistance = distance
 				closestNeighbor = neighbor
 			}
@@ -342,13 +504,13 @@ func (xx *Hnsw) greedyClosestNeighbor(query edge.Vector, entrypoint *hnswVertex,
 	return entrypoint, minDistance
 }
 
-func (xx *Hnsw) searchLevel(query edge.Vector, entrypoint *hnswVertex, ef, level int) PriorityQueue {
-	entrypointDistance := xx.distancer.Distance(query, entrypoint.vector)
+func (xx *Hnsw) searchLevel(distFn vertexDistancer, entrypoint *hnswVertex, ef, level int) PriorityQueue {
+	entrypointDistance := distFn(entrypoint)
 	pqItem := NewPriorityQueueItem(entrypointDistance, entrypoint)
 	candidateVertices := NewMinPriorityQueue(pqItem)
 	resultVertices := NewMaxPriorityQueue(pqItem)
 
-	visitedVertices := make(map[*hnswVertex]struct{}, ef*xx.config.mMax0)
+	visitedVertices := make(map[*hnswVertex]struct{}, ef*xx.loadConfig().mMax0)
 	visitedVertices[entrypoint] = struct{}{}
 
 	for candidateVertices.Len() > 0 {
@@ -370,7 +532,7 @@ func (xx *Hnsw) searchLevel(query edge.Vector, entrypoint *hnswVertex, ef, level
 			}
 			visitedVertices[neighbor] = struct{}{}
 
-			distance := xx.distancer.Distance(query, neighbor.vector)
+			distance := distFn(neighbor)
 			if (distance < lowerBound) || (resultVertices.Len() < ef) {
 				pqItem := NewPriorityQueueItem(distance, neighbor)
 				candidateVertices.Push(pqItem)
@@ -388,6 +550,55 @@ func (xx *Hnsw) searchLevel(query edge.Vector, entrypoint *hnswVertex, ef, level
 	return resultVertices
 }
 
+// searchLevelFiltered is searchLevel keeping only the vertices allow accepts in
+// the result, the candidates still go through the others.
+func (xx *Hnsw) searchLevelFiltered(distFn vertexDistancer, entrypoint *hnswVertex, ef, level int, allow func(id uint64) bool) PriorityQueue {
+	pqItem := NewPriorityQueueItem(distFn(entrypoint), entrypoint)
+	candidateVertices := NewMinPriorityQueue(pqItem)
+	resultVertices := NewMaxPriorityQueue()
+	if allow(entrypoint.Id()) {
+		resultVertices.Push(pqItem)
+	}
+
+	visitedVertices := make(map[*hnswVertex]struct{}, ef*xx.loadConfig().mMax0)
+	visitedVertices[entrypoint] = struct{}{}
+
+	for candidateVertices.Len() > 0 {
+		candidateItem := candidateVertices.Pop()
+		candidate := candidateItem.Value().(*hnswVertex)
+		if resultVertices.Len() >= ef && candidateItem.Priority() > resultVertices.Peek().Priority() {
+			break
+		}
+
+		candidate.edgeMutexes[level].RLock()
+		for neighbor, _ := range candidate.edges[level] {
+			if neighbor.isDeleted() {
+				continue
+			}
+			if _, exists := visitedVertices[neighbor]; exists {
+				continue
+			}
+			visitedVertices[neighbor] = struct{}{}
+
+			distance := distFn(neighbor)
+			if resultVertices.Len() < ef || distance < resultVertices.Peek().Priority() {
+				pqItem := NewPriorityQueueItem(distance, neighbor)
+				candidateVertices.Push(pqItem)
+				if allow(neighbor.Id()) {
+					resultVertices.Push(pqItem)
+					if resultVertices.Len() > ef {
+						resultVertices.Pop()
+					}
+				}
+			}
+		}
+		candidate.edgeMutexes[level].RUnlock()
+	}
+
+	// MaxPriorityQueue
+	return resultVertices
+}
+
 func (xx *Hnsw) selectNeighbors(neighbors PriorityQueue, k int) PriorityQueue {
 	for neighbors.Len() > k {
 		neighbors.Pop()
@@ -396,12 +607,15 @@ func (xx *Hnsw) selectNeighbors(neighbors PriorityQueue, k int) PriorityQueue {
 	return neighbors
 }
 
-func (xx *Hnsw) selectNeighborsHeuristic(query edge.Vector, neighbors PriorityQueue, k, level int, extendCandidates, keepPruned bool) PriorityQueue {
+// selectNeighborsHeuristic keeps the k closest of the neighbors, extended by
+// their own neighbors when extendCandidates is set. allow filters the extended
+// candidates as searchLevelFiltered filters the neighbors, nil allows all.
+func (xx *Hnsw) selectNeighborsHeuristic(distFn vertexDistancer, neighbors PriorityQueue, k, level int, extendCandidates, keepPruned bool, allow func(id uint64) bool) PriorityQueue {
 	candidateVertices := neighbors.Reverse() // MinPriorityQueue
 
 	existingCandidatesSize := neighbors.Len()
 	if extendCandidates {
-		existingCandidatesSize += neighbors.Len() * xx.config.mMax0
+		existingCandidatesSize += neighbors.Len() * xx.loadConfig().mMax0
 	}
 	existingCandidates := make(map[*hnswVertex]struct{}, existingCandidatesSize)
 	for _, neighbor := range neighbors.ToSlice() {
@@ -414,7 +628,7 @@ func (xx *Hnsw) selectNeighborsHeuristic(query edge.Vector, neighbors PriorityQu
 
 			candidate.edgeMutexes[level].RLock()
 			for neighbor, _ := range candidate.edges[level] {
-				if neighbor.isDeleted() {
+				if neighbor.isDeleted() || (allow != nil && !allow(neighbor.Id())) {
 					continue
 				}
 				if _, exists := existingCandidates[neighbor]; exists {
@@ -422,7 +636,7 @@ func (xx *Hnsw) selectNeighborsHeuristic(query edge.Vector, neighbors PriorityQu
 				}
 				existingCandidates[neighbor] = struct{}{}
 
-				distance := xx.distancer.Distance(query, neighbor.vector)
+				distance := distFn(neighbor)
 				candidateVertices.Push(NewPriorityQueueItem(distance, neighbor))
 			}
 			candidate.edgeMutexes[level].RUnlock()
@@ -447,6 +661,7 @@ func (xx *Hnsw) selectNeighborsHeuristic(query edge.Vector, neighbors PriorityQu
 }
 
 func (xx *Hnsw) pruneNeighbors(vertex *hnswVertex, k, level int) {
+	config := xx.loadConfig()
 	neighborsQueue := NewMaxPriorityQueue()
 
 	vertex.edgeMutexes[level].RLock()
@@ -458,11 +673,11 @@ func (xx *Hnsw) pruneNeighbors(vertex *hnswVertex, k, level int) {
 	}
 	vertex.edgeMutexes[level].RUnlock()
 
-	switch xx.config.searchAlgorithm {
+	switch config.searchAlgorithm {
 	case HnswSearchSimple:
 		neighborsQueue = xx.selectNeighbors(neighborsQueue, k)
 	case HnswSearchHeuristic:
-		neighborsQueue = xx.selectNeighborsHeuristic(vertex.vector, neighborsQueue, k, level, xx.config.heuristicExtendCandidates, xx.config.heuristicKeepPruned)
+		neighborsQueue = xx.selectNeighborsHeuristic(xx.queryDistancer(xx.vertexVector(vertex)), neighborsQueue, k, level, config.heuristicExtendCandidates, config.heuristicKeepPruned, nil)
 	}
 
 	newNeighbors := make(hnswEdgeSet, neighborsQueue.Len())
@@ -474,15 +689,16 @@ func (xx *Hnsw) pruneNeighbors(vertex *hnswVertex, k, level int) {
 }
 
 func (xx *Hnsw) BytesSize() uint64 {
+	config := xx.loadConfig()
 	maxLevel := 10
 	if entrypoint := (*hnswVertex)(atomic.LoadPointer(&xx.entrypoint)); entrypoint != nil {
 		maxLevel = entrypoint.level
 	}
 
 	mutb := float64(HNSW_VERTEX_MUTEX_BYTES)
-	var pointersSize float64 = float64(xx.config.mMax0*HNSW_VERTEX_EDGE_BYTES) + mutb
+	var pointersSize float64 = float64(config.mMax0*HNSW_VERTEX_EDGE_BYTES) + mutb
 	for i := 1; i < maxLevel; i++ {
-		pointersSize += (float64(xx.config.mMax*HNSW_VERTEX_EDGE_BYTES) + mutb) * math.Exp(float64(i)/-float64(xx.config.levelMultiplier))
+		pointersSize += (float64(config.mMax*HNSW_VERTEX_EDGE_BYTES) + mutb) * math.Exp(float64(i)/-float64(config.levelMultiplier))
 	}
 
 	verticesDataSize := atomic.LoadUint64(&xx.bytesSize)
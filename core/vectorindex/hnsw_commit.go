@@ -67,8 +67,12 @@ func bytesToId(byt []byte) (uint64, error) {
 }
 
 func (xx *Hnsw) Commit(w io.Writer, header bool) error {
+	xx.quantizeMu.RLock()
+	defer xx.quantizeMu.RUnlock()
+
+	quantizer := xx.loadConfig().quantizer
 	if header {
-		if err := xx.config.save(w); err != nil {
+		if err := xx.loadConfig().save(w); err != nil {
 			return err
 		}
 		if err := binary.Write(w, binary.BigEndian, uint32(xx.dim)); err != nil {
@@ -78,6 +82,12 @@ func (xx *Hnsw) Commit(w io.Writer, header bool) error {
 			return err
 		}
 	}
+	// codebooks are stored ahead of the vertices, raw snapshots carry no quantizer section
+	if quantizer != nil {
+		if err := quantizer.Save(w); err != nil {
+			return err
+		}
+	}
 
 	if xx.Len() == 0 {
 		return nil
@@ -111,7 +121,7 @@ func (xx *Hnsw) Commit(w io.Writer, header bool) error {
 			if err := binary.Write(w, binary.BigEndian, int32(vertex.level)); err != nil {
 				return err
 			}
-			if err := vertex.vector.Save(w); err != nil {
+			if err := saveVertexVector(w, vertex, quantizer); err != nil {
 				return err
 			}
 			if err := vertex.metadata.save(w); err != nil {
@@ -165,9 +175,11 @@ func (xx *Hnsw) Load(r io.Reader, header bool) error {
 	if header {
 		var size uint32
 		var distIdx uint8
-		if err := xx.config.load(r); err != nil {
+		config := *xx.loadConfig()
+		if err := config.load(r); err != nil {
 			return err
 		}
+		atomic.StorePointer(&xx.config, unsafe.Pointer(&config))
 		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
 			return err
 		}
@@ -181,13 +193,23 @@ func (xx *Hnsw) Load(r io.Reader, header bool) error {
 		xx.dim = uint(size)
 		xx.distancer = dist
 	}
+	quantizer := xx.loadConfig().quantizer
+	if quantizer != nil {
+		if err := quantizer.Load(r); err != nil {
+			return err
+		}
+	}
 
 	var level int32
 	var numEdges uint32
 	var distance float32
 
 	idBuf := make([]byte, 8)
-	if _, err := r.Read(idBuf); err != nil {
+	if n, err := r.Read(idBuf); err != nil {
+		// an empty graph is committed without entrypoint and vertices
+		if err == io.EOF && n == 0 {
+			return nil
+		}
 		return err
 	}
 	entrypointId, err := bytesToId(idBuf)
@@ -220,8 +242,8 @@ func (xx *Hnsw) Load(r io.Reader, header bool) error {
 				return err
 			}
 
-			vector := make(edge.Vector, xx.dim)
-			if err := vector.Load(r); err != nil {
+			vector, code, err := loadVertexVector(r, xx.dim, quantizer)
+			if err != nil {
 				return err
 			}
 
@@ -231,6 +253,7 @@ func (xx *Hnsw) Load(r io.Reader, header bool) error {
 			}
 
 			vertex = newHnswVertex(id, vector, metadata, int(level))
+			vertex.code = code
 			xx.bytesSize += vertex.bytesSize()
 			verticesShard[id] = vertex
 		}
@@ -276,3 +299,50 @@ func (xx *Hnsw) Load(r io.Reader, header bool) error {
 
 	return nil
 }
+
+// saveVertexVector writes the raw vector, or for a quantized index a flag
+// byte followed by the code (or the raw vector if it was not encoded yet).
+func saveVertexVector(w io.Writer, vertex *hnswVertex, quantizer Quantizer) error {
+	if quantizer == nil {
+		return vertex.vector.Save(w)
+	}
+	if vertex.code == nil {
+		if err := binary.Write(w, binary.BigEndian, uint8(0)); err != nil {
+			return err
+		}
+		return vertex.vector.Save(w)
+	}
+	if err := binary.Write(w, binary.BigEndian, uint8(1)); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(len(vertex.code))); err != nil {
+		return err
+	}
+	_, err := w.Write(vertex.code)
+	return err
+}
+
+func loadVertexVector(r io.Reader, dim uint, quantizer Quantizer) (edge.Vector, []byte, error) {
+	if quantizer != nil {
+		var flag uint8
+		if err := binary.Read(r, binary.BigEndian, &flag); err != nil {
+			return nil, nil, err
+		}
+		if flag == 1 {
+			var size uint32
+			if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+				return nil, nil, err
+			}
+			code := make([]byte, size)
+			if _, err := io.ReadFull(r, code); err != nil {
+				return nil, nil, err
+			}
+			return nil, code, nil
+		}
+	}
+	vector := make(edge.Vector, dim)
+	if err := vector.Load(r); err != nil {
+		return nil, nil, err
+	}
+	return vector, nil, nil
+}
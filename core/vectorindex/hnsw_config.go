@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/sjy-dv/coltt/edge"
 	"github.com/sjy-dv/coltt/pkg/gomath"
 )
 
@@ -108,6 +109,64 @@ func HnswHeuristicKeepPruned(value bool) HnswOption {
 	}}
 }
 
+// Search options, applied per Search call on top of the index config
+type HnswSearchOption interface {
+	apply(*hnswSearchConfig)
+}
+
+type hnswSearchOption struct {
+	applyFunc func(*hnswSearchConfig)
+}
+
+func (opt *hnswSearchOption) apply(config *hnswSearchConfig) {
+	opt.applyFunc(config)
+}
+
+// SearchEf overrides the configured ef for a single search.
+// Values <= 0 keep the index default.
+func SearchEf(value int) HnswSearchOption {
+	return &hnswSearchOption{func(config *hnswSearchConfig) {
+		if value > 0 {
+			config.ef = value
+		}
+	}}
+}
+
+// SearchRerank fetches the raw vectors of the top candidates (at least k)
+// of a quantized index and orders them by their exact distance. Candidates
+// whose fetch returns ItemNotFoundError are dropped from the result.
+func SearchRerank(candidates int, fetch func(id uint64) (edge.Vector, error)) HnswSearchOption {
+	return &hnswSearchOption{func(config *hnswSearchConfig) {
+		config.rerank = candidates
+		config.fetch = fetch
+	}}
+}
+
+// SearchFilter keeps only the vertices allow accepts in the result. The graph is
+// still traversed through the others, so the matching vertices stay reachable.
+func SearchFilter(allow func(id uint64) bool) HnswSearchOption {
+	return &hnswSearchOption{func(config *hnswSearchConfig) {
+		config.allow = allow
+	}}
+}
+
+type hnswSearchConfig struct {
+	ef     int
+	rerank int
+	fetch  func(id uint64) (edge.Vector, error)
+	allow  func(id uint64) bool
+}
+
+func newHnswSearchConfig(config *hnswConfig, options []HnswSearchOption) *hnswSearchConfig {
+	searchConfig := &hnswSearchConfig{
+		ef: config.ef,
+	}
+	for _, option := range options {
+		option.apply(searchConfig)
+	}
+	return searchConfig
+}
+
 type hnswConfig struct {
 	searchAlgorithm           hnswSearchAlgorithm
 	levelMultiplier           float32
@@ -118,6 +177,7 @@ type hnswConfig struct {
 	mMax0                     int
 	heuristicExtendCandidates bool
 	heuristicKeepPruned       bool
+	quantizer                 Quantizer
 }
 
 type ProtoConfig struct {
@@ -0,0 +1,139 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vectorindex
+
+import (
+	"errors"
+	"sort"
+	"sync/atomic"
+
+	"github.com/sjy-dv/coltt/edge"
+)
+
+type vertexDistancer func(*hnswVertex) float32
+
+// queryDistancer returns the distance from query to a vertex.
+// Quantized vertices are compared through their codes, vertices
+// inserted before the quantizer was trained still use the raw vector.
+func (xx *Hnsw) queryDistancer(query edge.Vector) vertexDistancer {
+	quantizer := xx.loadConfig().quantizer
+	if quantizer == nil || !quantizer.Trained() {
+		return func(vertex *hnswVertex) float32 {
+			return xx.distancer.Distance(query, vertex.vector)
+		}
+	}
+	codeDistance := quantizer.QueryDistancer(query, xx.distancer)
+	return func(vertex *hnswVertex) float32 {
+		if vertex.code == nil {
+			return xx.distancer.Distance(query, vertex.vector)
+		}
+		return codeDistance(vertex.code)
+	}
+}
+
+func (xx *Hnsw) newVertex(id uint64, value edge.Vector, metadata Metadata, level int) *hnswVertex {
+	quantizer := xx.loadConfig().quantizer
+	if quantizer == nil || !quantizer.Trained() {
+		return newHnswVertex(id, value, metadata, level)
+	}
+	vertex := newHnswVertex(id, nil, metadata, level)
+	vertex.code = quantizer.Encode(value)
+	return vertex
+}
+
+// vertexVector returns the raw vector or the approximation decoded from the code.
+func (xx *Hnsw) vertexVector(vertex *hnswVertex) edge.Vector {
+	if vertex.code == nil {
+		return vertex.vector
+	}
+	return xx.loadConfig().quantizer.Decode(vertex.code)
+}
+
+// trainQuantizer trains the quantizer once enough vectors were inserted
+// and replaces every raw vector in the graph with its code.
+func (xx *Hnsw) trainQuantizer() error {
+	quantizer := xx.loadConfig().quantizer
+	if quantizer == nil || quantizer.TrainSize() == 0 || xx.Len() < quantizer.TrainSize() {
+		return nil
+	}
+	if !atomic.CompareAndSwapUint32(&xx.training, 0, 1) {
+		return nil
+	}
+	xx.quantizeMu.Lock()
+	defer xx.quantizeMu.Unlock()
+	if quantizer.Trained() {
+		return nil
+	}
+
+	samples := make([]edge.Vector, 0, quantizer.TrainSize())
+	for i := range xx.vertices {
+		for _, vertex := range xx.vertices[i] {
+			if len(samples) >= quantizer.TrainSize() {
+				break
+			}
+			if vertex.code == nil {
+				samples = append(samples, vertex.vector)
+			}
+		}
+	}
+	if err := quantizer.Train(samples); err != nil {
+		atomic.StoreUint32(&xx.training, 0)
+		return err
+	}
+	// the vectors are replaced by their codes, so the vertices size is recomputed
+	var bytesSize uint64
+	for i := range xx.vertices {
+		for _, vertex := range xx.vertices[i] {
+			if vertex.code == nil {
+				vertex.code = quantizer.Encode(vertex.vector)
+				vertex.vector = nil
+			}
+			bytesSize += vertex.bytesSize()
+		}
+	}
+	atomic.StoreUint64(&xx.bytesSize, bytesSize)
+	return nil
+}
+
+// rerank replaces the compressed scores by the exact distance of the raw
+// vectors returned by fetch and keeps the k closest candidates. Candidates
+// without a raw vector, deleted or expired but not yet removed from the
+// graph, are dropped.
+func (xx *Hnsw) rerank(query edge.Vector, result SearchResult, k int, fetch func(id uint64) (edge.Vector, error)) (SearchResult, error) {
+	kept := result[:0]
+	for _, item := range result {
+		vector, err := fetch(item.Id)
+		if errors.Is(err, ItemNotFoundError) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if xx.distancer.Type() == "cosine-dot" {
+			vector = Normalize(vector)
+		}
+		item.Score = xx.distancer.Distance(query, vector)
+		kept = append(kept, item)
+	}
+	result = kept
+	sort.Sort(result)
+	if len(result) > k {
+		result = result[:k]
+	}
+	return result, nil
+}
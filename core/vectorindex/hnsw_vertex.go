@@ -32,6 +32,7 @@ type hnswEdgeSet map[*hnswVertex]float32
 type hnswVertex struct {
 	id          uint64
 	vector      edge.Vector
+	code        []byte // quantized vector, vector is nil when set
 	level       int
 	metadata    Metadata
 	deleted     uint32
@@ -123,5 +124,5 @@ func (xx *hnswVertex) setEdges(level int, edges hnswEdgeSet) {
 func (xx *hnswVertex) bytesSize() uint64 {
 	//  uint64 = 8byte
 	// float32 => 4 byte x vector len
-	return 8 + 4*uint64(len(xx.vector)) + xx.metadata.byteSize()
+	return 8 + 4*uint64(len(xx.vector)) + uint64(len(xx.code)) + xx.metadata.byteSize()
 }
@@ -0,0 +1,329 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vectorindex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+
+	"github.com/sjy-dv/coltt/edge"
+	"github.com/sjy-dv/coltt/pkg/distance"
+)
+
+const (
+	pqMaxCentroids   = 256
+	pqKmeansIters    = 25
+	pqDefaultSubSize = 8
+)
+
+var _ Quantizer = &ProductQuantizer{}
+
+// ProductQuantizer splits a vector into subspaces and stores
+// the nearest centroid index (1 byte) of every subspace.
+type ProductQuantizer struct {
+	dim       int
+	subspaces int
+	centroids int
+	trainSize int
+	trained   bool
+	// codebooks[subspace][centroid] => sub vector(dim/subspaces)
+	codebooks [][]edge.Vector
+}
+
+// NewProductQuantizer returns an untrained quantizer.
+// Zero values pick defaults: subspaces covering pqDefaultSubSize dimensions,
+// 256 centroids and 4*centroids training vectors.
+func NewProductQuantizer(dim, subspaces, centroids, trainSize int) (*ProductQuantizer, error) {
+	if subspaces <= 0 {
+		subspaces = defaultSubspaces(dim)
+	}
+	if centroids <= 0 || centroids > pqMaxCentroids {
+		centroids = pqMaxCentroids
+	}
+	if trainSize < centroids {
+		trainSize = 4 * centroids
+	}
+	if dim%subspaces != 0 {
+		return nil, fmt.Errorf("product quantization: dimension %d is not divisible by %d subspaces", dim, subspaces)
+	}
+	return &ProductQuantizer{
+		dim:       dim,
+		subspaces: subspaces,
+		centroids: centroids,
+		trainSize: trainSize,
+	}, nil
+}
+
+func defaultSubspaces(dim int) int {
+	for sub := pqDefaultSubSize; sub > 1; sub /= 2 {
+		if dim%sub == 0 {
+			return dim / sub
+		}
+	}
+	return dim
+}
+
+func (q *ProductQuantizer) Name() string {
+	return "productQuantization"
+}
+
+func (q *ProductQuantizer) Subspaces() int {
+	return q.subspaces
+}
+
+func (q *ProductQuantizer) Centroids() int {
+	return q.centroids
+}
+
+func (q *ProductQuantizer) TrainSize() int {
+	return q.trainSize
+}
+
+func (q *ProductQuantizer) Trained() bool {
+	return q.trained
+}
+
+func (q *ProductQuantizer) subDim() int {
+	return q.dim / q.subspaces
+}
+
+func (q *ProductQuantizer) Train(samples []edge.Vector) error {
+	if len(samples) == 0 {
+		return QuantizerNotTrainedErr
+	}
+	subDim := q.subDim()
+	k := q.centroids
+	if len(samples) < k {
+		k = len(samples)
+	}
+	rnd := rand.New(rand.NewSource(int64(len(samples))))
+	codebooks := make([][]edge.Vector, q.subspaces)
+	for s := 0; s < q.subspaces; s++ {
+		points := make([]edge.Vector, len(samples))
+		for i, sample := range samples {
+			points[i] = sample[s*subDim : (s+1)*subDim]
+		}
+		codebooks[s] = kmeans(points, k, subDim, rnd)
+	}
+	q.codebooks = codebooks
+	q.trained = true
+	return nil
+}
+
+func kmeans(points []edge.Vector, k, dim int, rnd *rand.Rand) []edge.Vector {
+	centers := make([]edge.Vector, k)
+	for i, idx := range rnd.Perm(len(points))[:k] {
+		centers[i] = points[idx].Clone()
+	}
+	assign := make([]int, len(points))
+	for iter := 0; iter < pqKmeansIters; iter++ {
+		changed := false
+		for i, p := range points {
+			if best := nearestCenter(centers, p); best != assign[i] {
+				assign[i] = best
+				changed = true
+			}
+		}
+		sums := make([]edge.Vector, k)
+		counts := make([]int, k)
+		for i := range sums {
+			sums[i] = make(edge.Vector, dim)
+		}
+		for i, p := range points {
+			c := assign[i]
+			counts[c]++
+			for j := range p {
+				sums[c][j] += p[j]
+			}
+		}
+		for c := range centers {
+			if counts[c] == 0 {
+				// empty cluster, reseed from a random point
+				centers[c] = points[rnd.Intn(len(points))].Clone()
+				continue
+			}
+			for j := range sums[c] {
+				sums[c][j] /= float32(counts[c])
+			}
+			centers[c] = sums[c]
+		}
+		if !changed && iter > 0 {
+			break
+		}
+	}
+	return centers
+}
+
+func nearestCenter(centers []edge.Vector, p edge.Vector) int {
+	best, bestDist := 0, float32(math.MaxFloat32)
+	for c, center := range centers {
+		if d := squaredL2(center, p); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+func squaredL2(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		diff := a[i] - b[i]
+		sum += diff * diff
+	}
+	return sum
+}
+
+func dot(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+func (q *ProductQuantizer) Encode(v edge.Vector) []byte {
+	subDim := q.subDim()
+	code := make([]byte, q.subspaces)
+	for s := 0; s < q.subspaces; s++ {
+		code[s] = byte(nearestCenter(q.codebooks[s], v[s*subDim:(s+1)*subDim]))
+	}
+	return code
+}
+
+func (q *ProductQuantizer) Decode(code []byte) edge.Vector {
+	subDim := q.subDim()
+	out := make(edge.Vector, q.dim)
+	for s, c := range code {
+		copy(out[s*subDim:(s+1)*subDim], q.codebooks[s][c])
+	}
+	return out
+}
+
+// QueryDistancer builds the asymmetric distance lookup tables for a query.
+func (q *ProductQuantizer) QueryDistancer(query edge.Vector, dist distance.Space) func(code []byte) float32 {
+	subDim := q.subDim()
+	if dist.Type() == "cosine-dot" {
+		dots := make([][]float32, q.subspaces)
+		norms := make([][]float32, q.subspaces)
+		for s := 0; s < q.subspaces; s++ {
+			sub := query[s*subDim : (s+1)*subDim]
+			dots[s] = make([]float32, len(q.codebooks[s]))
+			norms[s] = make([]float32, len(q.codebooks[s]))
+			for c, center := range q.codebooks[s] {
+				dots[s][c] = dot(sub, center)
+				norms[s][c] = dot(center, center)
+			}
+		}
+		queryNorm := float32(math.Sqrt(float64(dot(query, query))))
+		return func(code []byte) float32 {
+			var d, n float32
+			for s, c := range code {
+				d += dots[s][c]
+				n += norms[s][c]
+			}
+			if n == 0 || queryNorm == 0 {
+				return 1
+			}
+			return float32(math.Abs(float64(1 - d/(queryNorm*float32(math.Sqrt(float64(n)))))))
+		}
+	}
+	table := make([][]float32, q.subspaces)
+	for s := 0; s < q.subspaces; s++ {
+		sub := query[s*subDim : (s+1)*subDim]
+		table[s] = make([]float32, len(q.codebooks[s]))
+		for c, center := range q.codebooks[s] {
+			table[s][c] = squaredL2(sub, center)
+		}
+	}
+	return func(code []byte) float32 {
+		var sum float32
+		for s, c := range code {
+			sum += table[s][c]
+		}
+		return float32(math.Sqrt(float64(sum)))
+	}
+}
+
+func (q *ProductQuantizer) CodeSize(dim int) int {
+	return q.subspaces
+}
+
+func (q *ProductQuantizer) Save(w io.Writer) error {
+	for _, val := range []int32{int32(q.dim), int32(q.subspaces), int32(q.centroids), int32(q.trainSize)} {
+		if err := binary.Write(w, binary.BigEndian, val); err != nil {
+			return err
+		}
+	}
+	if err := binary.Write(w, binary.BigEndian, q.trained); err != nil {
+		return err
+	}
+	if !q.trained {
+		return nil
+	}
+	for _, codebook := range q.codebooks {
+		if err := binary.Write(w, binary.BigEndian, uint16(len(codebook))); err != nil {
+			return err
+		}
+		for _, center := range codebook {
+			if err := center.Save(w); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (q *ProductQuantizer) Load(r io.Reader) error {
+	var header [4]int32
+	for i := range header {
+		if err := binary.Read(r, binary.BigEndian, &header[i]); err != nil {
+			return err
+		}
+	}
+	q.dim, q.subspaces, q.centroids, q.trainSize = int(header[0]), int(header[1]), int(header[2]), int(header[3])
+	if q.subspaces <= 0 || q.dim%q.subspaces != 0 {
+		return InvalidQuantizerErr
+	}
+	if err := binary.Read(r, binary.BigEndian, &q.trained); err != nil {
+		return err
+	}
+	if !q.trained {
+		q.codebooks = nil
+		return nil
+	}
+	subDim := q.subDim()
+	q.codebooks = make([][]edge.Vector, q.subspaces)
+	for s := range q.codebooks {
+		var size uint16
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return err
+		}
+		q.codebooks[s] = make([]edge.Vector, size)
+		for c := range q.codebooks[s] {
+			center := make(edge.Vector, subDim)
+			if err := center.Load(r); err != nil {
+				return err
+			}
+			q.codebooks[s][c] = center
+		}
+	}
+	return nil
+}
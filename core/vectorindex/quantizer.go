@@ -0,0 +1,55 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vectorindex
+
+import (
+	"errors"
+	"io"
+
+	"github.com/sjy-dv/coltt/edge"
+	"github.com/sjy-dv/coltt/pkg/distance"
+)
+
+var (
+	QuantizerNotTrainedErr error = errors.New("Quantizer is not trained")
+	InvalidQuantizerErr    error = errors.New("Invalid quantizer state")
+)
+
+// Quantizer compresses the vectors stored in hnswVertex.
+// Graph traversal uses the distance returned by QueryDistancer, so
+// a quantized index never needs the raw float32 vector after Encode.
+type Quantizer interface {
+	Name() string
+	// TrainSize is the number of vectors needed before Train can run.
+	// 0 means the quantizer works without training.
+	TrainSize() int
+	Trained() bool
+	Train(samples []edge.Vector) error
+	Encode(v edge.Vector) []byte
+	Decode(code []byte) edge.Vector
+	QueryDistancer(query edge.Vector, dist distance.Space) func(code []byte) float32
+	CodeSize(dim int) int
+	Save(w io.Writer) error
+	Load(r io.Reader) error
+}
+
+func HnswQuantization(value Quantizer) HnswOption {
+	return &hnswOption{func(config *hnswConfig) {
+		config.quantizer = value
+	}}
+}
@@ -0,0 +1,136 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vectorindex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sjy-dv/coltt/edge"
+	"github.com/sjy-dv/coltt/pkg/distance"
+	"github.com/sjy-dv/coltt/pkg/gomath"
+	"github.com/stretchr/testify/assert"
+)
+
+func generateQuantizedIndex(t *testing.T, quantizer Quantizer, dim, size int) (*Hnsw, map[uint64]edge.Vector) {
+	raw := make(map[uint64]edge.Vector, size)
+	index := NewHnsw(uint(dim), distance.NewEuclidean(), HnswQuantization(quantizer))
+	for i := 0; i < size; i++ {
+		vector := edge.Vector(gomath.RandomStandardNormalVector(dim))
+		raw[uint64(i)] = vector
+		err := index.Insert(uint64(i), vector, Metadata{"id": i}, index.RandomLevel())
+		assert.Nil(t, err)
+	}
+	return index, raw
+}
+
+func TestProductQuantizationCommitAndLoad(t *testing.T) {
+	pq, err := NewProductQuantizer(32, 8, 16, 200)
+	assert.Nil(t, err)
+	index, raw := generateQuantizedIndex(t, pq, 32, 500)
+	assert.True(t, pq.Trained())
+
+	var buf bytes.Buffer
+	assert.Nil(t, index.Commit(&buf, true))
+
+	otherPq, _ := NewProductQuantizer(32, 8, 16, 200)
+	other := NewHnsw(32, distance.NewEuclidean(), HnswQuantization(otherPq))
+	assert.Nil(t, other.Load(&buf, true))
+	assert.True(t, otherPq.Trained())
+	assert.Equal(t, index.Len(), other.Len())
+
+	for id := range raw {
+		a, _ := index.Get(id)
+		b, _ := other.Get(id)
+		assert.Equal(t, a, b)
+	}
+}
+
+func TestQuantizationTrainingBytesSize(t *testing.T) {
+	pq, err := NewProductQuantizer(32, 8, 16, 200)
+	assert.Nil(t, err)
+	index, _ := generateQuantizedIndex(t, pq, 32, 200)
+	assert.True(t, pq.Trained())
+
+	// the size counts the codes which replaced the vectors
+	var want uint64
+	for i := range index.vertices {
+		for _, vertex := range index.vertices[i] {
+			assert.Nil(t, vertex.vector)
+			want += vertex.bytesSize()
+		}
+	}
+	assert.Equal(t, want, index.bytesSize)
+}
+
+func TestQuantizedSearchRerank(t *testing.T) {
+	index, raw := generateQuantizedIndex(t, NewBinaryQuantizer(32), 32, 300)
+	fetch := func(id uint64) (edge.Vector, error) {
+		return raw[id], nil
+	}
+	query := raw[7]
+	result, err := index.Search(context.Background(), query, 5, SearchEf(100), SearchRerank(50, fetch))
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(result))
+	assert.Equal(t, uint64(7), result[0].Id)
+	assert.InDelta(t, 0, result[0].Score, 1e-4)
+}
+
+func TestQuantizedSearchRerankSkipsMissing(t *testing.T) {
+	index, raw := generateQuantizedIndex(t, NewBinaryQuantizer(32), 32, 300)
+	query := raw[7]
+	fetch := func(id uint64) (edge.Vector, error) {
+		if id == 7 {
+			return nil, ItemNotFoundError
+		}
+		return raw[id], nil
+	}
+	result, err := index.Search(context.Background(), query, 5, SearchEf(100), SearchRerank(50, fetch))
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(result))
+	for _, item := range result {
+		assert.NotEqual(t, uint64(7), item.Id)
+	}
+
+	broken := errors.New("disk failure")
+	_, err = index.Search(context.Background(), query, 5, SearchEf(100), SearchRerank(50, func(id uint64) (edge.Vector, error) {
+		return nil, broken
+	}))
+	assert.ErrorIs(t, err, broken)
+}
+
+func TestScalarQuantizationCommitAndLoad(t *testing.T) {
+	for _, quantizer := range []*ScalarQuantizer{NewFloat16Quantizer(16), NewBFloat16Quantizer(16), NewFloat8Quantizer(16)} {
+		index, raw := generateQuantizedIndex(t, quantizer, 16, 200)
+
+		var buf bytes.Buffer
+		assert.Nil(t, index.Commit(&buf, true))
+
+		other := NewHnsw(16, distance.NewEuclidean(), HnswQuantization(&ScalarQuantizer{kind: quantizer.kind}))
+		assert.Nil(t, other.Load(&buf, true), quantizer.Name())
+		assert.Equal(t, index.Len(), other.Len())
+
+		for id, vector := range raw {
+			loaded, err := other.Get(id)
+			assert.Nil(t, err)
+			assert.Equal(t, len(vector), len(loaded))
+		}
+	}
+}
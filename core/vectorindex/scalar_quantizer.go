@@ -0,0 +1,152 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vectorindex
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/sjy-dv/coltt/edge"
+	"github.com/sjy-dv/coltt/pkg/compresshelper"
+	"github.com/sjy-dv/coltt/pkg/distance"
+)
+
+type scalarKind uint8
+
+const (
+	scalarFloat16 scalarKind = iota + 1
+	scalarBFloat16
+	scalarFloat8
+)
+
+var _ Quantizer = &ScalarQuantizer{}
+
+// ScalarQuantizer stores every component in a smaller float format
+// (f16, bf16 or f8) from pkg/compresshelper, no training is needed.
+type ScalarQuantizer struct {
+	dim  int
+	kind scalarKind
+}
+
+func NewFloat16Quantizer(dim int) *ScalarQuantizer {
+	return &ScalarQuantizer{dim: dim, kind: scalarFloat16}
+}
+
+func NewBFloat16Quantizer(dim int) *ScalarQuantizer {
+	return &ScalarQuantizer{dim: dim, kind: scalarBFloat16}
+}
+
+func NewFloat8Quantizer(dim int) *ScalarQuantizer {
+	return &ScalarQuantizer{dim: dim, kind: scalarFloat8}
+}
+
+func (q *ScalarQuantizer) Name() string {
+	switch q.kind {
+	case scalarBFloat16:
+		return "bf16"
+	case scalarFloat8:
+		return "f8"
+	}
+	return "f16"
+}
+
+func (q *ScalarQuantizer) TrainSize() int {
+	return 0
+}
+
+func (q *ScalarQuantizer) Trained() bool {
+	return true
+}
+
+func (q *ScalarQuantizer) Train(samples []edge.Vector) error {
+	return nil
+}
+
+func (q *ScalarQuantizer) Encode(v edge.Vector) []byte {
+	code := make([]byte, q.CodeSize(len(v)))
+	for i, x := range v {
+		switch q.kind {
+		case scalarFloat16:
+			binary.LittleEndian.PutUint16(code[i*2:], compresshelper.Fromfloat32(x).Bits())
+		case scalarBFloat16:
+			binary.LittleEndian.PutUint16(code[i*2:], compresshelper.BF16Fromfloat32(x).Bits())
+		case scalarFloat8:
+			code[i] = compresshelper.F8Fromfloat32(x).Bits()
+		}
+	}
+	return code
+}
+
+func (q *ScalarQuantizer) Decode(code []byte) edge.Vector {
+	out := make(edge.Vector, q.dim)
+	q.decodeTo(out, code)
+	return out
+}
+
+func (q *ScalarQuantizer) decodeTo(out edge.Vector, code []byte) {
+	for i := range out {
+		switch q.kind {
+		case scalarFloat16:
+			out[i] = compresshelper.Frombits(binary.LittleEndian.Uint16(code[i*2:])).Float32()
+		case scalarBFloat16:
+			out[i] = compresshelper.BF16Frombits(binary.LittleEndian.Uint16(code[i*2:])).Float32()
+		case scalarFloat8:
+			out[i] = compresshelper.F8Frombits(code[i]).Float32()
+		}
+	}
+}
+
+// QueryDistancer decodes into a buffer owned by the returned closure,
+// so it must not be shared between goroutines.
+func (q *ScalarQuantizer) QueryDistancer(query edge.Vector, dist distance.Space) func(code []byte) float32 {
+	buf := make(edge.Vector, q.dim)
+	return func(code []byte) float32 {
+		q.decodeTo(buf, code)
+		return dist.Distance(query, buf)
+	}
+}
+
+func (q *ScalarQuantizer) CodeSize(dim int) int {
+	if q.kind == scalarFloat8 {
+		return dim
+	}
+	return 2 * dim
+}
+
+func (q *ScalarQuantizer) Save(w io.Writer) error {
+	if err := binary.Write(w, binary.BigEndian, uint8(q.kind)); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.BigEndian, int32(q.dim))
+}
+
+func (q *ScalarQuantizer) Load(r io.Reader) error {
+	var kind uint8
+	var dim int32
+	if err := binary.Read(r, binary.BigEndian, &kind); err != nil {
+		return err
+	}
+	if scalarKind(kind) != q.kind {
+		return InvalidQuantizerErr
+	}
+	if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
+		return err
+	}
+	q.dim = int(dim)
+	return nil
+}
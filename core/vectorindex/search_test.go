@@ -0,0 +1,123 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package vectorindex
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/sjy-dv/coltt/edge"
+	"github.com/sjy-dv/coltt/pkg/distance"
+	"github.com/sjy-dv/coltt/pkg/gomath"
+	"github.com/stretchr/testify/assert"
+)
+
+func bruteForce(dist distance.Space, raw map[uint64]edge.Vector, query edge.Vector, k int, allow func(id uint64) bool) []uint64 {
+	ids := make([]uint64, 0, len(raw))
+	for id := range raw {
+		if allow(id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return dist.Distance(query, raw[ids[i]]) < dist.Distance(query, raw[ids[j]])
+	})
+	if len(ids) > k {
+		ids = ids[:k]
+	}
+	return ids
+}
+
+func TestSearchFilter(t *testing.T) {
+	dim, size := 16, 2000
+	index := NewHnsw(uint(dim), distance.NewEuclidean())
+	raw := make(map[uint64]edge.Vector, size)
+	for i := 0; i < size; i++ {
+		vector := edge.Vector(gomath.RandomStandardNormalVector(dim))
+		raw[uint64(i)] = vector
+		assert.Nil(t, index.Insert(uint64(i), vector, Metadata{"id": i}, index.RandomLevel()))
+	}
+	allow := func(id uint64) bool { return id%10 == 3 }
+
+	found, total := 0, 0
+	for q := 0; q < 20; q++ {
+		query := edge.Vector(gomath.RandomStandardNormalVector(dim))
+		result, err := index.Search(context.Background(), query, 10, SearchEf(100), SearchFilter(allow))
+		assert.Nil(t, err)
+		assert.Equal(t, 10, len(result))
+		got := make(map[uint64]bool, len(result))
+		for _, item := range result {
+			assert.True(t, allow(item.Id))
+			got[item.Id] = true
+		}
+		for _, id := range bruteForce(index.distancer, raw, query, 10, allow) {
+			if got[id] {
+				found++
+			}
+			total++
+		}
+	}
+	assert.GreaterOrEqual(t, float64(found)/float64(total), 0.9)
+}
+
+func TestSearchFilterExtendCandidates(t *testing.T) {
+	dim, size := 8, 1000
+	index := NewHnsw(uint(dim), distance.NewEuclidean(),
+		HnswSearchAlgorithm(HnswSearchHeuristic), HnswHeuristicExtendCandidates(true))
+	for i := 0; i < size; i++ {
+		vector := edge.Vector(gomath.RandomStandardNormalVector(dim))
+		assert.Nil(t, index.Insert(uint64(i), vector, Metadata{"id": i}, index.RandomLevel()))
+	}
+	allow := func(id uint64) bool { return id%7 == 0 }
+
+	// the neighbors of the allowed vertices extend the candidates and must be filtered too
+	for q := 0; q < 20; q++ {
+		query := edge.Vector(gomath.RandomStandardNormalVector(dim))
+		result, err := index.Search(context.Background(), query, 10, SearchFilter(allow))
+		assert.Nil(t, err)
+		assert.NotEmpty(t, result)
+		for _, item := range result {
+			assert.True(t, allow(item.Id))
+		}
+	}
+}
+
+func TestSearchExact(t *testing.T) {
+	dim, size := 16, 500
+	index := NewHnsw(uint(dim), distance.NewEuclidean())
+	raw := make(map[uint64]edge.Vector, size)
+	ids := make([]uint64, 0, size)
+	for i := 0; i < size; i++ {
+		vector := edge.Vector(gomath.RandomStandardNormalVector(dim))
+		raw[uint64(i)] = vector
+		assert.Nil(t, index.Insert(uint64(i), vector, Metadata{"id": i}, index.RandomLevel()))
+		if i%7 == 0 {
+			ids = append(ids, uint64(i))
+		}
+	}
+	ids = append(ids, uint64(size+1)) // unknown ids are skipped
+	query := edge.Vector(gomath.RandomStandardNormalVector(dim))
+	result, err := index.SearchExact(context.Background(), query, 5, ids)
+	assert.Nil(t, err)
+	want := bruteForce(index.distancer, raw, query, 5, func(id uint64) bool { return id%7 == 0 })
+	assert.Equal(t, len(want), len(result))
+	for i, item := range result {
+		assert.Equal(t, want[i], item.Id)
+	}
+}
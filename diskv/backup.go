@@ -0,0 +1,247 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskv
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sjy-dv/coltt/pkg/wal"
+)
+
+// backupFile is a data, hint or merge finished file of a backup, only the
+// first size bytes are copied so the active segment is cut at the backup point.
+type backupFile struct {
+	name string
+	size int64
+	fd   *os.File
+}
+
+// backupFiles opens the files of a point-in-time copy of the db.
+// It holds the db lock only to pin the active segment size and open the
+// files, a merge replacing them afterwards leaves the opened ones readable.
+// The caller closes them with closeBackupFiles.
+func (db *DB) backupFiles() ([]backupFile, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.closed {
+		return nil, ErrDBClosed
+	}
+	activeId := db.dataFiles.ActiveSegmentID()
+	files := make([]backupFile, 0, activeId+2)
+	add := func(ext string, id wal.SegmentID) error {
+		path := wal.SegmentFileName(db.options.DirPath, ext, id)
+		fd, err := os.Open(path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		stat, err := fd.Stat()
+		if err != nil {
+			_ = fd.Close()
+			return err
+		}
+		files = append(files, backupFile{name: filepath.Base(path), size: stat.Size(), fd: fd})
+		return nil
+	}
+	// segments removed by a merge leave gaps in the ids
+	for id := wal.SegmentID(1); id <= activeId; id++ {
+		if err := add(dataFileNameSuffix, id); err != nil {
+			closeBackupFiles(files)
+			return nil, err
+		}
+	}
+	if err := add(hintFileNameSuffix, 1); err != nil {
+		closeBackupFiles(files)
+		return nil, err
+	}
+	if err := add(mergeFinNameSuffix, 1); err != nil {
+		closeBackupFiles(files)
+		return nil, err
+	}
+	return files, nil
+}
+
+func closeBackupFiles(files []backupFile) {
+	for _, file := range files {
+		_ = file.fd.Close()
+	}
+}
+
+// Backup writes a consistent point-in-time copy of the db to w as a tar stream,
+// writes and merges are not blocked while it runs. Restore reads it back.
+func (db *DB) Backup(w io.Writer) error {
+	files, err := db.backupFiles()
+	if err != nil {
+		return err
+	}
+	defer closeBackupFiles(files)
+
+	tw := tar.NewWriter(w)
+	for _, file := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    file.name,
+			Mode:    0644,
+			Size:    file.size,
+			ModTime: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, io.NewSectionReader(file.fd, 0, file.size)); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// BackupDir writes a consistent point-in-time copy of the db into dir,
+// dir can be opened as a db or given to RestoreDir.
+func (db *DB) BackupDir(dir string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	files, err := db.backupFiles()
+	if err != nil {
+		return err
+	}
+	defer closeBackupFiles(files)
+
+	for _, file := range files {
+		dst, err := os.OpenFile(filepath.Join(dir, file.name), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(dst, io.NewSectionReader(file.fd, 0, file.size)); err != nil {
+			_ = dst.Close()
+			return err
+		}
+		if err := dst.Sync(); err != nil {
+			_ = dst.Close()
+			return err
+		}
+		if err := dst.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore writes the backup read from r into dirPath,
+// dirPath must not hold a db yet.
+func Restore(r io.Reader, dirPath string) error {
+	if err := prepareRestoreDir(dirPath); err != nil {
+		return err
+	}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := validBackupName(header.Name); err != nil {
+			return err
+		}
+		if err := writeRestoreFile(filepath.Join(dirPath, header.Name), tr); err != nil {
+			return err
+		}
+	}
+}
+
+// RestoreDir copies the backup written by BackupDir into dirPath,
+// dirPath must not hold a db yet.
+func RestoreDir(backupDir, dirPath string) error {
+	if err := prepareRestoreDir(dirPath); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(backupDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || validBackupName(entry.Name()) != nil {
+			continue
+		}
+		src, err := os.Open(filepath.Join(backupDir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		err = writeRestoreFile(filepath.Join(dirPath, entry.Name()), src)
+		_ = src.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func prepareRestoreDir(dirPath string) error {
+	if err := os.MkdirAll(dirPath, os.ModePerm); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if validBackupName(entry.Name()) == nil {
+			return fmt.Errorf("restore: %s already holds a db", dirPath)
+		}
+	}
+	return nil
+}
+
+func validBackupName(name string) error {
+	if name != filepath.Base(name) || strings.Contains(name, "..") {
+		return fmt.Errorf("restore: invalid file name %q in backup", name)
+	}
+	for _, ext := range []string{dataFileNameSuffix, hintFileNameSuffix, mergeFinNameSuffix} {
+		if strings.HasSuffix(name, ext) {
+			return nil
+		}
+	}
+	return errors.New("restore: not a diskv file " + name)
+}
+
+func writeRestoreFile(path string, r io.Reader) error {
+	dst, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, r); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err := dst.Sync(); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
@@ -0,0 +1,72 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskv
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupRestoreOpen(t *testing.T) {
+	db := openTestDB(t)
+	for i := 0; i < 2000; i++ {
+		assert.Nil(t, db.Put([]byte(fmt.Sprintf("key-%04d", i)), []byte(fmt.Sprintf("value-%d", i))))
+	}
+	// a merged db has a hint file to back up as well
+	assert.Nil(t, db.Merge(false))
+	for i := 0; i < 2000; i += 2 {
+		assert.Nil(t, db.Delete([]byte(fmt.Sprintf("key-%04d", i))))
+	}
+
+	var stream bytes.Buffer
+	assert.Nil(t, db.Backup(&stream))
+	backupDir := filepath.Join(t.TempDir(), "backup")
+	assert.Nil(t, db.BackupDir(backupDir))
+	// writes after the backup are not in it
+	assert.Nil(t, db.Put([]byte("key-0001"), []byte("changed")))
+
+	streamDir := filepath.Join(t.TempDir(), "stream")
+	assert.Nil(t, Restore(&stream, streamDir))
+	restoreDir := filepath.Join(t.TempDir(), "dir")
+	assert.Nil(t, RestoreDir(backupDir, restoreDir))
+	// a restore never overwrites a db
+	assert.NotNil(t, RestoreDir(backupDir, restoreDir))
+
+	for _, dirPath := range []string{streamDir, restoreDir} {
+		options := DefaultOptions
+		options.DirPath = dirPath
+		options.SegmentSize = 1 * MB
+		restored, err := Open(options)
+		assert.Nil(t, err)
+		assert.Equal(t, 1000, restored.Stat().KeysNum)
+		for i := 0; i < 2000; i++ {
+			value, err := restored.Get([]byte(fmt.Sprintf("key-%04d", i)))
+			if i%2 == 0 {
+				assert.Equal(t, ErrKeyNotFound, err)
+				continue
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, []byte(fmt.Sprintf("value-%d", i)), value)
+		}
+		assert.Nil(t, restored.Close())
+	}
+}
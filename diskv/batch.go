@@ -26,6 +26,7 @@ import (
 	"github.com/sjy-dv/coltt/diskv/utils"
 	"github.com/sjy-dv/coltt/pkg/bytebufferpool"
 	"github.com/sjy-dv/coltt/pkg/snowflake"
+	"github.com/sjy-dv/coltt/pkg/wal"
 )
 
 type Batch struct {
@@ -77,6 +78,7 @@ func newRecord() interface{} {
 func (b *Batch) init(rdonly, sync bool, db *DB) *Batch {
 	b.options.ReadOnly = rdonly
 	b.options.Sync = sync
+	b.options.Durability = wal.DurabilityDefault
 	b.db = db
 	b.lock()
 	return b
@@ -140,6 +142,39 @@ func (b *Batch) Put(key []byte, value []byte) error {
 	return nil
 }
 
+// PutWithTTL adds a key-value pair with ttl to the batch for writing.
+// A ttl <= 0 is rejected with ErrInvalidTTL, use Delete to drop the key.
+func (b *Batch) PutWithTTL(key []byte, value []byte, ttl time.Duration) error {
+	if len(key) == 0 {
+		return ErrKeyIsEmpty
+	}
+	if ttl <= 0 {
+		return ErrInvalidTTL
+	}
+	if b.db.closed {
+		return ErrDBClosed
+	}
+	if b.options.ReadOnly {
+		return ErrReadOnlyBatch
+	}
+
+	b.mu.Lock()
+	// write to pendingWrites
+	var record = b.lookupPendingWrites(key)
+	if record == nil {
+		// if the key does not exist in pendingWrites, write a new record
+		// the record will be put back to the pool when the batch is committed or rollbacked
+		record = b.db.recordPool.Get().(*LogRecord)
+		b.appendPendingWrites(key, record)
+	}
+
+	record.Key, record.Value = key, value
+	record.Type, record.Expire = LogRecordNormal, time.Now().Add(ttl).UnixNano()
+	b.mu.Unlock()
+
+	return nil
+}
+
 func (b *Batch) Get(key []byte) ([]byte, error) {
 	if len(key) == 0 {
 		return nil, ErrKeyIsEmpty
@@ -163,7 +198,10 @@ func (b *Batch) Get(key []byte) ([]byte, error) {
 	}
 
 	// get key/value from data file
-	chunkPosition := b.db.index.Get(key)
+	chunkPosition, err := b.db.index.Get(key)
+	if err != nil {
+		return nil, err
+	}
 	if chunkPosition == nil {
 		return nil, ErrKeyNotFound
 	}
@@ -178,7 +216,9 @@ func (b *Batch) Get(key []byte) ([]byte, error) {
 		panic("Deleted data cannot exist in the index")
 	}
 	if record.IsExpired(now) {
-		b.db.index.Delete(record.Key)
+		if _, _, err := b.db.index.Delete(record.Key); err != nil {
+			return nil, err
+		}
 		return nil, ErrKeyNotFound
 	}
 	return record.Value, nil
@@ -218,6 +258,108 @@ func (b *Batch) Delete(key []byte) error {
 	return nil
 }
 
+// Expire sets the ttl of the key.
+func (b *Batch) Expire(key []byte, ttl time.Duration) error {
+	return b.setExpire(key, func(now time.Time) int64 {
+		return now.Add(ttl).UnixNano()
+	})
+}
+
+// Persist removes the ttl of the key.
+func (b *Batch) Persist(key []byte) error {
+	return b.setExpire(key, func(time.Time) int64 {
+		return 0
+	})
+}
+
+// setExpire rewrites the live record of the key with a new expiry time.
+func (b *Batch) setExpire(key []byte, expireFn func(now time.Time) int64) error {
+	if len(key) == 0 {
+		return ErrKeyIsEmpty
+	}
+	if b.db.closed {
+		return ErrDBClosed
+	}
+	if b.options.ReadOnly {
+		return ErrReadOnlyBatch
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	// if the key exists in pendingWrites, update the expiry time directly
+	var record = b.lookupPendingWrites(key)
+	if record != nil {
+		if record.Type == LogRecordDeleted || record.IsExpired(now.UnixNano()) {
+			return ErrKeyNotFound
+		}
+		record.Expire = expireFn(now)
+		return nil
+	}
+
+	// otherwise read the value from the data file and rewrite the record
+	position, err := b.db.index.Get(key)
+	if err != nil {
+		return err
+	}
+	if position == nil {
+		return ErrKeyNotFound
+	}
+	chunk, err := b.db.dataFiles.Read(position)
+	if err != nil {
+		return err
+	}
+	record = decodeLogRecord(chunk)
+	if record.Type == LogRecordDeleted || record.IsExpired(now.UnixNano()) {
+		if _, _, err := b.db.index.Delete(key); err != nil {
+			return err
+		}
+		return ErrKeyNotFound
+	}
+	record.Expire = expireFn(now)
+	b.appendPendingWrites(key, record)
+	return nil
+}
+
+// TTL returns the remaining ttl of the key, -1 if the key has no ttl.
+func (b *Batch) TTL(key []byte) (time.Duration, error) {
+	if len(key) == 0 {
+		return -1, ErrKeyIsEmpty
+	}
+	if b.db.closed {
+		return -1, ErrDBClosed
+	}
+
+	now := time.Now().UnixNano()
+	b.mu.RLock()
+	var record = b.lookupPendingWrites(key)
+	b.mu.RUnlock()
+
+	if record == nil {
+		position, err := b.db.index.Get(key)
+		if err != nil {
+			return -1, err
+		}
+		if position == nil {
+			return -1, ErrKeyNotFound
+		}
+		chunk, err := b.db.dataFiles.Read(position)
+		if err != nil {
+			return -1, err
+		}
+		// an expired key is left to DeleteExpiredKeys, TTL may hold the read lock only
+		record = decodeLogRecord(chunk)
+	}
+	if record.Type == LogRecordDeleted || record.IsExpired(now) {
+		return -1, ErrKeyNotFound
+	}
+	if record.Expire == 0 {
+		return -1, nil
+	}
+	return time.Duration(record.Expire - now), nil
+}
+
 // Exist checks if the key exists in the database.
 func (b *Batch) Exist(key []byte) (bool, error) {
 	if len(key) == 0 {
@@ -238,7 +380,10 @@ func (b *Batch) Exist(key []byte) (bool, error) {
 	}
 
 	// check if the key exists in index
-	position := b.db.index.Get(key)
+	position, err := b.db.index.Get(key)
+	if err != nil {
+		return false, err
+	}
 	if position == nil {
 		return false, nil
 	}
@@ -251,20 +396,37 @@ func (b *Batch) Exist(key []byte) (bool, error) {
 
 	record = decodeLogRecord(chunk)
 	if record.Type == LogRecordDeleted || record.IsExpired(now) {
-		b.db.index.Delete(record.Key)
+		if _, _, err := b.db.index.Delete(record.Key); err != nil {
+			return false, err
+		}
 		return false, nil
 	}
 	return true, nil
 }
 
+// Commit writes the batch to the data files and makes it readable,
+// see BatchOptions.Durability for the wait on the fsyncs and their failure.
 func (b *Batch) Commit() error {
+	publish, err := b.commit()
+	if err != nil || publish == nil {
+		return err
+	}
+	// wait for the fsync after the db lock is released, so concurrent commits share it.
+	// The records are readable from the index once they are synced.
+	return publish()
+}
+
+// commit writes the batch to the data files. The records are put to the index
+// right away when the batch needs no sync and no batch written before it is
+// pending, otherwise the returned func publishes them with the lock released.
+func (b *Batch) commit() (func() error, error) {
 	defer b.unlock()
 	if b.db.closed {
-		return ErrDBClosed
+		return nil, ErrDBClosed
 	}
 
 	if b.options.ReadOnly || len(b.pendingWrites) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	b.mu.Lock()
@@ -272,10 +434,10 @@ func (b *Batch) Commit() error {
 
 	// check if committed or rollbacked
 	if b.committed {
-		return ErrBatchCommitted
+		return nil, ErrBatchCommitted
 	}
 	if b.rollbacked {
-		return ErrBatchRollbacked
+		return nil, ErrBatchRollbacked
 	}
 
 	batchId := b.batchId.Generate()
@@ -298,29 +460,70 @@ func (b *Batch) Commit() error {
 	}, b.db.encodeHeader, buf)
 	b.db.dataFiles.PendingWrites(endRecord)
 
-	// write to wal file
-	chunkPositions, err := b.db.dataFiles.WriteAll()
+	// write to wal file, the sync is waited for by Commit
+	chunkPositions, waitSync, err := b.db.dataFiles.WriteAllDeferSync(b.durability())
 	if err != nil {
 		b.db.dataFiles.ClearPendingWrites()
-		return err
+		return nil, err
 	}
 	if len(chunkPositions) != len(b.pendingWrites)+1 {
 		panic("chunk positions length is not equal to pending writes length")
 	}
 
-	// flush wal if necessary
-	if b.options.Sync && !b.db.options.Sync {
-		if err := b.db.dataFiles.Sync(); err != nil {
+	ticket := b.db.publisher.take()
+	if waitSync == nil && b.db.publisher.ready(ticket) {
+		err := b.publish(chunkPositions, now)
+		b.db.publisher.done()
+		b.committed = true
+		return nil, err
+	}
+	return func() error {
+		var err error
+		if waitSync != nil {
+			err = waitSync()
+		}
+		b.db.publisher.wait(ticket)
+		defer b.db.publisher.done()
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if err != nil {
+			// the records may not be on disk, so they are not made readable,
+			// the next open replays them if they are
+			b.discard()
+			b.rollbacked = true
 			return err
 		}
-	}
+		b.db.mu.Lock()
+		if !b.db.closed {
+			err = b.publish(chunkPositions, now)
+		}
+		b.db.mu.Unlock()
+		b.committed = true
+		return err
+	}, nil
+}
 
-	// write to index
+// publish writes the records of the batch to the index, db.mu must be held.
+// The records are in the data files already, so the batch stays committed
+// when the index fails and the error is returned.
+func (b *Batch) publish(chunkPositions []*wal.ChunkPosition, now int64) error {
 	for i, record := range b.pendingWrites {
-		if record.Type == LogRecordDeleted || record.IsExpired(now) {
-			b.db.index.Delete(record.Key)
-		} else {
-			b.db.index.Put(record.Key, chunkPositions[i])
+		var err error
+		switch {
+		case record.Type == LogRecordRangeDeleted:
+			err = b.commitRangeDelete(record)
+		case record.Type == LogRecordDeleted || record.IsExpired(now):
+			_, _, err = b.db.index.Delete(record.Key)
+		default:
+			_, err = b.db.index.Put(record.Key, chunkPositions[i])
+		}
+		if err != nil {
+			return err
+		}
+		if record.Type == LogRecordRangeDeleted {
+			b.db.recordPool.Put(record)
+			continue
 		}
 
 		if b.db.options.WatchQueueSize > 0 {
@@ -335,11 +538,28 @@ func (b *Batch) Commit() error {
 		// put the record back to the pool
 		b.db.recordPool.Put(record)
 	}
-
-	b.committed = true
 	return nil
 }
 
+// discard puts the records of a batch that is not published back to the pool.
+func (b *Batch) discard() {
+	for _, record := range b.pendingWrites {
+		b.db.recordPool.Put(record)
+	}
+}
+
+// durability resolves the durability of the batch, BatchOptions.Durability
+// first, then BatchOptions.Sync, then the db options.
+func (b *Batch) durability() wal.Durability {
+	switch {
+	case b.options.Durability != wal.DurabilityDefault:
+		return b.options.Durability
+	case b.options.Sync:
+		return wal.DurabilityFsync
+	}
+	return b.db.options.Durability
+}
+
 // Rollback discards an uncommitted batch instance.
 // the discard operation will clear the buffered data and release the lock.
 func (b *Batch) Rollback() error {
@@ -399,3 +619,61 @@ func (b *Batch) appendPendingWrites(key []byte, record *LogRecord) {
 	hashKey := utils.MemHash(key)
 	b.pendingWritesMap[hashKey] = append(b.pendingWritesMap[hashKey], len(b.pendingWrites)-1)
 }
+
+// publisher makes the committed batches readable in the order they were
+// written to the data files, so a batch waiting for its fsync holds back
+// the batches written after it.
+type publisher struct {
+	mu        sync.Mutex
+	cond      *sync.Cond
+	written   uint64 // tickets handed out to the batches written so far
+	published uint64 // batches published or discarded so far
+}
+
+func newPublisher() *publisher {
+	p := &publisher{}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+// take hands out the ticket of a batch written to the data files, db.mu must be held.
+func (p *publisher) take() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ticket := p.written
+	p.written++
+	return ticket
+}
+
+// ready reports whether every batch written before the ticket is published.
+func (p *publisher) ready(ticket uint64) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.published == ticket
+}
+
+// pending returns the ticket of the next batch, waiting for it covers
+// every batch written so far. db.mu must be held.
+func (p *publisher) pending() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.written
+}
+
+// wait returns once every batch written before the ticket is published
+// or discarded, db.mu must not be held.
+func (p *publisher) wait(ticket uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for p.published < ticket {
+		p.cond.Wait()
+	}
+}
+
+// done marks the batch holding the oldest pending ticket published or discarded.
+func (p *publisher) done() {
+	p.mu.Lock()
+	p.published++
+	p.mu.Unlock()
+	p.cond.Broadcast()
+}
@@ -0,0 +1,106 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskv
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/sjy-dv/coltt/pkg/wal"
+	"github.com/stretchr/testify/assert"
+)
+
+func openTestDB(t *testing.T) *DB {
+	options := DefaultOptions
+	options.DirPath = t.TempDir()
+	options.SegmentSize = 1 * MB
+	db, err := Open(options)
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	return db
+}
+
+func TestBatchConcurrentDurability(t *testing.T) {
+	db := openTestDB(t)
+	durabilities := []wal.Durability{wal.DurabilityFsync, wal.DurabilityNone, wal.DurabilityOSBuffer}
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			shared := []byte(fmt.Sprintf("shared-%d", w))
+			for i := 0; i < 50; i++ {
+				batch := db.NewBatch(BatchOptions{Durability: durabilities[(w+i)%len(durabilities)]})
+				key := []byte(fmt.Sprintf("key-%d-%d", w, i))
+				value := []byte(fmt.Sprintf("value-%d", i))
+				assert.Nil(t, batch.Put(key, value))
+				assert.Nil(t, batch.Put(shared, value))
+				assert.Nil(t, batch.Commit())
+
+				// a committed batch is readable, and a later batch is never
+				// overwritten by an earlier one still waiting for its sync
+				got, err := db.Get(key)
+				assert.Nil(t, err)
+				assert.Equal(t, value, got)
+				got, err = db.Get(shared)
+				assert.Nil(t, err)
+				assert.Equal(t, value, got)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	stats := db.Stat().Sync
+	assert.True(t, stats.Syncs > 0)
+	assert.True(t, stats.SyncedWrites >= stats.Syncs)
+}
+
+func TestBatchMergeWaitsForPendingSync(t *testing.T) {
+	db := openTestDB(t)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				batch := db.NewBatch(BatchOptions{Durability: wal.DurabilityFsync})
+				assert.Nil(t, batch.Put([]byte(fmt.Sprintf("key-%d-%d", w, i)), []byte("value")))
+				assert.Nil(t, batch.Commit())
+			}
+		}(w)
+	}
+	for i := 0; i < 5; i++ {
+		err := db.Merge(true)
+		if err != ErrMergeRunning {
+			assert.Nil(t, err)
+		}
+	}
+	wg.Wait()
+
+	for w := 0; w < 4; w++ {
+		for i := 0; i < 100; i++ {
+			_, err := db.Get([]byte(fmt.Sprintf("key-%d-%d", w, i)))
+			assert.Nil(t, err, "key-%d-%d", w, i)
+		}
+	}
+}
@@ -42,6 +42,9 @@ const (
 	mergeFinNameSuffix = ".MERGEFIN"
 )
 
+// expiredKeysBatchSize is the number of keys DeleteExpiredKeys checks per lock
+const expiredKeysBatchSize = 100
+
 type DB struct {
 	dataFiles        *wal.WAL // data files are a sets of segment files in WAL.
 	hintFile         *wal.WAL // hint file is used to store the key and the position for fast startup.
@@ -49,6 +52,8 @@ type DB struct {
 	options          Options
 	fileLock         *flock.Flock
 	mu               sync.RWMutex
+	publisher        *publisher       // orders the batches made readable after their sync
+	pins             map[*wal.WAL]int // open snapshots per generation of the data files, a merge leaves a pinned one open
 	closed           bool
 	mergeRunning     uint32 // indicate if the database is merging
 	batchPool        sync.Pool
@@ -56,8 +61,9 @@ type DB struct {
 	encodeHeader     []byte
 	watchCh          chan *Event // user consume channel for watch events
 	watcher          *Watcher
-	expiredCursorKey []byte     // the location to which DeleteExpiredKeys executes.
-	cronScheduler    *cron.Cron // cron scheduler for auto merge task
+	expiredCursorKey []byte        // the location to which DeleteExpiredKeys executes.
+	indexCheckpoint  wal.SegmentID // segments before it are already in the Hash index.
+	cronScheduler    *cron.Cron    // cron scheduler for auto merge task
 }
 
 // Stat represents the statistics of the database.
@@ -66,6 +72,8 @@ type Stat struct {
 	KeysNum int
 	// Total disk size of database directory
 	DiskSize int64
+	// fsync latencies and batch sizes of the data files
+	Sync wal.SyncStats
 }
 
 func Open(options Options) (*DB, error) {
@@ -91,29 +99,51 @@ func Open(options Options) (*DB, error) {
 		return nil, ErrDatabaseIsUsing
 	}
 
+	// a pending merge replaces the data files, a persisted index no longer matches them
+	_, err = os.Stat(mergeDirPath(options.DirPath))
+	mergePending := err == nil
+
 	// load merge files if exists
 	if err = loadMergeFiles(options.DirPath); err != nil {
+		_ = fileLock.Unlock()
 		return nil, err
 	}
 
 	// init DB instance
 	db := &DB{
-		index:        index.NewIndexer(),
 		options:      options,
 		fileLock:     fileLock,
 		batchPool:    sync.Pool{New: newBatch},
 		recordPool:   sync.Pool{New: newRecord},
+		publisher:    newPublisher(),
+		pins:         make(map[*wal.WAL]int),
 		encodeHeader: make([]byte, maxLogRecordHeaderSize),
 	}
+	// release the files opened so far, so the directory can be repaired and opened again
+	fail := func(err error) (*DB, error) {
+		if db.index != nil {
+			_ = db.index.Close()
+		}
+		if db.dataFiles != nil {
+			_ = db.dataFiles.Close()
+		}
+		_ = fileLock.Unlock()
+		return nil, err
+	}
 
 	// open data files
 	if db.dataFiles, err = db.openWalFiles(); err != nil {
-		return nil, err
+		return fail(err)
+	}
+
+	// open index
+	if db.index, err = db.openIndex(mergePending); err != nil {
+		return fail(err)
 	}
 
 	// load index
 	if err = db.loadIndex(); err != nil {
-		return nil, err
+		return fail(err)
 	}
 
 	// enable watch
@@ -154,6 +184,7 @@ func (db *DB) openWalFiles() (*wal.WAL, error) {
 		SegmentFileExt: dataFileNameSuffix,
 		Sync:           db.options.Sync,
 		BytesPerSync:   db.options.BytesPerSync,
+		Compression:    db.options.Compression,
 	})
 	if err != nil {
 		return nil, err
@@ -162,9 +193,11 @@ func (db *DB) openWalFiles() (*wal.WAL, error) {
 }
 
 func (db *DB) loadIndex() error {
-	// load index frm hint file
-	if err := db.loadIndexFromHintFile(); err != nil {
-		return err
+	// load index frm hint file, a checkpointed Hash index already holds the hint records
+	if db.indexCheckpoint == 0 {
+		if err := db.loadIndexFromHintFile(); err != nil {
+			return err
+		}
 	}
 	// load index from data files
 	if err := db.loadIndexFromWAL(); err != nil {
@@ -176,19 +209,31 @@ func (db *DB) loadIndex() error {
 // Close the database, close all data files and release file lock.
 // Set the closed flag to true.
 // The DB instance cannot be used after closing.
+// Every resource is released even when closing one of them fails,
+// the first error is returned.
 func (db *DB) Close() error {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
-	if err := db.closeFiles(); err != nil {
-		return err
+	var firstErr error
+	keep := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
 	}
 
-	// release file lock
-	if err := db.fileLock.Unlock(); err != nil {
-		return err
+	keep(db.closeIndex())
+	keep(db.closeFiles())
+	// the generations replaced by a merge and still pinned by snapshots
+	for files := range db.pins {
+		if files != db.dataFiles {
+			_ = files.Close()
+		}
 	}
 
+	// release file lock
+	keep(db.fileLock.Unlock())
+
 	// close watch channel
 	if db.options.WatchQueueSize > 0 {
 		close(db.watchCh)
@@ -200,22 +245,20 @@ func (db *DB) Close() error {
 	}
 
 	db.closed = true
-	return nil
+	return firstErr
 }
 
 // closeFiles close all data files and hint file
 func (db *DB) closeFiles() error {
 	// close wal
-	if err := db.dataFiles.Close(); err != nil {
-		return err
-	}
+	err := db.dataFiles.Close()
 	// close hint file if exists
 	if db.hintFile != nil {
-		if err := db.hintFile.Close(); err != nil {
-			return err
+		if hintErr := db.hintFile.Close(); err == nil {
+			err = hintErr
 		}
 	}
-	return nil
+	return err
 }
 
 // Sync all data files to the underlying storage.
@@ -239,6 +282,7 @@ func (db *DB) Stat() *Stat {
 	return &Stat{
 		KeysNum:  db.index.Size(),
 		DiskSize: diskSize,
+		Sync:     db.dataFiles.SyncStats(),
 	}
 }
 
@@ -259,6 +303,21 @@ func (db *DB) Put(key []byte, value []byte) error {
 	return batch.Commit()
 }
 
+// PutWithTTL writes a key-value pair that expires after ttl, ErrInvalidTTL is returned for a ttl <= 0.
+func (db *DB) PutWithTTL(key []byte, value []byte, ttl time.Duration) error {
+	batch := db.batchPool.Get().(*Batch)
+	defer func() {
+		batch.reset()
+		db.batchPool.Put(batch)
+	}()
+	batch.init(false, false, db)
+	if err := batch.PutWithTTL(key, value, ttl); err != nil {
+		_ = batch.Rollback()
+		return err
+	}
+	return batch.Commit()
+}
+
 func (db *DB) Get(key []byte) ([]byte, error) {
 	batch := db.batchPool.Get().(*Batch)
 	batch.init(true, false, db)
@@ -298,6 +357,143 @@ func (db *DB) Exist(key []byte) (bool, error) {
 	return batch.Exist(key)
 }
 
+// Expire sets the ttl of an existing key.
+func (db *DB) Expire(key []byte, ttl time.Duration) error {
+	batch := db.batchPool.Get().(*Batch)
+	defer func() {
+		batch.reset()
+		db.batchPool.Put(batch)
+	}()
+	batch.init(false, false, db)
+	if err := batch.Expire(key, ttl); err != nil {
+		_ = batch.Rollback()
+		return err
+	}
+	return batch.Commit()
+}
+
+// TTL returns the remaining ttl of the key, -1 if the key has no ttl.
+func (db *DB) TTL(key []byte) (time.Duration, error) {
+	batch := db.batchPool.Get().(*Batch)
+	batch.init(true, false, db)
+	defer func() {
+		_ = batch.Commit()
+		batch.reset()
+		db.batchPool.Put(batch)
+	}()
+	return batch.TTL(key)
+}
+
+// Persist removes the ttl of an existing key.
+func (db *DB) Persist(key []byte) error {
+	batch := db.batchPool.Get().(*Batch)
+	defer func() {
+		batch.reset()
+		db.batchPool.Put(batch)
+	}()
+	batch.init(false, false, db)
+	if err := batch.Persist(key); err != nil {
+		_ = batch.Rollback()
+		return err
+	}
+	return batch.Commit()
+}
+
+// DeleteExpiredKeys scans the index in ascending order and removes expired keys.
+// The db lock is held for one batch of keys at a time, so reads and writes are
+// not blocked for the whole scan. When timeout elapses the scan stops and the
+// next call resumes from expiredCursorKey.
+//
+// A Hash index has no key order to resume from, its table is walked once
+// under the db lock and the walk stops when timeout elapses.
+func (db *DB) DeleteExpiredKeys(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	now := time.Now().UnixNano()
+	for time.Now().Before(deadline) {
+		finished, err := db.deleteExpiredBatch(now)
+		if errors.Is(err, index.ErrUnordered) {
+			return db.deleteExpiredUnordered(now, deadline)
+		}
+		if err != nil || finished {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteExpiredUnordered walks the index once and removes the expired keys.
+func (db *DB) deleteExpiredUnordered(now int64, deadline time.Time) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.closed {
+		return ErrDBClosed
+	}
+
+	// the keys are collected first since the index can't be modified while it is traversed
+	var expired [][]byte
+	err := db.index.Range(func(key []byte, pos *wal.ChunkPosition) (bool, error) {
+		chunk, err := db.dataFiles.Read(pos)
+		if err != nil {
+			return false, err
+		}
+		if decodeLogRecord(chunk).IsExpired(now) {
+			expired = append(expired, key)
+		}
+		return time.Now().Before(deadline), nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range expired {
+		if _, _, err := db.index.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteExpiredBatch removes the expired keys of the next batch after
+// expiredCursorKey, it reports whether the whole index was scanned.
+func (db *DB) deleteExpiredBatch(now int64) (bool, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.closed {
+		return false, ErrDBClosed
+	}
+
+	positions := make([]*wal.ChunkPosition, 0, expiredKeysBatchSize)
+	err := db.index.AscendGreaterOrEqual(db.expiredCursorKey, func(k []byte, pos *wal.ChunkPosition) (bool, error) {
+		positions = append(positions, pos)
+		return len(positions) < expiredKeysBatchSize, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	// a full pass is done, the next call starts from the first key again
+	if len(positions) == 0 {
+		db.expiredCursorKey = nil
+		return true, nil
+	}
+
+	for _, pos := range positions {
+		chunk, err := db.dataFiles.Read(pos)
+		if err != nil {
+			return false, err
+		}
+		record := decodeLogRecord(chunk)
+		if record.IsExpired(now) {
+			if _, _, err := db.index.Delete(record.Key); err != nil {
+				return false, err
+			}
+		}
+		// the smallest key greater than the current one
+		cursor := make([]byte, len(record.Key)+1)
+		copy(cursor, record.Key)
+		db.expiredCursorKey = cursor
+	}
+	return false, nil
+}
+
 func (db *DB) Watch() (<-chan *Event, error) {
 	if db.options.WatchQueueSize <= 0 {
 		return nil, ErrWatchDisabled
@@ -306,11 +502,11 @@ func (db *DB) Watch() (<-chan *Event, error) {
 }
 
 // Ascend calls handleFn for each key/value pair in the db in ascending order.
-func (db *DB) Ascend(handleFn func(k []byte, v []byte) (bool, error)) {
+func (db *DB) Ascend(handleFn func(k []byte, v []byte) (bool, error)) error {
 	db.mu.RLock()
 	defer db.mu.RUnlock()
 
-	db.index.Ascend(func(key []byte, pos *wal.ChunkPosition) (bool, error) {
+	return db.index.Ascend(func(key []byte, pos *wal.ChunkPosition) (bool, error) {
 		chunk, err := db.dataFiles.Read(pos)
 		if err != nil {
 			return false, err
@@ -323,11 +519,11 @@ func (db *DB) Ascend(handleFn func(k []byte, v []byte) (bool, error)) {
 }
 
 // AscendRange calls handleFn for each key/value pair in the db within the range [startKey, endKey] in ascending order.
-func (db *DB) AscendRange(startKey, endKey []byte, handleFn func(k []byte, v []byte) (bool, error)) {
+func (db *DB) AscendRange(startKey, endKey []byte, handleFn func(k []byte, v []byte) (bool, error)) error {
 	db.mu.RLock()
 	defer db.mu.RUnlock()
 
-	db.index.AscendRange(startKey, endKey, func(key []byte, pos *wal.ChunkPosition) (bool, error) {
+	return db.index.AscendRange(startKey, endKey, func(key []byte, pos *wal.ChunkPosition) (bool, error) {
 		chunk, err := db.dataFiles.Read(pos)
 		if err != nil {
 			return false, nil
@@ -340,11 +536,11 @@ func (db *DB) AscendRange(startKey, endKey []byte, handleFn func(k []byte, v []b
 }
 
 // AscendGreaterOrEqual calls handleFn for each key/value pair in the db with keys greater than or equal to the given key.
-func (db *DB) AscendGreaterOrEqual(key []byte, handleFn func(k []byte, v []byte) (bool, error)) {
+func (db *DB) AscendGreaterOrEqual(key []byte, handleFn func(k []byte, v []byte) (bool, error)) error {
 	db.mu.RLock()
 	defer db.mu.RUnlock()
 
-	db.index.AscendGreaterOrEqual(key, func(key []byte, pos *wal.ChunkPosition) (bool, error) {
+	return db.index.AscendGreaterOrEqual(key, func(key []byte, pos *wal.ChunkPosition) (bool, error) {
 		chunk, err := db.dataFiles.Read(pos)
 		if err != nil {
 			return false, nil
@@ -356,7 +552,7 @@ func (db *DB) AscendGreaterOrEqual(key []byte, handleFn func(k []byte, v []byte)
 	})
 }
 
-func (db *DB) AscendKeys(pattern []byte, filterExpired bool, handleFn func(k []byte) (bool, error)) {
+func (db *DB) AscendKeys(pattern []byte, filterExpired bool, handleFn func(k []byte) (bool, error)) error {
 	db.mu.RLock()
 	defer db.mu.RUnlock()
 
@@ -365,7 +561,7 @@ func (db *DB) AscendKeys(pattern []byte, filterExpired bool, handleFn func(k []b
 		reg = regexp.MustCompile(string(pattern))
 	}
 
-	db.index.Ascend(func(key []byte, pos *wal.ChunkPosition) (bool, error) {
+	return db.index.Ascend(func(key []byte, pos *wal.ChunkPosition) (bool, error) {
 		if reg == nil || reg.Match(key) {
 			var invalid bool
 			if filterExpired {
@@ -387,11 +583,11 @@ func (db *DB) AscendKeys(pattern []byte, filterExpired bool, handleFn func(k []b
 }
 
 // Descend calls handleFn for each key/value pair in the db in descending order.
-func (db *DB) Descend(handleFn func(k []byte, v []byte) (bool, error)) {
+func (db *DB) Descend(handleFn func(k []byte, v []byte) (bool, error)) error {
 	db.mu.RLock()
 	defer db.mu.RUnlock()
 
-	db.index.Descend(func(key []byte, pos *wal.ChunkPosition) (bool, error) {
+	return db.index.Descend(func(key []byte, pos *wal.ChunkPosition) (bool, error) {
 		chunk, err := db.dataFiles.Read(pos)
 		if err != nil {
 			return false, nil
@@ -404,11 +600,11 @@ func (db *DB) Descend(handleFn func(k []byte, v []byte) (bool, error)) {
 }
 
 // DescendRange calls handleFn for each key/value pair in the db within the range [startKey, endKey] in descending order.
-func (db *DB) DescendRange(startKey, endKey []byte, handleFn func(k []byte, v []byte) (bool, error)) {
+func (db *DB) DescendRange(startKey, endKey []byte, handleFn func(k []byte, v []byte) (bool, error)) error {
 	db.mu.RLock()
 	defer db.mu.RUnlock()
 
-	db.index.DescendRange(startKey, endKey, func(key []byte, pos *wal.ChunkPosition) (bool, error) {
+	return db.index.DescendRange(startKey, endKey, func(key []byte, pos *wal.ChunkPosition) (bool, error) {
 		chunk, err := db.dataFiles.Read(pos)
 		if err != nil {
 			return false, nil
@@ -421,11 +617,11 @@ func (db *DB) DescendRange(startKey, endKey []byte, handleFn func(k []byte, v []
 }
 
 // DescendLessOrEqual calls handleFn for each key/value pair in the db with keys less than or equal to the given key.
-func (db *DB) DescendLessOrEqual(key []byte, handleFn func(k []byte, v []byte) (bool, error)) {
+func (db *DB) DescendLessOrEqual(key []byte, handleFn func(k []byte, v []byte) (bool, error)) error {
 	db.mu.RLock()
 	defer db.mu.RUnlock()
 
-	db.index.DescendLessOrEqual(key, func(key []byte, pos *wal.ChunkPosition) (bool, error) {
+	return db.index.DescendLessOrEqual(key, func(key []byte, pos *wal.ChunkPosition) (bool, error) {
 		chunk, err := db.dataFiles.Read(pos)
 		if err != nil {
 			return false, nil
@@ -437,7 +633,7 @@ func (db *DB) DescendLessOrEqual(key []byte, handleFn func(k []byte, v []byte) (
 	})
 }
 
-func (db *DB) DescendKeys(pattern []byte, filterExpired bool, handleFn func(k []byte) (bool, error)) {
+func (db *DB) DescendKeys(pattern []byte, filterExpired bool, handleFn func(k []byte) (bool, error)) error {
 	db.mu.RLock()
 	defer db.mu.RUnlock()
 
@@ -446,7 +642,7 @@ func (db *DB) DescendKeys(pattern []byte, filterExpired bool, handleFn func(k []
 		reg = regexp.MustCompile(string(pattern))
 	}
 
-	db.index.Descend(func(key []byte, pos *wal.ChunkPosition) (bool, error) {
+	return db.index.Descend(func(key []byte, pos *wal.ChunkPosition) (bool, error) {
 		if reg == nil || reg.Match(key) {
 			var invalid bool
 			if filterExpired {
@@ -494,6 +690,31 @@ func checkOptions(options Options) error {
 	return nil
 }
 
+// replayIndexRecord applies a record of a finished batch to the index.
+func (db *DB) replayIndexRecord(idxRecord *IndexRecord) error {
+	switch idxRecord.recordType {
+	case LogRecordNormal:
+		_, err := db.index.Put(idxRecord.key, idxRecord.position)
+		return err
+	case LogRecordDeleted:
+		_, _, err := db.index.Delete(idxRecord.key)
+		return err
+	case LogRecordRangeDeleted:
+		// only the keys indexed so far are covered,
+		// the ones written after the tombstone are replayed later.
+		keys, err := rangeKeys(db.index, idxRecord.key, idxRecord.end)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if _, _, err := db.index.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (db *DB) loadIndexFromWAL() error {
 	mergeFinSegmentId, err := getMergeFinSegmentId(db.options.DirPath)
 	if err != nil {
@@ -508,7 +729,9 @@ func (db *DB) loadIndexFromWAL() error {
 		// if the current segment id is less than the mergeFinSegmentId,
 		// we can skip this segment because it has been merged,
 		// and we can load index from the hint file directly.
-		if reader.CurrentSegmentId() <= mergeFinSegmentId {
+		// segments before the index checkpoint are already in a Hash index.
+		if reader.CurrentSegmentId() <= mergeFinSegmentId ||
+			reader.CurrentSegmentId() < db.indexCheckpoint {
 			reader.SkipCurrentSegment()
 			continue
 		}
@@ -518,7 +741,9 @@ func (db *DB) loadIndexFromWAL() error {
 			if err == io.EOF {
 				break
 			}
-			return err
+			pos := reader.CurrentChunkPosition()
+			return fmt.Errorf("read data file %d at block %d offset %d, the db can be repaired offline with Repair: %w",
+				pos.SegmentId, pos.BlockNumber, pos.ChunkOffset, err)
 		}
 		// decode and get log record
 		record := decodeLogRecord(chunk)
@@ -531,11 +756,8 @@ func (db *DB) loadIndexFromWAL() error {
 				return err
 			}
 			for _, idxRecord := range indexRecords[uint64(batchId)] {
-				if idxRecord.recordType == LogRecordNormal {
-					db.index.Put(idxRecord.key, idxRecord.position)
-				}
-				if idxRecord.recordType == LogRecordDeleted {
-					db.index.Delete(idxRecord.key)
+				if err := db.replayIndexRecord(idxRecord); err != nil {
+					return err
 				}
 			}
 			// delete indexRecords according to batchId after indexing
@@ -544,20 +766,27 @@ func (db *DB) loadIndexFromWAL() error {
 			// if the record is a normal record and the batch id is 0,
 			// it means that the record is involved in the merge operation.
 			// so put the record into index directly.
-			db.index.Put(record.Key, position)
+			if _, err := db.index.Put(record.Key, position); err != nil {
+				return err
+			}
 		} else {
 			// expired records should not be indexed
 			if record.IsExpired(now) {
-				db.index.Delete(record.Key)
+				if _, _, err := db.index.Delete(record.Key); err != nil {
+					return err
+				}
 				continue
 			}
 			// put the record into the temporary indexRecords
-			indexRecords[record.BatchId] = append(indexRecords[record.BatchId],
-				&IndexRecord{
-					key:        record.Key,
-					recordType: record.Type,
-					position:   position,
-				})
+			idxRecord := &IndexRecord{
+				key:        record.Key,
+				recordType: record.Type,
+				position:   position,
+			}
+			if record.Type == LogRecordRangeDeleted {
+				idxRecord.end = record.Value
+			}
+			indexRecords[record.BatchId] = append(indexRecords[record.BatchId], idxRecord)
 		}
 	}
 	db.dataFiles.SetIsStartupTraversal(false)
@@ -0,0 +1,71 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskv
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sjy-dv/coltt/diskv/index"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingCloseIndex struct {
+	index.Indexer
+}
+
+func (failingCloseIndex) Close() error {
+	return errors.New("close failed")
+}
+
+func TestCloseReleasesFilesOnIndexError(t *testing.T) {
+	options := DefaultOptions
+	options.DirPath = t.TempDir()
+	db, err := Open(options)
+	assert.Nil(t, err)
+	assert.Nil(t, db.Put([]byte("key"), []byte("value")))
+
+	db.index = failingCloseIndex{db.index}
+	assert.EqualError(t, db.Close(), "close failed")
+
+	// the file lock is released, so the directory opens again
+	db, err = Open(options)
+	assert.Nil(t, err)
+	defer db.Close()
+	value, err := db.Get([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestPutWithTTL(t *testing.T) {
+	db := openTestDB(t)
+	assert.Equal(t, ErrInvalidTTL, db.PutWithTTL([]byte("key"), []byte("value"), 0))
+	assert.Equal(t, ErrInvalidTTL, db.PutWithTTL([]byte("key"), []byte("value"), -time.Second))
+	_, err := db.Get([]byte("key"))
+	assert.Equal(t, ErrKeyNotFound, err)
+
+	assert.Nil(t, db.PutWithTTL([]byte("key"), []byte("value"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	_, err = db.TTL([]byte("key"))
+	assert.Equal(t, ErrKeyNotFound, err)
+	// TTL leaves the expired key to DeleteExpiredKeys
+	assert.Equal(t, 1, db.Stat().KeysNum)
+	assert.Nil(t, db.DeleteExpiredKeys(time.Second))
+	assert.Equal(t, 0, db.Stat().KeysNum)
+}
@@ -0,0 +1,72 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskv
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sjy-dv/coltt/diskv/index"
+	"github.com/stretchr/testify/assert"
+)
+
+func openHashTestDB(t *testing.T, dirPath string) *DB {
+	options := DefaultOptions
+	options.DirPath = dirPath
+	options.SegmentSize = 1 * MB
+	options.IndexType = index.Hash
+	db, err := Open(options)
+	assert.Nil(t, err)
+	return db
+}
+
+func TestHashIndexReopen(t *testing.T) {
+	dirPath := t.TempDir()
+	db := openHashTestDB(t, dirPath)
+	for i := 0; i < 1000; i++ {
+		assert.Nil(t, db.Put([]byte(fmt.Sprintf("key-%04d", i)), []byte(fmt.Sprintf("value-%d", i))))
+	}
+	assert.Nil(t, db.Delete([]byte("key-0000")))
+	assert.Nil(t, db.DeleteRange([]byte("key-0100"), []byte("key-0200")))
+	assert.Nil(t, db.PutWithTTL([]byte("ttl"), []byte("value"), time.Millisecond))
+	assert.Nil(t, db.Close())
+
+	db = openHashTestDB(t, dirPath)
+	defer db.Close()
+	for i := 0; i < 1000; i++ {
+		value, err := db.Get([]byte(fmt.Sprintf("key-%04d", i)))
+		if i == 0 || (i >= 100 && i < 200) {
+			assert.Equal(t, ErrKeyNotFound, err)
+			continue
+		}
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(fmt.Sprintf("value-%d", i)), value)
+	}
+
+	// the table is walked once for the expired keys
+	time.Sleep(10 * time.Millisecond)
+	assert.Nil(t, db.DeleteExpiredKeys(time.Second))
+	assert.Equal(t, 899, db.Stat().KeysNum)
+
+	// the hash table has no key order
+	err := db.Ascend(func(k []byte, v []byte) (bool, error) { return true, nil })
+	assert.ErrorIs(t, err, index.ErrUnordered)
+	_, err = db.Snapshot()
+	assert.ErrorIs(t, err, index.ErrUnordered)
+}
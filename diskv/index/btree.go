@@ -49,116 +49,136 @@ func (it *item) Less(bi btree.Item) bool {
 	return bytes.Compare(it.key, bi.(*item).key) < 0
 }
 
-func (mt *MemoryBTree) Put(key []byte, position *wal.ChunkPosition) *wal.ChunkPosition {
+func (mt *MemoryBTree) Put(key []byte, position *wal.ChunkPosition) (*wal.ChunkPosition, error) {
 	mt.lock.Lock()
 	defer mt.lock.Unlock()
 
 	oldValue := mt.tree.ReplaceOrInsert(&item{key: key, pos: position})
 	if oldValue != nil {
-		return oldValue.(*item).pos
+		return oldValue.(*item).pos, nil
 	}
-	return nil
+	return nil, nil
 }
 
-func (mt *MemoryBTree) Get(key []byte) *wal.ChunkPosition {
+func (mt *MemoryBTree) Get(key []byte) (*wal.ChunkPosition, error) {
 	mt.lock.RLock()
 	defer mt.lock.RUnlock()
 	value := mt.tree.Get(&item{key: key})
 	if value != nil {
-		return value.(*item).pos
+		return value.(*item).pos, nil
 	}
-	return nil
+	return nil, nil
 }
 
-func (mt *MemoryBTree) Delete(key []byte) (*wal.ChunkPosition, bool) {
+func (mt *MemoryBTree) Delete(key []byte) (*wal.ChunkPosition, bool, error) {
 	mt.lock.Lock()
 	defer mt.lock.Unlock()
 
 	value := mt.tree.Delete(&item{key: key})
 	if value != nil {
-		return value.(*item).pos, true
+		return value.(*item).pos, true, nil
 	}
-	return nil, false
+	return nil, false, nil
 }
 
 func (mt *MemoryBTree) Size() int {
 	return mt.tree.Len()
 }
 
-func (mt *MemoryBTree) Ascend(handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) {
+func (mt *MemoryBTree) Ascend(handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error {
 	mt.lock.RLock()
 	defer mt.lock.RUnlock()
 
+	var err error
 	mt.tree.Ascend(func(i btree.Item) bool {
-		cont, err := handleFn(i.(*item).key, i.(*item).pos)
-		if err != nil {
-			return false
-		}
-		return cont
+		var cont bool
+		cont, err = handleFn(i.(*item).key, i.(*item).pos)
+		return err == nil && cont
 	})
+	return err
 }
 
-func (mt *MemoryBTree) Descend(handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) {
+func (mt *MemoryBTree) Descend(handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error {
 	mt.lock.RLock()
 	defer mt.lock.RUnlock()
 
+	var err error
 	mt.tree.Descend(func(i btree.Item) bool {
-		cont, err := handleFn(i.(*item).key, i.(*item).pos)
-		if err != nil {
-			return false
-		}
-		return cont
+		var cont bool
+		cont, err = handleFn(i.(*item).key, i.(*item).pos)
+		return err == nil && cont
 	})
+	return err
 }
 
-func (mt *MemoryBTree) AscendRange(startKey, endKey []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) {
+func (mt *MemoryBTree) AscendRange(startKey, endKey []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error {
 	mt.lock.RLock()
 	defer mt.lock.RUnlock()
 
+	var err error
 	mt.tree.AscendRange(&item{key: startKey}, &item{key: endKey}, func(i btree.Item) bool {
-		cont, err := handleFn(i.(*item).key, i.(*item).pos)
-		if err != nil {
-			return false
-		}
-		return cont
+		var cont bool
+		cont, err = handleFn(i.(*item).key, i.(*item).pos)
+		return err == nil && cont
 	})
+	return err
 }
 
-func (mt *MemoryBTree) DescendRange(startKey, endKey []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) {
+func (mt *MemoryBTree) DescendRange(startKey, endKey []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error {
 	mt.lock.RLock()
 	defer mt.lock.RUnlock()
 
+	var err error
 	mt.tree.DescendRange(&item{key: startKey}, &item{key: endKey}, func(i btree.Item) bool {
-		cont, err := handleFn(i.(*item).key, i.(*item).pos)
-		if err != nil {
-			return false
-		}
-		return cont
+		var cont bool
+		cont, err = handleFn(i.(*item).key, i.(*item).pos)
+		return err == nil && cont
 	})
+	return err
 }
 
-func (mt *MemoryBTree) AscendGreaterOrEqual(key []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) {
+func (mt *MemoryBTree) AscendGreaterOrEqual(key []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error {
 	mt.lock.RLock()
 	defer mt.lock.RUnlock()
 
+	var err error
 	mt.tree.AscendGreaterOrEqual(&item{key: key}, func(i btree.Item) bool {
-		cont, err := handleFn(i.(*item).key, i.(*item).pos)
-		if err != nil {
-			return false
-		}
-		return cont
+		var cont bool
+		cont, err = handleFn(i.(*item).key, i.(*item).pos)
+		return err == nil && cont
 	})
+	return err
 }
 
-func (mt *MemoryBTree) DescendLessOrEqual(key []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) {
+func (mt *MemoryBTree) DescendLessOrEqual(key []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error {
 	mt.lock.RLock()
 	defer mt.lock.RUnlock()
 
+	var err error
 	mt.tree.DescendLessOrEqual(&item{key: key}, func(i btree.Item) bool {
-		cont, err := handleFn(i.(*item).key, i.(*item).pos)
-		if err != nil {
-			return false
-		}
-		return cont
+		var cont bool
+		cont, err = handleFn(i.(*item).key, i.(*item).pos)
+		return err == nil && cont
 	})
+	return err
+}
+
+// Range walks the keys in ascending order, any order is allowed by the Indexer.
+func (mt *MemoryBTree) Range(handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error {
+	return mt.Ascend(handleFn)
+}
+
+// Snapshot clones the tree lazily, the clone shares nodes with the tree until either is written.
+func (mt *MemoryBTree) Snapshot() (Indexer, error) {
+	mt.lock.Lock()
+	defer mt.lock.Unlock()
+
+	return &MemoryBTree{
+		tree: mt.tree.Clone(),
+		lock: new(sync.RWMutex),
+	}, nil
+}
+
+func (mt *MemoryBTree) Close() error {
+	return nil
 }
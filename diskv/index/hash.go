@@ -0,0 +1,159 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package index
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/sjy-dv/coltt/pkg/diskhash"
+	"github.com/sjy-dv/coltt/pkg/wal"
+)
+
+// KeyReader reads back the key of the record stored at position.
+// The hash table only keeps key hashes, so every hash collision is
+// resolved by reading the record from the data files.
+type KeyReader func(position *wal.ChunkPosition) ([]byte, error)
+
+var positionLength = uint32(len(new(wal.ChunkPosition).EncodeFixedSize()))
+
+// DiskHash keeps the key positions in the on-disk linear hash table of
+// pkg/diskhash, so the index does not need to fit in memory.
+//
+// A hash table has no key order, Ascend*, Descend* and Snapshot return
+// ErrUnordered and Range walks the keys in bucket order. Use the BTree
+// indexer when ordered iteration is needed.
+type DiskHash struct {
+	table   *diskhash.Table
+	readKey KeyReader
+}
+
+func openDiskHash(dirPath string, readKey KeyReader) (*DiskHash, error) {
+	table, err := diskhash.Open(diskhash.Options{
+		DirPath:         dirPath,
+		SlotValueLength: positionLength,
+		LoadFactor:      diskhash.DefaultOptions.LoadFactor,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DiskHash{table: table, readKey: readKey}, nil
+}
+
+// matchKey returns a diskhash.MatchKeyFunc that keeps the position of the
+// slot holding key in matched.
+func (dh *DiskHash) matchKey(key []byte, matched **wal.ChunkPosition) diskhash.MatchKeyFunc {
+	return func(slot diskhash.Slot) (bool, error) {
+		position := wal.DecodeChunkPosition(slot.Value)
+		slotKey, err := dh.readKey(position)
+		if err != nil {
+			return false, err
+		}
+		if !bytes.Equal(slotKey, key) {
+			return false, nil
+		}
+		*matched = position
+		return true, nil
+	}
+}
+
+func (dh *DiskHash) Put(key []byte, position *wal.ChunkPosition) (*wal.ChunkPosition, error) {
+	var oldPosition *wal.ChunkPosition
+	if err := dh.table.Put(key, position.EncodeFixedSize(), dh.matchKey(key, &oldPosition)); err != nil {
+		return nil, fmt.Errorf("diskhash index: put failed: %w", err)
+	}
+	return oldPosition, nil
+}
+
+func (dh *DiskHash) Get(key []byte) (*wal.ChunkPosition, error) {
+	var position *wal.ChunkPosition
+	if err := dh.table.Get(key, dh.matchKey(key, &position)); err != nil {
+		return nil, fmt.Errorf("diskhash index: get failed: %w", err)
+	}
+	return position, nil
+}
+
+func (dh *DiskHash) Delete(key []byte) (*wal.ChunkPosition, bool, error) {
+	var position *wal.ChunkPosition
+	if err := dh.table.Delete(key, dh.matchKey(key, &position)); err != nil {
+		return nil, false, fmt.Errorf("diskhash index: delete failed: %w", err)
+	}
+	return position, position != nil, nil
+}
+
+func (dh *DiskHash) Size() int {
+	return int(dh.table.Size())
+}
+
+// Range calls handleFn for every key of the table in bucket order.
+func (dh *DiskHash) Range(handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error {
+	var fnErr error
+	err := dh.table.Range(func(slot diskhash.Slot) (bool, error) {
+		position := wal.DecodeChunkPosition(slot.Value)
+		key, err := dh.readKey(position)
+		if err != nil {
+			return false, err
+		}
+		var cont bool
+		cont, fnErr = handleFn(key, position)
+		return fnErr == nil && cont, nil
+	})
+	if err != nil {
+		return fmt.Errorf("diskhash index: scan failed: %w", err)
+	}
+	return fnErr
+}
+
+func (dh *DiskHash) Ascend(handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error {
+	return ErrUnordered
+}
+
+func (dh *DiskHash) AscendRange(startKey, endKey []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error {
+	return ErrUnordered
+}
+
+func (dh *DiskHash) AscendGreaterOrEqual(key []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error {
+	return ErrUnordered
+}
+
+func (dh *DiskHash) Descend(handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error {
+	return ErrUnordered
+}
+
+func (dh *DiskHash) DescendRange(startKey, endKey []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error {
+	return ErrUnordered
+}
+
+func (dh *DiskHash) DescendLessOrEqual(key []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error {
+	return ErrUnordered
+}
+
+// Snapshot is not supported, a copy of the table would have to be taken in memory.
+func (dh *DiskHash) Snapshot() (Indexer, error) {
+	return nil, ErrUnordered
+}
+
+func (dh *DiskHash) Close() error {
+	return dh.table.Close()
+}
+
+// RemoveDiskHash drops the table files so the index can be rebuilt from scratch
+func RemoveDiskHash(dirPath string) error {
+	return os.RemoveAll(dirPath)
+}
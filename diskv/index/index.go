@@ -17,44 +17,73 @@
 
 package index
 
-import "github.com/sjy-dv/coltt/pkg/wal"
+import (
+	"errors"
+	"fmt"
 
+	"github.com/sjy-dv/coltt/pkg/wal"
+)
+
+// ErrUnordered is returned by the ordered scans and Snapshot of an index without key order.
+var ErrUnordered = errors.New("index: ordered scans are not supported by the hash index")
+
+// Indexer maps the keys to the positions of their records in the data files.
+// The errors are the ones of the index storage, the scans also stop at the
+// first error of handleFn and return it.
 type Indexer interface {
-	Put(key []byte, position *wal.ChunkPosition) *wal.ChunkPosition
+	Put(key []byte, position *wal.ChunkPosition) (*wal.ChunkPosition, error)
 
-	Get(key []byte) *wal.ChunkPosition
+	Get(key []byte) (*wal.ChunkPosition, error)
 
-	Delete(key []byte) (*wal.ChunkPosition, bool)
+	Delete(key []byte) (*wal.ChunkPosition, bool, error)
 
 	Size() int
 
-	Ascend(handleFn func(key []byte, position *wal.ChunkPosition) (bool, error))
+	// Range calls handleFn for every key in no particular order.
+	Range(handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error
+
+	Ascend(handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error
 
-	AscendRange(startKey, endKey []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error))
+	AscendRange(startKey, endKey []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error
 
-	AscendGreaterOrEqual(key []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error))
+	AscendGreaterOrEqual(key []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error
 
-	Descend(handleFn func(key []byte, pos *wal.ChunkPosition) (bool, error))
+	Descend(handleFn func(key []byte, pos *wal.ChunkPosition) (bool, error)) error
 
-	DescendRange(startKey, endKey []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error))
+	DescendRange(startKey, endKey []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error
 
-	DescendLessOrEqual(key []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error))
+	DescendLessOrEqual(key []byte, handleFn func(key []byte, position *wal.ChunkPosition) (bool, error)) error
+
+	// Snapshot returns a read-only copy of the index, later writes to the index do not show in it.
+	Snapshot() (Indexer, error)
+
+	Close() error
 }
 
 type IndexerType = byte
 
 const (
+	// BTree keeps every key and position in memory, rebuilt at every open.
 	BTree IndexerType = iota
+	// Hash keeps the positions in an on-disk hash table, see DiskHash.
+	Hash
 )
 
-// Change the index type as you implement.
-var indexType = BTree
+type Options struct {
+	Type IndexerType
+	// DirPath is the directory of the Hash table files.
+	DirPath string
+	// KeyReader resolves key hash collisions of the Hash indexer.
+	KeyReader KeyReader
+}
 
-func NewIndexer() Indexer {
-	switch indexType {
+func NewIndexer(options Options) (Indexer, error) {
+	switch options.Type {
 	case BTree:
-		return newBTree()
+		return newBTree(), nil
+	case Hash:
+		return openDiskHash(options.DirPath, options.KeyReader)
 	default:
-		panic("unexpected index type")
+		return nil, fmt.Errorf("unexpected index type %d", options.Type)
 	}
 }
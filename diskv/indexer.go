@@ -0,0 +1,104 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskv
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+
+	"github.com/sjy-dv/coltt/diskv/index"
+	"github.com/sjy-dv/coltt/pkg/wal"
+)
+
+const (
+	hashIndexDirName        = "INDEX"
+	hashIndexCheckpointName = "CHECKPOINT"
+)
+
+// openIndex opens the indexer selected by Options.IndexType.
+//
+// The Hash indexer survives a restart. Close writes the active segment id
+// as a checkpoint, and the next open only replays the segments from the
+// checkpoint on. Without a checkpoint (crash, pending merge) the table is
+// dropped and rebuilt from the hint and data files, like the BTree.
+func (db *DB) openIndex(reset bool) (index.Indexer, error) {
+	db.indexCheckpoint = 0
+	if db.options.IndexType != index.Hash {
+		return index.NewIndexer(index.Options{Type: db.options.IndexType})
+	}
+	dirPath := filepath.Join(db.options.DirPath, hashIndexDirName)
+	checkpoint, err := takeIndexCheckpoint(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	if reset || checkpoint == 0 {
+		if err := index.RemoveDiskHash(dirPath); err != nil {
+			return nil, err
+		}
+	} else {
+		db.indexCheckpoint = checkpoint
+	}
+	return index.NewIndexer(index.Options{
+		Type:      index.Hash,
+		DirPath:   dirPath,
+		KeyReader: db.readKey,
+	})
+}
+
+// closeIndex closes the indexer, a Hash indexer records the active segment
+// id so the next open can skip the older segments.
+func (db *DB) closeIndex() error {
+	if err := db.index.Close(); err != nil {
+		return err
+	}
+	if db.options.IndexType != index.Hash {
+		return nil
+	}
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, db.dataFiles.ActiveSegmentID())
+	return os.WriteFile(filepath.Join(db.options.DirPath, hashIndexDirName, hashIndexCheckpointName), buf, 0644)
+}
+
+// takeIndexCheckpoint reads and removes the checkpoint, so a crash before
+// the next close forces a rebuild. 0 means there is no checkpoint.
+func takeIndexCheckpoint(dirPath string) (wal.SegmentID, error) {
+	path := filepath.Join(dirPath, hashIndexCheckpointName)
+	buf, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := os.Remove(path); err != nil {
+		return 0, err
+	}
+	if len(buf) != 4 {
+		return 0, nil
+	}
+	return binary.LittleEndian.Uint32(buf), nil
+}
+
+func (db *DB) readKey(position *wal.ChunkPosition) ([]byte, error) {
+	chunk, err := db.dataFiles.Read(position)
+	if err != nil {
+		return nil, err
+	}
+	return decodeLogRecord(chunk).Key, nil
+}
@@ -0,0 +1,209 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskv
+
+import (
+	"bytes"
+
+	"github.com/sjy-dv/coltt/diskv/index"
+	"github.com/sjy-dv/coltt/pkg/wal"
+)
+
+// IteratorOptions specifies the options of an Iterator.
+type IteratorOptions struct {
+	// Prefix bounds the iterator to the keys starting with it.
+	Prefix []byte
+}
+
+// Iterator is a cursor over the keys of a Snapshot in ascending order.
+// Every move looks the next key up in the snapshot index, so an iterator
+// holds no lock between calls.
+//
+//	for iter.Rewind(); iter.Valid(); iter.Next() {
+//		iter.Key(), iter.Value()
+//	}
+//	err := iter.Err()
+type Iterator struct {
+	snapshot *Snapshot
+	options  IteratorOptions
+	owned    bool // the iterator took the snapshot and releases it on Close
+	key      []byte
+	value    []byte
+	valid    bool
+	err      error
+}
+
+// NewIterator returns an iterator over a new snapshot of the db,
+// Close releases the snapshot.
+func (db *DB) NewIterator(options IteratorOptions) (*Iterator, error) {
+	snapshot, err := db.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	iter := snapshot.NewIterator(options)
+	iter.owned = true
+	return iter, nil
+}
+
+// NewIterator returns an iterator over the snapshot.
+// The iterator is not positioned, call Rewind, Last or Seek first.
+func (s *Snapshot) NewIterator(options IteratorOptions) *Iterator {
+	return &Iterator{snapshot: s, options: options}
+}
+
+// Rewind moves to the first key.
+func (it *Iterator) Rewind() {
+	it.Seek(it.options.Prefix)
+}
+
+// Last moves to the last key.
+func (it *Iterator) Last() {
+	if it.stopped() {
+		return
+	}
+	upper := prefixUpperBound(it.options.Prefix)
+	if upper == nil {
+		it.move(func(index index.Indexer, fn func([]byte, *wal.ChunkPosition) (bool, error)) error {
+			return index.Descend(fn)
+		})
+		return
+	}
+	it.move(func(index index.Indexer, fn func([]byte, *wal.ChunkPosition) (bool, error)) error {
+		return index.DescendLessOrEqual(upper, func(key []byte, pos *wal.ChunkPosition) (bool, error) {
+			if bytes.Equal(key, upper) {
+				return true, nil
+			}
+			return fn(key, pos)
+		})
+	})
+}
+
+// Seek moves to the first key greater than or equal to key.
+func (it *Iterator) Seek(key []byte) {
+	if it.stopped() {
+		return
+	}
+	if bytes.Compare(key, it.options.Prefix) < 0 {
+		key = it.options.Prefix
+	}
+	it.move(func(index index.Indexer, fn func([]byte, *wal.ChunkPosition) (bool, error)) error {
+		return index.AscendGreaterOrEqual(key, fn)
+	})
+}
+
+// Next moves to the next key.
+func (it *Iterator) Next() {
+	if !it.valid || it.stopped() {
+		return
+	}
+	// the smallest key greater than the current one
+	next := append(append(make([]byte, 0, len(it.key)+1), it.key...), 0)
+	it.move(func(index index.Indexer, fn func([]byte, *wal.ChunkPosition) (bool, error)) error {
+		return index.AscendGreaterOrEqual(next, fn)
+	})
+}
+
+// Prev moves to the previous key.
+func (it *Iterator) Prev() {
+	if !it.valid || it.stopped() {
+		return
+	}
+	current := it.key
+	it.move(func(index index.Indexer, fn func([]byte, *wal.ChunkPosition) (bool, error)) error {
+		return index.DescendLessOrEqual(current, func(key []byte, pos *wal.ChunkPosition) (bool, error) {
+			if bytes.Equal(key, current) {
+				return true, nil
+			}
+			return fn(key, pos)
+		})
+	})
+}
+
+// Valid reports whether the iterator is positioned at a key.
+func (it *Iterator) Valid() bool {
+	return it.valid
+}
+
+// Key returns the current key.
+func (it *Iterator) Key() []byte {
+	return it.key
+}
+
+// Value returns the value of the current key.
+func (it *Iterator) Value() []byte {
+	return it.value
+}
+
+// Err returns the error that stopped the iterator.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Close closes the iterator, and releases the snapshot taken by DB.NewIterator.
+func (it *Iterator) Close() {
+	it.valid, it.key, it.value = false, nil, nil
+	if it.owned {
+		it.snapshot.Release()
+	}
+}
+
+func (it *Iterator) stopped() bool {
+	if it.err != nil {
+		it.valid = false
+		return true
+	}
+	return false
+}
+
+// move positions the iterator at the first live key walk yields inside the prefix.
+// walk yields the keys in the direction of the move, so the first key outside the prefix ends it.
+func (it *Iterator) move(walk func(index index.Indexer, fn func([]byte, *wal.ChunkPosition) (bool, error)) error) {
+	it.valid, it.key, it.value = false, nil, nil
+	err := it.snapshot.view(func(index index.Indexer) error {
+		return walk(index, func(key []byte, pos *wal.ChunkPosition) (bool, error) {
+			if !bytes.HasPrefix(key, it.options.Prefix) {
+				return false, nil
+			}
+			value, err := it.snapshot.read(pos)
+			if err != nil {
+				return false, err
+			}
+			if value == nil {
+				return true, nil
+			}
+			it.key, it.value, it.valid = key, value, true
+			return false, nil
+		})
+	})
+	if err != nil {
+		it.err, it.valid = err, false
+	}
+}
+
+// prefixUpperBound returns the smallest key greater than every key with
+// the prefix, nil when there is none.
+func prefixUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			upper := append([]byte(nil), prefix[:i+1]...)
+			upper[i]++
+			return upper
+		}
+	}
+	return nil
+}
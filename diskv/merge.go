@@ -48,8 +48,15 @@ func (db *DB) Merge(reopenAfterDone bool) error {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
-	// close current files
-	_ = db.closeFiles()
+	// close current files. The open snapshots keep reading the generation
+	// they pinned, it stays open until the last of them is released, so the
+	// segments removed below are freed only then.
+	if db.pins[db.dataFiles] == 0 {
+		_ = db.dataFiles.Close()
+	}
+	if db.hintFile != nil {
+		_ = db.hintFile.Close()
+	}
 
 	// replace original file
 	err := loadMergeFiles(db.options.DirPath)
@@ -63,7 +70,12 @@ func (db *DB) Merge(reopenAfterDone bool) error {
 	}
 
 	// discard the old index first.
-	db.index = index.NewIndexer()
+	if err = db.index.Close(); err != nil {
+		return err
+	}
+	if db.index, err = db.openIndex(true); err != nil {
+		return err
+	}
 	// rebuild index
 	if err = db.loadIndex(); err != nil {
 		return err
@@ -102,11 +114,17 @@ func (db *DB) doMerge() error {
 		return err
 	}
 
+	written := db.publisher.pending()
+
 	// we can unlock the mutex here, because the write-ahead log files has been rotated,
 	// and the new active segment file will be used for the subsequent writes.
 	// Our Merge operation will only read from the older segment files.
 	db.mu.Unlock()
 
+	// the batches written to the older segments are merged by their index
+	// positions, so wait for those still waiting for their sync.
+	db.publisher.wait(written)
+
 	// open a merge db to write the data to the new data file.
 	// delete the merge directory if it exists and create a new one.
 	mergeDB, err := db.openMergeDB()
@@ -133,12 +151,17 @@ func (db *DB) doMerge() error {
 			return err
 		}
 		record := decodeLogRecord(chunk)
-		// Only handle the normal log record, LogRecordDeleted and LogRecordBatchFinished
-		// will be ignored, because they are not valid data.
+		// Only handle the normal log record, LogRecordDeleted, LogRecordRangeDeleted and
+		// LogRecordBatchFinished will be ignored, because they are not valid data.
+		// The records covered by a range tombstone are no longer in the index,
+		// so they are discarded along with the tombstone itself.
 		if record.Type == LogRecordNormal && (record.Expire == 0 || record.Expire > now) {
 			db.mu.RLock()
-			indexPos := db.index.Get(record.Key)
+			indexPos, err := db.index.Get(record.Key)
 			db.mu.RUnlock()
+			if err != nil {
+				return err
+			}
 			if indexPos != nil && positionEquals(indexPos, position) {
 				// clear the batch id of the record,
 				// all data after merge will be valid data, so the batch id should be 0.
@@ -189,8 +212,10 @@ func (db *DB) openMergeDB() (*DB, error) {
 	options := db.options
 	// we don't need to use the original sync policy,
 	// because we can sync the data file manually after the merge operation is completed.
-	options.Sync, options.BytesPerSync = false, 0
+	options.Sync, options.BytesPerSync, options.Durability = false, 0, wal.DurabilityNone
 	options.DirPath = mergePath
+	// the merge db is never read, its index only needs to be cheap.
+	options.IndexType = index.BTree
 	mergeDB, err := Open(options)
 	if err != nil {
 		return nil, err
@@ -356,7 +381,9 @@ func (db *DB) loadIndexFromHintFile() error {
 		key, position := decodeHintRecord(chunk)
 		// All the hint records are valid because it is generated by the merge operation.
 		// So just put them into the index without checking.
-		db.index.Put(key, position)
+		if _, err := db.index.Put(key, position); err != nil {
+			return err
+		}
 	}
 	hintFile.SetIsStartupTraversal(false)
 	return nil
@@ -23,6 +23,9 @@ import (
 	"path/filepath"
 	"strconv"
 	"time"
+
+	"github.com/sjy-dv/coltt/diskv/index"
+	"github.com/sjy-dv/coltt/pkg/wal"
 )
 
 // Options specifies the options for opening a database.
@@ -46,6 +49,11 @@ type Options struct {
 	// BytesPerSync specifies the number of bytes to write before calling fsync.
 	BytesPerSync uint32
 
+	// Durability is the default durability of the writes, see wal.Durability.
+	// wal.DurabilityDefault follows Sync and BytesPerSync. Concurrent writes
+	// waiting for an fsync share one, the fsyncs are reported by Stat.
+	Durability wal.Durability
+
 	// WatchQueueSize the cache length of the watch queue.
 	// if the size greater than 0, which means enable the watch.
 	WatchQueueSize uint64
@@ -60,12 +68,33 @@ type Options struct {
 	// do not set this shecule too frequently, it will affect the performance.
 	// refer to https://en.wikipedia.org/wiki/Cron
 	AutoMergeCronExpr string
+
+	// IndexType selects the key index.
+	// index.BTree keeps every key in memory and rebuilds it from the hint and data files at open.
+	// index.Hash keeps the index in an on-disk hash table under DirPath/INDEX, so the keys do not
+	// need to fit in memory and a cleanly closed db reopens without replaying the older segments.
+	// A Hash index has no key order, Ascend*, Descend*, Snapshot and NewIterator return
+	// index.ErrUnordered, and keys that expired while the db was closed stay indexed until
+	// DeleteExpiredKeys runs.
+	IndexType index.IndexerType
+
+	// Compression compresses the records written to the data files, e.g. wal.FlateCompression.
+	// The codec is recorded per record, so the data files written before a change stay readable,
+	// and merge rewrites the live records with the current compression.
+	Compression wal.Compression
 }
 
 // BatchOptions specifies the options for creating a batch.
 type BatchOptions struct {
 	// Sync has the same semantics as Options.Sync.
 	Sync bool
+	// Durability overrides Sync and Options.Durability for the batch when it is set.
+	// The batches become readable in commit order, so the Commit of a batch that
+	// needs no sync still waits for the fsync of a batch committed before it.
+	// When the fsync fails Commit returns the error and the records are not made
+	// readable, but they stay in the data files and the next Open replays them:
+	// the outcome of a batch whose fsync failed is unknown until the db is reopened.
+	Durability wal.Durability
 	// ReadOnly specifies whether the batch is read only.
 	ReadOnly bool
 }
@@ -82,8 +111,11 @@ var DefaultOptions = Options{
 	SegmentSize:       1 * GB,
 	Sync:              false,
 	BytesPerSync:      0,
+	Durability:        wal.DurabilityDefault,
 	WatchQueueSize:    0,
 	AutoMergeCronExpr: "",
+	IndexType:         index.BTree,
+	Compression:       wal.NoCompression,
 }
 
 var DefaultBatchOptions = BatchOptions{
@@ -0,0 +1,115 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskv
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/sjy-dv/coltt/diskv/index"
+	"github.com/sjy-dv/coltt/pkg/wal"
+)
+
+// DeleteRange deletes every key in [start, end), a nil end deletes to the last key.
+// A single range tombstone is written instead of a record per key,
+// the covered records are reclaimed by the next Merge.
+func (db *DB) DeleteRange(start, end []byte) error {
+	if len(end) > 0 && bytes.Compare(start, end) >= 0 {
+		return nil
+	}
+	batch := db.batchPool.Get().(*Batch)
+	defer func() {
+		batch.reset()
+		db.batchPool.Put(batch)
+	}()
+	batch.init(false, false, db)
+	if err := batch.deleteRange(start, end); err != nil {
+		_ = batch.Rollback()
+		return err
+	}
+	return batch.Commit()
+}
+
+// DeletePrefix deletes every key starting with prefix.
+func (db *DB) DeletePrefix(prefix []byte) error {
+	return db.DeleteRange(prefix, prefixUpperBound(prefix))
+}
+
+// deleteRange adds a range tombstone to the batch.
+// It is not visible to the reads of the batch until it is committed.
+func (b *Batch) deleteRange(start, end []byte) error {
+	if b.db.closed {
+		return ErrDBClosed
+	}
+	if b.options.ReadOnly {
+		return ErrReadOnlyBatch
+	}
+
+	b.mu.Lock()
+	record := b.db.recordPool.Get().(*LogRecord)
+	record.Key, record.Value = start, end
+	record.Type, record.Expire = LogRecordRangeDeleted, 0
+	b.pendingWrites = append(b.pendingWrites, record)
+	b.mu.Unlock()
+
+	return nil
+}
+
+// commitRangeDelete removes the keys covered by the tombstone from the index.
+func (b *Batch) commitRangeDelete(record *LogRecord) error {
+	keys, err := rangeKeys(b.db.index, record.Key, record.Value)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, _, err := b.db.index.Delete(key); err != nil {
+			return err
+		}
+		if b.db.options.WatchQueueSize > 0 {
+			b.db.watcher.putEvent(&Event{Action: WatchActionDelete, Key: key, BatchId: record.BatchId})
+		}
+	}
+	return nil
+}
+
+// rangeKeys returns the keys of the index in [start, end), an empty end is unbounded.
+// The keys are collected first since the index can't be modified while it is traversed.
+func rangeKeys(idx index.Indexer, start, end []byte) ([][]byte, error) {
+	var keys [][]byte
+	collect := func(key []byte, _ *wal.ChunkPosition) (bool, error) {
+		keys = append(keys, key)
+		return true, nil
+	}
+	var err error
+	if len(end) == 0 {
+		err = idx.AscendGreaterOrEqual(start, collect)
+	} else {
+		err = idx.AscendRange(start, end, collect)
+	}
+	if !errors.Is(err, index.ErrUnordered) {
+		return keys, err
+	}
+	// without key order every key of the index is checked against the range
+	err = idx.Range(func(key []byte, _ *wal.ChunkPosition) (bool, error) {
+		if bytes.Compare(key, start) >= 0 && (len(end) == 0 || bytes.Compare(key, end) < 0) {
+			keys = append(keys, key)
+		}
+		return true, nil
+	})
+	return keys, err
+}
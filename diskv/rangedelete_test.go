@@ -0,0 +1,79 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskv
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteRangeSurvivesMergeAndReopen(t *testing.T) {
+	options := DefaultOptions
+	options.DirPath = t.TempDir()
+	options.SegmentSize = 1 * MB
+	db, err := Open(options)
+	assert.Nil(t, err)
+
+	for i := 0; i < 1000; i++ {
+		assert.Nil(t, db.Put([]byte(fmt.Sprintf("a-%04d", i)), []byte("value")))
+		assert.Nil(t, db.Put([]byte(fmt.Sprintf("b-%04d", i)), []byte("value")))
+	}
+	assert.Nil(t, db.DeleteRange([]byte("a-0100"), []byte("a-0200")))
+	assert.Nil(t, db.DeletePrefix([]byte("b-")))
+	// written after the tombstones, so not covered by them
+	assert.Nil(t, db.Put([]byte("a-0150"), []byte("again")))
+	assert.Nil(t, db.Put([]byte("b-0001"), []byte("again")))
+
+	check := func(db *DB) {
+		assert.Equal(t, 902, db.Stat().KeysNum)
+		for i := 0; i < 1000; i++ {
+			key := []byte(fmt.Sprintf("a-%04d", i))
+			value, err := db.Get(key)
+			switch {
+			case i == 150:
+				assert.Equal(t, []byte("again"), value)
+			case i >= 100 && i < 200:
+				assert.Equal(t, ErrKeyNotFound, err)
+			default:
+				assert.Nil(t, err)
+			}
+		}
+		value, err := db.Get([]byte("b-0001"))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("again"), value)
+		_, err = db.Get([]byte("b-0002"))
+		assert.Equal(t, ErrKeyNotFound, err)
+	}
+	check(db)
+
+	// replayed from the data files
+	assert.Nil(t, db.Close())
+	db, err = Open(options)
+	assert.Nil(t, err)
+	check(db)
+
+	// loaded from the hint file of the merge
+	assert.Nil(t, db.Merge(false))
+	assert.Nil(t, db.Close())
+	db, err = Open(options)
+	assert.Nil(t, err)
+	defer db.Close()
+	check(db)
+}
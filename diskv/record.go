@@ -34,6 +34,9 @@ const (
 	LogRecordDeleted
 	// LogRecordBatchFinished is the batch finished log record type.
 	LogRecordBatchFinished
+	// LogRecordRangeDeleted is the range tombstone log record type,
+	// the key is the inclusive start and the value the exclusive end of the range.
+	LogRecordRangeDeleted
 )
 
 // type batchId keySize valueSize expire
@@ -64,6 +67,7 @@ type IndexRecord struct {
 	key        []byte
 	recordType LogRecordType
 	position   *wal.ChunkPosition
+	end        []byte // exclusive end of a range tombstone
 }
 
 // +-------------+-------------+-------------+--------------+---------------+---------+--------------+
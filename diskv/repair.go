@@ -0,0 +1,117 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskv
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/gofrs/flock"
+	"github.com/sjy-dv/coltt/pkg/wal"
+)
+
+// Verify checks every chunk of the data, hint and merge finished files of
+// the db in dirPath and reports the damaged ones with their positions.
+// The db must be closed, it returns ErrDatabaseIsUsing otherwise.
+func Verify(dirPath string) (*wal.VerifyReport, error) {
+	unlock, err := lockClosedDB(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	report := &wal.VerifyReport{}
+	for _, ext := range []string{dataFileNameSuffix, hintFileNameSuffix, mergeFinNameSuffix} {
+		r, err := wal.Verify(wal.Options{DirPath: dirPath, SegmentFileExt: ext})
+		if err != nil {
+			return nil, err
+		}
+		report.Segments = append(report.Segments, r.Segments...)
+	}
+	return report, nil
+}
+
+// Repair repairs the db in dirPath so it opens again, see wal.Repair.
+// A torn tail of the active data file is truncated. A damaged older data
+// file is quarantined and its records are lost, a key whose latest record
+// was in it reads its previous value again. The hint and merge finished
+// files are quarantined when they or a data file are damaged, the index is
+// then rebuilt from the data files at the next open.
+// The db must be closed, it returns ErrDatabaseIsUsing otherwise.
+func Repair(dirPath string) (*wal.VerifyReport, error) {
+	unlock, err := lockClosedDB(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	report, err := wal.Repair(wal.Options{DirPath: dirPath, SegmentFileExt: dataFileNameSuffix})
+	if err != nil {
+		return report, err
+	}
+	var truncated, quarantined bool
+	for _, seg := range report.Segments {
+		truncated = truncated || seg.Truncated
+		quarantined = quarantined || seg.Quarantined
+	}
+
+	var merged []*wal.SegmentReport
+	for _, ext := range []string{hintFileNameSuffix, mergeFinNameSuffix} {
+		r, err := wal.Verify(wal.Options{DirPath: dirPath, SegmentFileExt: ext})
+		if err != nil {
+			return report, err
+		}
+		quarantined = quarantined || r.Corrupted()
+		merged = append(merged, r.Segments...)
+	}
+	if quarantined {
+		for _, seg := range merged {
+			if err := wal.Quarantine(seg.Path); err != nil {
+				return report, err
+			}
+			seg.Quarantined = true
+		}
+	}
+	report.Segments = append(report.Segments, merged...)
+
+	// a persisted Hash index may point into the repaired files
+	if truncated || quarantined {
+		err := os.Remove(filepath.Join(dirPath, hashIndexDirName, hashIndexCheckpointName))
+		if err != nil && !os.IsNotExist(err) {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+func lockClosedDB(dirPath string) (func(), error) {
+	if _, err := os.Stat(dirPath); err != nil {
+		return nil, err
+	}
+	fileLock := flock.New(filepath.Join(dirPath, fileLockName))
+	hold, err := fileLock.TryLock()
+	if err != nil {
+		return nil, err
+	}
+	if !hold {
+		return nil, ErrDatabaseIsUsing
+	}
+	return func() {
+		_ = fileLock.Unlock()
+	}, nil
+}
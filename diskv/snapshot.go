@@ -0,0 +1,197 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskv
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sjy-dv/coltt/diskv/index"
+	"github.com/sjy-dv/coltt/pkg/wal"
+)
+
+// Snapshot is a read-only view of the db pinned to the WAL position it was
+// taken at. Writes after the snapshot do not show in it, and reading it
+// does not hold the db lock, so writers are never blocked by a scan.
+//
+// The view reads the records from the generation of the data files it was
+// taken on. A merge replacing them leaves a pinned generation open, the
+// last snapshot released on it closes it and frees the replaced segments.
+// A Hash index can't be snapshotted, index.ErrUnordered is returned.
+type Snapshot struct {
+	db       *DB
+	files    *wal.WAL // the pinned data files
+	position *wal.ChunkPosition
+	now      int64 // ttl of the records is judged at the snapshot time
+
+	mu       sync.RWMutex // held by the reads, Release waits for them
+	index    index.Indexer
+	released bool
+}
+
+// Snapshot takes a read snapshot of the db, it must be released with Release.
+func (db *DB) Snapshot() (*Snapshot, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.closed {
+		return nil, ErrDBClosed
+	}
+	idx, err := db.index.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	db.pins[db.dataFiles]++
+	return &Snapshot{
+		db:       db,
+		files:    db.dataFiles,
+		index:    idx,
+		position: db.dataFiles.EndPosition(),
+		now:      time.Now().UnixNano(),
+	}, nil
+}
+
+// Position returns the WAL position the snapshot is pinned to,
+// every record written at or after it is not visible.
+func (s *Snapshot) Position() *wal.ChunkPosition {
+	return s.position
+}
+
+// Release releases the snapshot, it cannot be read afterwards.
+// It must not be called from the handleFn of a scan of the snapshot.
+func (s *Snapshot) Release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.released {
+		return
+	}
+	s.released = true
+	s.index = nil
+	s.db.unpinFiles(s.files)
+}
+
+// unpinFiles drops a snapshot pin of the data files, the last pin of a
+// generation replaced by a merge closes it.
+func (db *DB) unpinFiles(files *wal.WAL) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	// Close closed every generation
+	if db.closed {
+		return
+	}
+	if db.pins[files]--; db.pins[files] > 0 {
+		return
+	}
+	delete(db.pins, files)
+	if files != db.dataFiles {
+		_ = files.Close()
+	}
+}
+
+// view calls fn with the snapshot index, holding off Release until it returns.
+func (s *Snapshot) view(fn func(index index.Indexer) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.released {
+		return ErrSnapshotReleased
+	}
+	return fn(s.index)
+}
+
+// Get returns the value of the key in the snapshot.
+func (s *Snapshot) Get(key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, ErrKeyIsEmpty
+	}
+	var value []byte
+	err := s.view(func(index index.Indexer) error {
+		position, err := index.Get(key)
+		if err != nil {
+			return err
+		}
+		if position == nil {
+			return ErrKeyNotFound
+		}
+		if value, err = s.read(position); err != nil {
+			return err
+		}
+		if value == nil {
+			return ErrKeyNotFound
+		}
+		return nil
+	})
+	return value, err
+}
+
+// Exist checks if the key exists in the snapshot.
+func (s *Snapshot) Exist(key []byte) (bool, error) {
+	_, err := s.Get(key)
+	if err == ErrKeyNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Ascend calls handleFn for each key/value pair in the snapshot in ascending order.
+func (s *Snapshot) Ascend(handleFn func(k []byte, v []byte) (bool, error)) error {
+	return s.view(func(index index.Indexer) error {
+		return s.scan(index.Ascend, handleFn)
+	})
+}
+
+// AscendRange calls handleFn for each key/value pair in the snapshot within [startKey, endKey) in ascending order.
+func (s *Snapshot) AscendRange(startKey, endKey []byte, handleFn func(k []byte, v []byte) (bool, error)) error {
+	return s.view(func(index index.Indexer) error {
+		return s.scan(func(fn func([]byte, *wal.ChunkPosition) (bool, error)) error {
+			return index.AscendRange(startKey, endKey, fn)
+		}, handleFn)
+	})
+}
+
+// scan calls handleFn for the live records walk yields.
+func (s *Snapshot) scan(walk func(fn func([]byte, *wal.ChunkPosition) (bool, error)) error, handleFn func(k []byte, v []byte) (bool, error)) error {
+	return walk(func(key []byte, pos *wal.ChunkPosition) (bool, error) {
+		value, err := s.read(pos)
+		if err != nil {
+			return false, err
+		}
+		if value == nil {
+			return true, nil
+		}
+		return handleFn(key, value)
+	})
+}
+
+// read returns the value at position, nil when the record has expired at the snapshot time.
+func (s *Snapshot) read(position *wal.ChunkPosition) ([]byte, error) {
+	s.db.mu.RLock()
+	closed := s.db.closed
+	s.db.mu.RUnlock()
+	if closed {
+		return nil, ErrDBClosed
+	}
+	chunk, err := s.files.Read(position)
+	if err != nil {
+		return nil, err
+	}
+	record := decodeLogRecord(chunk)
+	if record.Type == LogRecordDeleted || record.IsExpired(s.now) {
+		return nil, nil
+	}
+	return record.Value, nil
+}
@@ -0,0 +1,112 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskv
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotDoesNotBlockMerge(t *testing.T) {
+	db := openTestDB(t)
+	for i := 0; i < 1000; i++ {
+		assert.Nil(t, db.Put([]byte(fmt.Sprintf("key-%04d", i)), []byte("old")))
+	}
+	iter, err := db.NewIterator(IteratorOptions{Prefix: []byte("key-")})
+	assert.Nil(t, err)
+	defer iter.Close()
+	iter.Rewind()
+	assert.True(t, iter.Valid())
+
+	for i := 0; i < 1000; i++ {
+		key := []byte(fmt.Sprintf("key-%04d", i))
+		if i%2 == 0 {
+			assert.Nil(t, db.Delete(key))
+		} else {
+			assert.Nil(t, db.Put(key, []byte("new")))
+		}
+	}
+
+	// the merge replaces the data files the iterator reads without waiting for it
+	merged := make(chan error, 1)
+	go func() { merged <- db.Merge(true) }()
+	select {
+	case err := <-merged:
+		assert.Nil(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("merge waits for the open iterator")
+	}
+	// and so do new snapshots
+	snapshot, err := db.Snapshot()
+	assert.Nil(t, err)
+	count := 0
+	assert.Nil(t, snapshot.Ascend(func(k, v []byte) (bool, error) {
+		assert.Equal(t, "new", string(v))
+		count++
+		return true, nil
+	}))
+	assert.Equal(t, 500, count)
+	snapshot.Release()
+
+	// the iterator still reads the generation it pinned
+	count = 0
+	for ; iter.Valid(); iter.Next() {
+		assert.Equal(t, "old", string(iter.Value()))
+		count++
+	}
+	assert.Nil(t, iter.Err())
+	assert.Equal(t, 1000, count)
+
+	iter.Close()
+	assert.Empty(t, db.pins)
+	value, err := db.Get([]byte("key-0001"))
+	assert.Nil(t, err)
+	assert.Equal(t, "new", string(value))
+}
+
+func TestSnapshotReleaseWhileReading(t *testing.T) {
+	db := openTestDB(t)
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, db.Put([]byte(fmt.Sprintf("key-%02d", i)), []byte("value")))
+	}
+	snapshot, err := db.Snapshot()
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_, err := snapshot.Get([]byte(fmt.Sprintf("key-%02d", i)))
+				if err != nil {
+					assert.Equal(t, ErrSnapshotReleased, err)
+					return
+				}
+			}
+		}()
+	}
+	snapshot.Release()
+	wg.Wait()
+	_, err = snapshot.Get([]byte("key-00"))
+	assert.Equal(t, ErrSnapshotReleased, err)
+}
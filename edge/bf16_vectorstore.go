@@ -30,6 +30,7 @@ import (
 	"github.com/sjy-dv/coltt/pkg/compresshelper"
 	"github.com/sjy-dv/coltt/pkg/distance"
 	"github.com/sjy-dv/coltt/pkg/inverted"
+	"github.com/sjy-dv/coltt/pkg/planner"
 	"github.com/sjy-dv/coltt/pkg/sharding"
 )
 
@@ -63,7 +64,7 @@ func newBF16Vectorstore(collectionName string, metadata Metadata) *bf16vecSpace
 	return vecspace
 }
 
-func (vertex *bf16vecSpace) ChangedVertex(updateId string, commitId uint64, data ENode) error {
+func (vertex *bf16vecSpace) ChangedVertex(updateId string, commitId uint64, data ENode) (uint64, error) {
 	if updateId != "" {
 		var primaryIndex string
 		for _, indexer := range vertex.Indexer() {
@@ -75,39 +76,40 @@ func (vertex *bf16vecSpace) ChangedVertex(updateId string, commitId uint64, data
 		finder := inverted.NewFilter(primaryIndex, inverted.OpEqual, updateId)
 		ids, err := vertex.invertedIndex.SearchSingleFilter(finder)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		if len(ids) != 0 {
 			commitId = ids[0]
 		}
 	}
 	if vertex.vertexMetadata.Dimensional() != uint32(data.Vector.Dimensions()) {
-		return fmt.Errorf("Dim Length UnmatchdError: expect dimension: [%d], but got [%d]", vertex.vertexMetadata.Dimensional(), data.Vector.Dimensions())
+		return 0, fmt.Errorf("Dim Length UnmatchdError: expect dimension: [%d], but got [%d]", vertex.vertexMetadata.Dimensional(), data.Vector.Dimensions())
 	}
 	if err := standardAnalyzer(data.Metadata, vertex.Indexer()); err != nil {
-		return err
+		return 0, err
 	}
-	if err := vertex.invertedIndex.Add(commitId, data.Metadata); err != nil {
-		return fmt.Errorf("ErrInvertedIndexAddFailed: %s", err.Error())
+	if err := vertex.invertedIndex.Add(commitId, indexedMetadata(data.Metadata, vertex.Indexer())); err != nil {
+		return 0, fmt.Errorf("ErrInvertedIndexAddFailed: %s", err.Error())
 	}
 	if vertex.distance.Type() == T_COSINE {
 		data.Vector = Normalize(data.Vector)
 	}
 	lower, err := vertex.quantization.Lower(data.Vector)
 	if err != nil {
-		return fmt.Errorf(ErrQuantizedFailed, err)
+		return 0, fmt.Errorf(ErrQuantizedFailed, err)
 	}
 
 	shardIdx := sharding.ShardVertex(commitId, uint64(EDGE_MAP_SHARD_COUNT))
 	vertex.verticesMu[shardIdx].Lock()
 	defer vertex.verticesMu[shardIdx].Unlock()
 	vertex.vertices[shardIdx][commitId] = ENodeBF16{Vector: lower, Metadata: data.Metadata}
-	return nil
+	return commitId, nil
 }
 
-func (vertex *bf16vecSpace) RemoveVertex(dropFilter map[string]interface{}) error {
+// RemoveVertex drops the vertices matching dropFilter and returns their ids and metadata
+func (vertex *bf16vecSpace) RemoveVertex(dropFilter map[string]interface{}) ([]uint64, []map[string]interface{}, error) {
 	if err := dropKeyAnalyzer(dropFilter, vertex.Indexer()); err != nil {
-		return err
+		return nil, nil, err
 	}
 	filters := make([]*inverted.Filter, 0)
 	for index, indexValue := range dropFilter {
@@ -116,19 +118,48 @@ func (vertex *bf16vecSpace) RemoveVertex(dropFilter map[string]interface{}) erro
 	}
 	dropIds, err := vertex.invertedIndex.SearchMultiFilter(filters)
 	if err != nil {
-		return fmt.Errorf("InvertedIndexFindDeleteIdsError: %s", err.Error())
+		return nil, nil, fmt.Errorf("InvertedIndexFindDeleteIdsError: %s", err.Error())
 	}
+	removedIds := make([]uint64, 0, len(dropIds))
+	removed := make([]map[string]interface{}, 0, len(dropIds))
 	for _, id := range dropIds {
 		shardIdx := sharding.ShardVertex(id, uint64(EDGE_MAP_SHARD_COUNT))
 		vertex.verticesMu[shardIdx].Lock()
-		vertex.invertedIndex.Remove(id, vertex.vertices[shardIdx][id].Metadata)
+		if node, ok := vertex.vertices[shardIdx][id]; ok {
+			removedIds = append(removedIds, id)
+			removed = append(removed, node.Metadata)
+		}
+		vertex.invertedIndex.Remove(id, indexedMetadata(vertex.vertices[shardIdx][id].Metadata, vertex.Indexer()))
 		delete(vertex.vertices[shardIdx], id)
 		vertex.verticesMu[shardIdx].Unlock()
 	}
-	return nil
+	return removedIds, removed, nil
+}
+
+// RemoveVertexIds drops the vertices and their inverted index entries and returns
+// the metadata of the dropped ones, unknown ids are skipped
+func (vertex *bf16vecSpace) RemoveVertexIds(ids []uint64) ([]map[string]interface{}, error) {
+	removed := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		shardIdx := sharding.ShardVertex(id, uint64(EDGE_MAP_SHARD_COUNT))
+		vertex.verticesMu[shardIdx].Lock()
+		node, ok := vertex.vertices[shardIdx][id]
+		if ok {
+			delete(vertex.vertices[shardIdx], id)
+		}
+		vertex.verticesMu[shardIdx].Unlock()
+		if !ok {
+			continue
+		}
+		if err := vertex.invertedIndex.Remove(id, indexedMetadata(node.Metadata, vertex.Indexer())); err != nil {
+			return removed, err
+		}
+		removed = append(removed, node.Metadata)
+	}
+	return removed, nil
 }
 
-func (vertex *bf16vecSpace) VertexSearch(target Vector, topK int, highCpu bool,
+func (vertex *bf16vecSpace) VertexSearch(target Vector, topK int, highCpu bool, allow func(id uint64) bool,
 ) ([]*SearchResultItem, error) {
 	if vertex.distance.Type() == T_COSINE {
 		target = Normalize(target)
@@ -137,12 +168,20 @@ func (vertex *bf16vecSpace) VertexSearch(target Vector, topK int, highCpu bool,
 	if err != nil {
 		return nil, fmt.Errorf(ErrQuantizedFailed, err)
 	}
+	return vertex.scanVertices(lower, topK, highCpu, allow), nil
+}
+
+// scanVertices scores the vertices allow accepts, every vertex for a nil allow
+func (vertex *bf16vecSpace) scanVertices(target bfloat16Vec, topK int, highCpu bool, allow func(id uint64) bool) []*SearchResultItem {
 	pq := NewPriorityQueue(topK)
 	if !highCpu {
 		for shard := 0; shard < EDGE_MAP_SHARD_COUNT; shard++ {
 			vertex.verticesMu[shard].RLock()
 			for uid, node := range vertex.vertices[shard] {
-				sim := vertex.quantization.Similarity(lower, node.Vector, vertex.distance)
+				if allow != nil && !allow(uid) {
+					continue
+				}
+				sim := vertex.quantization.Similarity(target, node.Vector, vertex.distance)
 				pq.Add(&SearchResultItem{
 					Id:       uid,
 					Score:    sim,
@@ -164,7 +203,10 @@ func (vertex *bf16vecSpace) VertexSearch(target Vector, topK int, highCpu bool,
 				localpq := NewPriorityQueue(topK)
 				vertex.verticesMu[shard].RLock()
 				for uid, node := range vertex.vertices[shard] {
-					sim := vertex.quantization.Similarity(lower, node.Vector, vertex.distance)
+					if allow != nil && !allow(uid) {
+						continue
+					}
+					sim := vertex.quantization.Similarity(target, node.Vector, vertex.distance)
 					localpq.Add(&SearchResultItem{
 						Id:       uid,
 						Score:    sim,
@@ -182,24 +224,36 @@ func (vertex *bf16vecSpace) VertexSearch(target Vector, topK int, highCpu bool,
 			}
 		}
 	}
-	return pq.ToSlice(), nil
+	return pq.ToSlice()
 }
 
-func (vertex *bf16vecSpace) FilterableVertexSearch(filter *inverted.FilterExpression, target Vector, topK int, highCpu bool,
-) ([]*SearchResultItem, error) {
+func (vertex *bf16vecSpace) FilterableVertexSearch(filter *inverted.FilterExpression, target Vector, topK int, highCpu bool, allow func(id uint64) bool,
+) ([]*SearchResultItem, planner.Plan, error) {
 	if vertex.distance.Type() == T_COSINE {
 		target = Normalize(target)
 	}
 	lower, err := vertex.quantization.Lower(target)
 	if err != nil {
-		return nil, fmt.Errorf(ErrQuantizedFailed, err)
+		return nil, planner.Plan{}, fmt.Errorf(ErrQuantizedFailed, err)
 	}
-	candidates, err := vertex.invertedIndex.SearchWithExpression(filter)
+	matched, err := vertex.invertedIndex.SearchBitmap(filter)
 	if err != nil {
-		return nil, err
+		return nil, planner.Plan{}, err
+	}
+	plan := planner.Choose(matched.GetCardinality(), vertex.invertedIndex.Len(), false)
+	if plan.Strategy == planner.PostFilter {
+		// most rows match, testing each one against the bitmap is cheaper
+		// than gathering the candidates by shard
+		return vertex.scanVertices(lower, topK, highCpu, func(id uint64) bool {
+			return matched.Contains(id) && (allow == nil || allow(id))
+		}), plan, nil
 	}
+	candidates := matched.ToArray()
 	shardCandidates := make([][]uint64, EDGE_MAP_SHARD_COUNT)
 	for _, cand := range candidates {
+		if allow != nil && !allow(cand) {
+			continue
+		}
 		shardIndex := sharding.ShardVertex(cand, uint64(EDGE_MAP_SHARD_COUNT))
 		shardCandidates[shardIndex] = append(shardCandidates[shardIndex], cand)
 	}
@@ -240,7 +294,7 @@ func (vertex *bf16vecSpace) FilterableVertexSearch(filter *inverted.FilterExpres
 				for _, uid := range shardCandidates[shard] {
 					if node, ok := vertex.vertices[shard][uid]; ok {
 						sim := vertex.quantization.Similarity(lower, node.Vector, vertex.distance)
-						globalPQ.Add(&SearchResultItem{
+						localpq.Add(&SearchResultItem{
 							Id:       uid,
 							Score:    sim,
 							Metadata: node.Metadata,
@@ -258,7 +312,7 @@ func (vertex *bf16vecSpace) FilterableVertexSearch(filter *inverted.FilterExpres
 			}
 		}
 	}
-	return globalPQ.ToSlice(), nil
+	return globalPQ.ToSlice(), plan, nil
 }
 
 func (vertex *bf16vecSpace) SaveVertexMetadata() ([]byte, error) {
@@ -290,6 +344,26 @@ func (vertex *bf16vecSpace) LoadVertexInverted(data []byte) error {
 	return vertex.invertedIndex.DeserializeBinary(data)
 }
 
+// FilterIds returns the ids matching the filter, every id when it is nil
+func (vertex *bf16vecSpace) FilterIds(filter *inverted.FilterExpression) ([]uint64, error) {
+	if filter == nil {
+		return vertex.invertedIndex.Nodes(), nil
+	}
+	return vertex.invertedIndex.SearchWithExpression(filter)
+}
+
+func (vertex *bf16vecSpace) Facets(filter *inverted.FilterExpression, exclude []uint64, requests []inverted.FacetRequest) ([]inverted.FacetResult, error) {
+	return vertex.invertedIndex.Facets(filter, exclude, requests...)
+}
+
+func (vertex *bf16vecSpace) VertexMetadata(id uint64) (map[string]interface{}, bool) {
+	shardIdx := sharding.ShardVertex(id, uint64(EDGE_MAP_SHARD_COUNT))
+	vertex.verticesMu[shardIdx].RLock()
+	defer vertex.verticesMu[shardIdx].RUnlock()
+	node, ok := vertex.vertices[shardIdx][id]
+	return node.Metadata, ok
+}
+
 func (vertex *bf16vecSpace) Quantization() edgepb.Quantization {
 	return vertex.vertexMetadata.Quantizationer()
 }
@@ -361,68 +435,9 @@ func (n *bf16vecSpace) SaveVertex() ([]byte, error) {
 					n.verticesMu[i].RUnlock()
 					return nil, err
 				}
-				switch v := metaVal.(type) {
-				case int64:
-					if err := buf.WriteByte(0); err != nil {
-						n.verticesMu[i].RUnlock()
-						return nil, err
-					}
-					if err := binary.Write(&buf, binary.BigEndian, v); err != nil {
-						n.verticesMu[i].RUnlock()
-						return nil, err
-					}
-				case string:
-					if err := buf.WriteByte(1); err != nil {
-						n.verticesMu[i].RUnlock()
-						return nil, err
-					}
-					strBytes := []byte(v)
-					if len(strBytes) > 65535 {
-						n.verticesMu[i].RUnlock()
-						return nil, fmt.Errorf("metadata string too long: %s", v)
-					}
-					if err := binary.Write(&buf, binary.BigEndian, uint16(len(strBytes))); err != nil {
-						n.verticesMu[i].RUnlock()
-						return nil, err
-					}
-					if _, err := buf.Write(strBytes); err != nil {
-						n.verticesMu[i].RUnlock()
-						return nil, err
-					}
-				case float32:
-					if err := buf.WriteByte(2); err != nil {
-						n.verticesMu[i].RUnlock()
-						return nil, err
-					}
-					if err := binary.Write(&buf, binary.BigEndian, float64(v)); err != nil {
-						n.verticesMu[i].RUnlock()
-						return nil, err
-					}
-				case float64:
-					if err := buf.WriteByte(2); err != nil {
-						n.verticesMu[i].RUnlock()
-						return nil, err
-					}
-					if err := binary.Write(&buf, binary.BigEndian, v); err != nil {
-						n.verticesMu[i].RUnlock()
-						return nil, err
-					}
-				case bool:
-					if err := buf.WriteByte(3); err != nil {
-						n.verticesMu[i].RUnlock()
-						return nil, err
-					}
-					var b byte = 0
-					if v {
-						b = 1
-					}
-					if err := buf.WriteByte(b); err != nil {
-						n.verticesMu[i].RUnlock()
-						return nil, err
-					}
-				default:
+				if err := writeMetadataValue(&buf, metaVal); err != nil {
 					n.verticesMu[i].RUnlock()
-					return nil, fmt.Errorf("unsupported metadata type: %T", v)
+					return nil, err
 				}
 			}
 		}
@@ -483,42 +498,11 @@ func (n *bf16vecSpace) LoadVertex(data []byte) error {
 				}
 				metaKey := string(metaKeyBytes)
 
-				typ, err := buf.ReadByte()
+				metaVal, err := readMetadataValue(buf)
 				if err != nil {
 					return err
 				}
-				switch typ {
-				case 0:
-					var val int64
-					if err := binary.Read(buf, binary.BigEndian, &val); err != nil {
-						return err
-					}
-					node.Metadata[metaKey] = val
-				case 1:
-					var strLen uint16
-					if err := binary.Read(buf, binary.BigEndian, &strLen); err != nil {
-						return err
-					}
-					strBytes := make([]byte, strLen)
-					if _, err := io.ReadFull(buf, strBytes); err != nil {
-						return err
-					}
-					node.Metadata[metaKey] = string(strBytes)
-				case 2:
-					var val float64
-					if err := binary.Read(buf, binary.BigEndian, &val); err != nil {
-						return err
-					}
-					node.Metadata[metaKey] = val
-				case 3:
-					boolByte, err := buf.ReadByte()
-					if err != nil {
-						return err
-					}
-					node.Metadata[metaKey] = boolByte != 0
-				default:
-					return fmt.Errorf("unsupported metadata type tag: %d", typ)
-				}
+				node.Metadata[metaKey] = metaVal
 			}
 			m[key] = node
 		}
@@ -21,6 +21,7 @@ import (
 	"encoding/binary"
 	"io"
 	"math"
+	"time"
 )
 
 var (
@@ -38,6 +39,18 @@ var (
 	diskColList           = "edge_collections"
 )
 
+const expiryReapInterval = time.Minute
+
+// changes retained per followed collection for Subscribe to resume from,
+// kept for changefeedIdle after the last subscriber leaves
+const (
+	changefeedCapacity = 10000
+	changefeedIdle     = 5 * time.Minute
+)
+
+// value counts a facet keeps when the request leaves top_n unset
+const defaultFacetTopN = 10
+
 const (
 	COSINE                   = "cosine"
 	EUCLIDEAN                = "euclidean"
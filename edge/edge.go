@@ -20,9 +20,11 @@ package edge
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/sjy-dv/coltt/gen/protoc/v4/edgepb"
+	"github.com/sjy-dv/coltt/pkg/changefeed"
 	"github.com/sjy-dv/coltt/pkg/minio"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -30,6 +32,8 @@ import (
 type Edge struct {
 	VectorStore *Vectorstore
 	Storage     *minio.MinioAPI
+	Feed        *changefeed.Feed
+	stopReaper  chan struct{}
 }
 
 func NewEdge() (*Edge, error) {
@@ -37,13 +41,18 @@ func NewEdge() (*Edge, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Edge{
+	edge := &Edge{
 		VectorStore: NewVectorstore(),
 		Storage:     minioStorage,
-	}, nil
+		Feed:        changefeed.New(changefeedCapacity, changefeedIdle),
+		stopReaper:  make(chan struct{}),
+	}
+	go edge.expiryReaper()
+	return edge, nil
 }
 
 func (edge *Edge) Close() {
+	close(edge.stopReaper)
 	for col, status := range stateManager.Load.collections {
 		if status {
 			metaBytes, err := edge.VectorStore.SavedMetadata(col)
@@ -70,6 +79,14 @@ func (edge *Edge) Close() {
 			if err != nil {
 				log.Error().Msgf("collection: %s saved vertex inverted data to minio failed: %s", col, err.Error())
 			}
+			err = edge.saveExpiryHelper(col)
+			if err != nil {
+				log.Error().Msgf("collection: %s saved expiry data to minio failed: %s", col, err.Error())
+			}
+			err = edge.saveFullTextHelper(col)
+			if err != nil {
+				log.Error().Msgf("collection: %s saved fulltext data to minio failed: %s", col, err.Error())
+			}
 		}
 	}
 	log.Info().Msg("database shut down successfully")
@@ -125,6 +142,7 @@ func (edge *Edge) CreateCollection(ctx context.Context,
 			Quantization: int32(req.GetQuantization()),
 			IndexType:    indexDesignAnalyze(req.GetIndex()),
 			Versioning:   req.GetVersioning(),
+			DefaultTTL:   req.GetDefaultTtlSeconds(),
 		})
 		if err != nil {
 			c <- failFn(err.Error())
@@ -160,17 +178,28 @@ func (edge *Edge) CreateCollection(ctx context.Context,
 			c <- failFn(err.Error())
 			return
 		}
+		err = edge.saveExpiryHelper(req.GetCollectionName())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		err = edge.saveFullTextHelper(req.GetCollectionName())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
 		newAuthorizationBucketHelper(req.GetCollectionName())
 		c <- reply{
 			Result: &edgepb.CollectionResponse{
 				Status: true,
 				Collection: &edgepb.Collection{
-					CollectionName: req.GetCollectionName(),
-					Index:          req.GetIndex(),
-					Distance:       req.GetDistance(),
-					Quantization:   req.GetQuantization(),
-					Dim:            req.GetDim(),
-					Versioning:     req.GetVersioning(),
+					CollectionName:    req.GetCollectionName(),
+					Index:             req.GetIndex(),
+					Distance:          req.GetDistance(),
+					Quantization:      req.GetQuantization(),
+					Dim:               req.GetDim(),
+					Versioning:        req.GetVersioning(),
+					DefaultTtlSeconds: req.GetDefaultTtlSeconds(),
 				},
 			},
 		}
@@ -224,6 +253,7 @@ func (edge *Edge) DeleteCollection(ctx context.Context, req *edgepb.CollectionNa
 		destroyBucketHelper(req.GetCollectionName())
 
 		edge.VectorStore.DestroySpace(req.GetCollectionName())
+		edge.Feed.Drop(req.GetCollectionName())
 		if err := edge.Storage.RemoveBucket(req.GetCollectionName()); err != nil {
 			c <- failFn(err.Error())
 			return
@@ -273,12 +303,13 @@ func (edge *Edge) GetCollection(ctx context.Context,
 				Result: &edgepb.CollectionDetail{
 					Status: true,
 					Collection: &edgepb.Collection{
-						CollectionName: req.GetCollectionName(),
-						Index:          reverseIndexDesign(edge.VectorStore.Indexer(req.GetCollectionName())),
-						Distance:       edge.VectorStore.Distance(req.GetCollectionName()),
-						Quantization:   edge.VectorStore.Quantization(req.GetCollectionName()),
-						Dim:            edge.VectorStore.Dim(req.GetCollectionName()),
-						Versioning:     edge.VectorStore.Versional(req.GetCollectionName()),
+						CollectionName:    req.GetCollectionName(),
+						Index:             reverseIndexDesign(edge.VectorStore.Indexer(req.GetCollectionName())),
+						Distance:          edge.VectorStore.Distance(req.GetCollectionName()),
+						Quantization:      edge.VectorStore.Quantization(req.GetCollectionName()),
+						Dim:               edge.VectorStore.Dim(req.GetCollectionName()),
+						Versioning:        edge.VectorStore.Versional(req.GetCollectionName()),
+						DefaultTtlSeconds: uint64(edge.VectorStore.DefaultTTL(req.GetCollectionName()).Seconds()),
 					},
 					CollectionSize:   uint32(edge.VectorStore.LoadSize(req.GetCollectionName())),
 					CollectionMemory: uint64(edge.VectorStore.LoadSize(req.GetCollectionName())),
@@ -331,12 +362,13 @@ func (edge *Edge) LoadCollection(ctx context.Context,
 				Result: &edgepb.CollectionDetail{
 					Status: true,
 					Collection: &edgepb.Collection{
-						CollectionName: req.GetCollectionName(),
-						Index:          reverseIndexDesign(edge.VectorStore.Indexer(req.GetCollectionName())),
-						Distance:       edge.VectorStore.Distance(req.GetCollectionName()),
-						Quantization:   edge.VectorStore.Quantization(req.GetCollectionName()),
-						Dim:            edge.VectorStore.Dim(req.GetCollectionName()),
-						Versioning:     edge.VectorStore.Versional(req.GetCollectionName()),
+						CollectionName:    req.GetCollectionName(),
+						Index:             reverseIndexDesign(edge.VectorStore.Indexer(req.GetCollectionName())),
+						Distance:          edge.VectorStore.Distance(req.GetCollectionName()),
+						Quantization:      edge.VectorStore.Quantization(req.GetCollectionName()),
+						Dim:               edge.VectorStore.Dim(req.GetCollectionName()),
+						Versioning:        edge.VectorStore.Versional(req.GetCollectionName()),
+						DefaultTtlSeconds: uint64(edge.VectorStore.DefaultTTL(req.GetCollectionName()).Seconds()),
 					},
 					CollectionSize:   uint32(edge.VectorStore.LoadSize(req.GetCollectionName())),
 					CollectionMemory: uint64(edge.VectorStore.LoadSize(req.GetCollectionName())),
@@ -391,6 +423,26 @@ func (edge *Edge) LoadCollection(ctx context.Context,
 			c <- failFn(err.Error())
 			return
 		}
+		expirydata, err := edge.loadExpiryHelper(req.GetCollectionName())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		err = edge.VectorStore.LoadedExpiry(req.GetCollectionName(), expirydata)
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		textdata, err := edge.loadFullTextHelper(req.GetCollectionName())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		err = edge.VectorStore.LoadedFullText(req.GetCollectionName(), textdata)
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
 		newAuthorizationBucketHelper(req.GetCollectionName())
 		edge.BucketLifeCycleJob(req.GetCollectionName())
 		c <- successFn()
@@ -472,6 +524,16 @@ func (edge *Edge) ReleaseCollection(ctx context.Context,
 			c <- failFn(err.Error())
 			return
 		}
+		err = edge.saveExpiryHelper(req.GetCollectionName())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		err = edge.saveFullTextHelper(req.GetCollectionName())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
 		edge.VectorStore.DestroySpace(req.GetCollectionName())
 		c <- successFn()
 	}()
@@ -546,6 +608,16 @@ func (edge *Edge) Flush(ctx context.Context,
 			c <- failFn(err.Error())
 			return
 		}
+		err = edge.saveExpiryHelper(req.GetCollectionName())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		err = edge.saveFullTextHelper(req.GetCollectionName())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
 		c <- successFn()
 	}()
 	res := <-c
@@ -588,15 +660,30 @@ func (edge *Edge) Index(ctx context.Context, req *edgepb.IndexChange) (
 		}
 		switch req.GetChanged() {
 		case edgepb.IndexChagedType_CHANGED:
-			if err := edge.VectorStore.ChangedVertex(req.GetCollectionName(), req.GetPrimaryKey(), autoCommitID(), req.GetMetadata().AsMap(), req.GetVectors()); err != nil {
+			metadata := req.GetMetadata().AsMap()
+			updated, err := edge.VectorStore.ChangedVertex(req.GetCollectionName(), req.GetPrimaryKey(), autoCommitID(), metadata, req.GetVectors(),
+				time.Duration(req.GetTtlSeconds())*time.Second)
+			if err != nil {
 				c <- failFn(err.Error())
 				return
 			}
+			op := changefeed.OpInsert
+			if updated {
+				op = changefeed.OpUpdate
+			}
+			edge.Feed.Publish(req.GetCollectionName(), changefeed.Event{
+				Op:       op,
+				Id:       edge.VectorStore.PrimaryKey(req.GetCollectionName(), metadata),
+				Metadata: metadata,
+				Vector:   req.GetVectors(),
+			})
 		case edgepb.IndexChagedType_DELETE:
-			if err := edge.VectorStore.RemoveVertex(req.GetCollectionName(), req.GetMetadata().AsMap()); err != nil {
+			removed, err := edge.VectorStore.RemoveVertex(req.GetCollectionName(), req.GetMetadata().AsMap())
+			if err != nil {
 				c <- failFn(err.Error())
 				return
 			}
+			edge.publishRemovedHelper(req.GetCollectionName(), removed)
 		default:
 			c <- failFn("unsupported changed type")
 			return
@@ -634,12 +721,56 @@ func (edge *Edge) Search(ctx context.Context, req *edgepb.SearchIndex) (
 			c <- failFn(err.Error())
 			return
 		}
-		expr, err := queryExprAnalyzer(req.GetFilterExpression())
+		expr, err := edge.filterHelper(req.GetCollectionName(), req.GetFilterExpression(), req.GetFilter())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		facets, err := edge.facetSearchHelper(req.GetCollectionName(), expr, req.GetFacets())
 		if err != nil {
 			c <- failFn(err.Error())
 			return
 		}
 		items := make([]*SearchResultItem, 0)
+		switch req.GetMode() {
+		case edgepb.SearchMode_TEXT, edgepb.SearchMode_HYBRID:
+			query := TextQuery{
+				Query:        req.GetTextQuery(),
+				Fields:       req.GetTextFields(),
+				Fusion:       req.GetFusion(),
+				VectorWeight: req.GetVectorWeight(),
+				RRFK:         req.GetRrfK(),
+			}
+			var recalls []*SearchResultItem
+			if req.GetMode() == edgepb.SearchMode_TEXT {
+				recalls, err = edge.VectorStore.TextSearch(req.GetCollectionName(), expr, req.GetLimit()+req.GetOffset(), query)
+			} else {
+				recalls, err = edge.VectorStore.HybridSearch(req.GetCollectionName(), expr, req.GetLimit()+req.GetOffset(), req.GetVector(), req.GetHighResourceAvaliable(), query)
+			}
+			if err != nil {
+				c <- failFn(err.Error())
+				return
+			}
+			recallRpc := make([]*edgepb.Candidates, 0, len(recalls))
+			for _, item := range recalls {
+				st, err := structpb.NewStruct(item.Metadata)
+				if err != nil {
+					c <- failFn(err.Error())
+					return
+				}
+				// bm25 and fused scores are reported as is
+				recallRpc = append(recallRpc, &edgepb.Candidates{Metadata: st, Score: item.Score})
+			}
+			c <- reply{
+				Result: &edgepb.SearchResponse{
+					Status:     true,
+					Candidates: recallRpc,
+					Facets:     facets,
+				},
+			}
+			return
+		}
+		var explain *edgepb.QueryPlan
 		switch expr == nil {
 		case true:
 			// non-filter
@@ -650,12 +781,13 @@ func (edge *Edge) Search(ctx context.Context, req *edgepb.SearchIndex) (
 			}
 			items = recalls
 		case false:
-			recalls, err := edge.VectorStore.FilterableVertexSearch(req.GetCollectionName(), expr, req.GetLimit()+req.GetOffset(), req.GetVector(), req.GetHighResourceAvaliable())
+			recalls, plan, err := edge.VectorStore.FilterableVertexSearch(req.GetCollectionName(), expr, req.GetLimit()+req.GetOffset(), req.GetVector(), req.GetHighResourceAvaliable())
 			if err != nil {
 				c <- failFn(err.Error())
 				return
 			}
 			items = recalls
+			explain = explainHelper(plan)
 		default:
 			c <- failFn("unsupported expr type")
 			return
@@ -682,6 +814,71 @@ func (edge *Edge) Search(ctx context.Context, req *edgepb.SearchIndex) (
 			Result: &edgepb.SearchResponse{
 				Status:     true,
 				Candidates: recallRpc,
+				Facets:     facets,
+				Explain:    explain,
+			},
+		}
+	}()
+	res := <-c
+	return res.Result, res.Error
+}
+
+func (edge *Edge) Query(ctx context.Context, req *edgepb.ScalarQuery) (
+	*edgepb.SearchResponse, error) {
+	type reply struct {
+		Result *edgepb.SearchResponse
+		Error  error
+	}
+	c := make(chan reply, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				c <- reply{
+					Error: fmt.Errorf(panicr, r),
+				}
+			}
+		}()
+		failFn := func(errMsg string) reply {
+			return reply{
+				Result: &edgepb.SearchResponse{
+					Status: false,
+					Error:  errorWrap(errMsg),
+				},
+			}
+		}
+		if err := authorization(req.GetCollectionName()); err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		expr, err := edge.filterHelper(req.GetCollectionName(), req.GetFilterExpression(), req.GetFilter())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		facets, err := edge.facetSearchHelper(req.GetCollectionName(), expr, req.GetFacets())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		items, err := edge.VectorStore.Query(req.GetCollectionName(), expr, req.GetOffset(), req.GetLimit())
+		if err != nil {
+			c <- failFn(err.Error())
+			return
+		}
+		recallRpc := make([]*edgepb.Candidates, 0, len(items))
+		for _, item := range items {
+			st, err := structpb.NewStruct(item.Metadata)
+			if err != nil {
+				c <- failFn(err.Error())
+				return
+			}
+			recallRpc = append(recallRpc, &edgepb.Candidates{Metadata: st})
+		}
+		c <- reply{
+			Result: &edgepb.SearchResponse{
+				Status:     true,
+				Candidates: recallRpc,
+				Facets:     facets,
 			},
 		}
 	}()
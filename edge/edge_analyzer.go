@@ -20,6 +20,9 @@ package edge
 import (
 	"errors"
 	"fmt"
+	"math"
+	"regexp"
+	"strings"
 
 	"github.com/sjy-dv/coltt/gen/protoc/v4/edgepb"
 	"github.com/sjy-dv/coltt/pkg/inverted"
@@ -28,8 +31,14 @@ import (
 func standardAnalyzer(metadata map[string]interface{}, analyzer map[string]IndexFeature) error {
 	for _, column := range analyzer {
 
-		value, ok := metadata[column.IndexName]
+		parent, key := metadataField(metadata, column.IndexName)
+		value, ok := parent[key]
+		geo := column.IndexType == int32(edgepb.IndexType_GeoPoint)
 		if !ok {
+			if geo && column.EnableNull {
+				// a geo point has no default, the row is left out of geo filters
+				continue
+			}
 			if column.EnableNull {
 				if column.PrimaryKey {
 					return fmt.Errorf("primaryKey %s must not be empty", column.IndexName)
@@ -50,38 +59,181 @@ func standardAnalyzer(metadata map[string]interface{}, analyzer map[string]Index
 			}
 			continue
 		}
-		switch column.IndexType {
-		case 0:
-			_, ok := value.(string)
+		if column.StoredOnly {
+			continue
+		}
+		if geo {
+			point, ok := geoPointOf(value)
+			if !ok {
+				return fmt.Errorf("index: [%s] type error, expect Type: GeoPoint {\"lat\": -90..90, \"lon\": -180..180}", column.IndexName)
+			}
+			parent[key] = map[string]interface{}{"lat": point.Lat, "lon": point.Lon}
+			continue
+		}
+		if column.IndexType >= int32(edgepb.IndexType_StringArray) {
+			elems, ok := value.([]interface{})
 			if !ok {
 				return fmt.Errorf("index: [%s] type error, expect Type: %s", column.IndexName, edgepb.IndexType_name[column.IndexType])
 			}
-		case 1:
-			switch v := value.(type) {
-			case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-			case float64:
-				if v != float64(int64(v)) {
-					return fmt.Errorf("index: [%s] type error, expect Type: %s", column.IndexName, edgepb.IndexType_name[column.IndexType])
+			elemType := column.IndexType - int32(edgepb.IndexType_StringArray)
+			for i, elem := range elems {
+				normalized, ok := scalarAnalyzer(elemType, elem)
+				if !ok {
+					return fmt.Errorf("index: [%s] element %d type error, expect Type: %s", column.IndexName, i, edgepb.IndexType_name[column.IndexType])
 				}
-				//prevent map forced convert int => float
-				metadata[column.IndexName] = int64(v)
-			default:
-				return fmt.Errorf("index: [%s] type error, expect Type: %s", column.IndexName, edgepb.IndexType_name[column.IndexType])
+				elems[i] = normalized
 			}
-		case 2:
-			switch value.(type) {
-			case float32, float64:
-			default:
-				return fmt.Errorf("index: [%s] type error, expect Type: %s", column.IndexName, edgepb.IndexType_name[column.IndexType])
+			continue
+		}
+		normalized, ok := scalarAnalyzer(column.IndexType, value)
+		if !ok {
+			return fmt.Errorf("index: [%s] type error, expect Type: %s", column.IndexName, edgepb.IndexType_name[column.IndexType])
+		}
+		if normalized != value {
+			parent[key] = normalized
+		}
+	}
+	return nil
+}
+
+// metadataField resolves an index name to the metadata map holding it and its key there.
+// A dotted name addresses a nested object unless a top-level key has that name.
+func metadataField(metadata map[string]interface{}, name string) (map[string]interface{}, string) {
+	if _, ok := metadata[name]; ok || !strings.Contains(name, ".") {
+		return metadata, name
+	}
+	parts := strings.Split(name, ".")
+	parent := metadata
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := parent[part].(map[string]interface{})
+		if !ok {
+			return metadata, name
+		}
+		parent = child
+	}
+	return parent, parts[len(parts)-1]
+}
+
+// indexedMetadata returns the metadata as the inverted index takes it, without
+// the stored-only fields and with the geo points as inverted.GeoPoint values.
+// It is the metadata itself when there is nothing to change.
+func indexedMetadata(metadata map[string]interface{}, analyzer map[string]IndexFeature) map[string]interface{} {
+	indexed := metadata
+	copied := false
+	for _, column := range analyzer {
+		geo := column.IndexType == int32(edgepb.IndexType_GeoPoint)
+		if !column.StoredOnly && !geo {
+			continue
+		}
+		parent, key := metadataField(metadata, column.IndexName)
+		value, ok := parent[key]
+		if !ok {
+			continue
+		}
+		if !copied {
+			indexed = make(map[string]interface{}, len(metadata))
+			for k, v := range metadata {
+				indexed[k] = v
 			}
-		case 3:
-			_, ok := value.(bool)
-			if !ok {
-				return fmt.Errorf("index: [%s] type error, expect Type: %s", column.IndexName, edgepb.IndexType_name[column.IndexType])
+			copied = true
+		}
+		if column.StoredOnly {
+			delete(indexed, column.IndexName)
+			continue
+		}
+		if point, ok := geoPointOf(value); ok {
+			setIndexedField(indexed, column.IndexName, point)
+		}
+	}
+	return indexed
+}
+
+// setIndexedField sets a field of the indexed copy, the nested objects on a
+// dotted path are copied so the stored metadata keeps its value.
+func setIndexedField(indexed map[string]interface{}, name string, value interface{}) {
+	if _, ok := indexed[name]; ok || !strings.Contains(name, ".") {
+		indexed[name] = value
+		return
+	}
+	parts := strings.Split(name, ".")
+	parent := indexed
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := parent[part].(map[string]interface{})
+		if !ok {
+			return
+		}
+		clone := make(map[string]interface{}, len(child))
+		for k, v := range child {
+			clone[k] = v
+		}
+		parent[part] = clone
+		parent = clone
+	}
+	parent[parts[len(parts)-1]] = value
+}
+
+// geoPointOf reads a {"lat": degrees, "lon": degrees} object
+func geoPointOf(value interface{}) (inverted.GeoPoint, bool) {
+	object, ok := value.(map[string]interface{})
+	if !ok || len(object) != 2 {
+		return inverted.GeoPoint{}, false
+	}
+	lat, ok := degreesOf(object["lat"])
+	if !ok {
+		return inverted.GeoPoint{}, false
+	}
+	lon, ok := degreesOf(object["lon"])
+	if !ok {
+		return inverted.GeoPoint{}, false
+	}
+	point := inverted.GeoPoint{Lat: lat, Lon: lon}
+	return point, point.Valid()
+}
+
+func degreesOf(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// scalarAnalyzer checks value against a String, Integer, Float or Boolean index type,
+// integral float64 values of Integer indexes are converted to int64.
+func scalarAnalyzer(indexType int32, value interface{}) (interface{}, bool) {
+	switch indexType {
+	case 0:
+		_, ok := value.(string)
+		return value, ok
+	case 1:
+		switch v := value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		case float64:
+			if v != float64(int64(v)) {
+				return nil, false
 			}
+			//prevent map forced convert int => float
+			return int64(v), true
+		default:
+			return nil, false
 		}
+	case 2:
+		switch value.(type) {
+		case float32, float64:
+		default:
+			return nil, false
+		}
+	case 3:
+		_, ok := value.(bool)
+		return value, ok
 	}
-	return nil
+	return value, true
 }
 func defaultType(typeLevel int32) interface{} {
 	switch typeLevel {
@@ -93,6 +245,8 @@ func defaultType(typeLevel int32) interface{} {
 		return float64(0)
 	case 3:
 		return false
+	case 4, 5, 6, 7:
+		return []interface{}{}
 	default:
 		return nil
 	}
@@ -151,28 +305,89 @@ func dropKeyAnalyzer(dropKey map[string]interface{}, analyzer map[string]IndexFe
 func queryExprAnalyzer(protoExpr *edgepb.FilterExpression) (*inverted.FilterExpression, error) {
 	if protoExpr.GetFilter() != nil {
 		f := protoExpr.GetFilter()
-		var value interface{}
-		switch v := f.Value.(type) {
-		case *edgepb.SearchFilter_StringVal:
-			value = v.StringVal
-		case *edgepb.SearchFilter_IntVal:
-			value = v.IntVal
-		case *edgepb.SearchFilter_FloatVal:
-			value = v.FloatVal
-		case *edgepb.SearchFilter_BoolVal:
-			value = v.BoolVal
+		filter := &inverted.Filter{
+			IndexName: f.IndexName,
+			Op:        convertProtoOp(f.Op),
+		}
+		switch f.Op {
+		case edgepb.Op_IN, edgepb.Op_NOT_IN, edgepb.Op_ANY_OF, edgepb.Op_ALL_OF, edgepb.Op_NONE_OF:
+			if len(f.Values) == 0 {
+				return nil, fmt.Errorf("index: [%s] %s expects values", f.IndexName, f.Op)
+			}
+			for _, fv := range f.Values {
+				value, err := filterValue(fv)
+				if err != nil {
+					return nil, err
+				}
+				filter.Values = append(filter.Values, value)
+			}
+		case edgepb.Op_BETWEEN:
+			lower, err := filterValue(f.Lower)
+			if err != nil {
+				return nil, fmt.Errorf("index: [%s] BETWEEN lower bound: %w", f.IndexName, err)
+			}
+			upper, err := filterValue(f.Upper)
+			if err != nil {
+				return nil, fmt.Errorf("index: [%s] BETWEEN upper bound: %w", f.IndexName, err)
+			}
+			filter.Value, filter.Upper = lower, upper
+			filter.LowerExclusive, filter.UpperExclusive = f.LowerExclusive, f.UpperExclusive
+		case edgepb.Op_IS_NULL, edgepb.Op_IS_NOT_NULL:
+		case edgepb.Op_GEO_RADIUS:
+			if f.Center == nil {
+				return nil, fmt.Errorf("index: [%s] GEO_RADIUS expects a center", f.IndexName)
+			}
+			center := inverted.GeoPoint{Lat: f.Center.Lat, Lon: f.Center.Lon}
+			if !center.Valid() {
+				return nil, fmt.Errorf("index: [%s] GEO_RADIUS center %v is off the globe", f.IndexName, center)
+			}
+			if !(f.RadiusMeters >= 0) {
+				return nil, fmt.Errorf("index: [%s] GEO_RADIUS radius must not be negative", f.IndexName)
+			}
+			filter.Value, filter.Radius = center, f.RadiusMeters
+		case edgepb.Op_GEO_BBOX:
+			if f.SouthWest == nil || f.NorthEast == nil {
+				return nil, fmt.Errorf("index: [%s] GEO_BBOX expects south_west and north_east", f.IndexName)
+			}
+			southWest := inverted.GeoPoint{Lat: f.SouthWest.Lat, Lon: f.SouthWest.Lon}
+			northEast := inverted.GeoPoint{Lat: f.NorthEast.Lat, Lon: f.NorthEast.Lon}
+			if !southWest.Valid() || !northEast.Valid() || southWest.Lat > northEast.Lat {
+				return nil, fmt.Errorf("index: [%s] GEO_BBOX corners %v %v are not a south-west and a north-east point", f.IndexName, southWest, northEast)
+			}
+			filter.Value, filter.Upper = southWest, northEast
+		case edgepb.Op_PREFIX, edgepb.Op_CONTAINS, edgepb.Op_REGEX:
+			v, ok := f.Value.(*edgepb.SearchFilter_StringVal)
+			if !ok {
+				return nil, fmt.Errorf("index: [%s] %s expects a string value", f.IndexName, f.Op)
+			}
+			if f.Op == edgepb.Op_REGEX {
+				if _, err := regexp.Compile(v.StringVal); err != nil {
+					return nil, fmt.Errorf("index: [%s] invalid regex: %v", f.IndexName, err)
+				}
+			}
+			filter.Value = v.StringVal
 		default:
-			return nil, fmt.Errorf("unsupported filter value type")
+			switch v := f.Value.(type) {
+			case *edgepb.SearchFilter_StringVal:
+				filter.Value = v.StringVal
+			case *edgepb.SearchFilter_IntVal:
+				filter.Value = v.IntVal
+			case *edgepb.SearchFilter_FloatVal:
+				filter.Value = v.FloatVal
+			case *edgepb.SearchFilter_BoolVal:
+				filter.Value = v.BoolVal
+			default:
+				return nil, fmt.Errorf("unsupported filter value type")
+			}
 		}
 		return &inverted.FilterExpression{
-			Single: &inverted.Filter{
-				IndexName: f.IndexName,
-				Op:        convertProtoOp(f.Op),
-				Value:     value,
-			},
+			Single: filter,
 		}, nil
 	} else if protoExpr.GetComposite() != nil {
 		comp := protoExpr.GetComposite()
+		if comp.Op == edgepb.LogicalOperator_NOT && len(comp.Expressions) != 1 {
+			return nil, fmt.Errorf("NOT expects a single expression, got %d", len(comp.Expressions))
+		}
 		var exprs []*inverted.FilterExpression
 		for _, pe := range comp.Expressions {
 			fe, err := queryExprAnalyzer(pe)
@@ -190,3 +405,53 @@ func queryExprAnalyzer(protoExpr *edgepb.FilterExpression) (*inverted.FilterExpr
 	}
 	return nil, nil
 }
+
+// facetAnalyzer checks the facet requests against the index design,
+// stats and histograms need a numeric index
+func facetAnalyzer(facets []*edgepb.FacetRequest, analyzer map[string]IndexFeature) ([]inverted.FacetRequest, error) {
+	requests := make([]inverted.FacetRequest, 0, len(facets))
+	for _, facet := range facets {
+		column, ok := analyzer[facet.IndexName]
+		if !ok {
+			return nil, fmt.Errorf("facet: index [%s] is not defined", facet.IndexName)
+		}
+		if column.StoredOnly || column.IndexType == int32(edgepb.IndexType_GeoPoint) {
+			return nil, fmt.Errorf("facet: index [%s] of type %s can't be counted", facet.IndexName, edgepb.IndexType_name[column.IndexType])
+		}
+		if facet.Stats || facet.HistogramInterval != 0 {
+			switch edgepb.IndexType(column.IndexType) {
+			case edgepb.IndexType_Integer, edgepb.IndexType_Float, edgepb.IndexType_IntegerArray, edgepb.IndexType_FloatArray:
+			default:
+				return nil, fmt.Errorf("facet: index [%s] stats and histogram need a numeric index", facet.IndexName)
+			}
+		}
+		if !(facet.HistogramInterval >= 0) || math.IsInf(facet.HistogramInterval, 1) {
+			return nil, fmt.Errorf("facet: index [%s] invalid histogram interval %v", facet.IndexName, facet.HistogramInterval)
+		}
+		topN := int(facet.TopN)
+		if topN == 0 {
+			topN = defaultFacetTopN
+		}
+		requests = append(requests, inverted.FacetRequest{
+			IndexName:         facet.IndexName,
+			TopN:              topN,
+			Stats:             facet.Stats,
+			HistogramInterval: facet.HistogramInterval,
+		})
+	}
+	return requests, nil
+}
+
+func filterValue(fv *edgepb.FilterValue) (interface{}, error) {
+	switch v := fv.GetValue().(type) {
+	case *edgepb.FilterValue_StringVal:
+		return v.StringVal, nil
+	case *edgepb.FilterValue_IntVal:
+		return v.IntVal, nil
+	case *edgepb.FilterValue_FloatVal:
+		return v.FloatVal, nil
+	case *edgepb.FilterValue_BoolVal:
+		return v.BoolVal, nil
+	}
+	return nil, fmt.Errorf("unsupported filter value type")
+}
@@ -0,0 +1,90 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package edge
+
+import (
+	"fmt"
+
+	"github.com/sjy-dv/coltt/gen/protoc/v4/edgepb"
+	"github.com/sjy-dv/coltt/pkg/changefeed"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Subscribe streams the insert, update and delete events of a collection.
+// A client resumes after a reconnect by passing the position of the last
+// event it received, the stream fails when that position is no longer held.
+func (edge *Edge) Subscribe(req *edgepb.SubscribeRequest, stream grpc.ServerStreamingServer[edgepb.ChangeEvent]) error {
+	if !hasCollection(req.GetCollectionName()) {
+		return fmt.Errorf(ErrCollectionNotFound, req.GetCollectionName())
+	}
+	sub, err := edge.Feed.Subscribe(req.GetCollectionName(), req.GetFromPosition())
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+	for {
+		event, err := sub.Next(stream.Context())
+		if err != nil {
+			return err
+		}
+		msg, err := changeEventHelper(event, req.GetWithVector())
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (helper *Edge) publishRemovedHelper(collectionName string, removed []map[string]interface{}) {
+	for _, metadata := range removed {
+		helper.Feed.Publish(collectionName, changefeed.Event{
+			Op:       changefeed.OpDelete,
+			Id:       helper.VectorStore.PrimaryKey(collectionName, metadata),
+			Metadata: metadata,
+		})
+	}
+}
+
+func changeEventHelper(event changefeed.Event, withVector bool) (*edgepb.ChangeEvent, error) {
+	msg := &edgepb.ChangeEvent{
+		Position:   event.Seq,
+		PrimaryKey: event.Id,
+	}
+	switch event.Op {
+	case changefeed.OpInsert:
+		msg.ChangeType = edgepb.ChangeEventType_CHANGE_INSERT
+	case changefeed.OpUpdate:
+		msg.ChangeType = edgepb.ChangeEventType_CHANGE_UPDATE
+	case changefeed.OpDelete:
+		msg.ChangeType = edgepb.ChangeEventType_CHANGE_DELETE
+	}
+	if event.Metadata != nil {
+		metadata, err := structpb.NewStruct(event.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		msg.Metadata = metadata
+	}
+	if withVector {
+		msg.Vectors = event.Vector
+	}
+	return msg, nil
+}
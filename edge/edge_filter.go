@@ -0,0 +1,621 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package edge
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sjy-dv/coltt/gen/protoc/v4/edgepb"
+)
+
+/*
+filter language of SearchIndex.filter, parsed and checked against the index design
+
+	expr      := and { OR and }
+	and       := unary { AND unary }
+	unary     := NOT unary | "(" expr ")" | predicate
+	predicate := field ( "=" | "==" | "!=" | "<>" | ">" | ">=" | "<" | "<=" ) value
+	           | field BETWEEN value AND value
+	           | field [ NOT ] IN list
+	           | field ( ANY_OF | ALL_OF | NONE_OF ) list
+	           | field ( PREFIX | CONTAINS | REGEX ) string
+	           | field IS [ NOT ] NULL
+	           | field GEO_RADIUS "(" lat "," lon "," meters ")"
+	           | field GEO_BBOX "(" south "," west "," north "," east ")"
+	list      := "[" value { "," value } "]"
+	value     := string | number | TRUE | FALSE
+	field     := name | `quoted name`
+
+keywords are case-insensitive, strings are double or single quoted with Go escapes.
+e.g. (type > 5 AND size < 4) OR volume <= 0.5 AND tag IN ["a", "b"]
+*/
+
+// filterError is an error at a line and column of the filter text
+type filterError struct {
+	line, col int
+	msg       string
+}
+
+func (e *filterError) Error() string {
+	return fmt.Sprintf("filter %d:%d: %s", e.line, e.col, e.msg)
+}
+
+type filterTokenKind int
+
+const (
+	tokEOF filterTokenKind = iota
+	tokIdent
+	tokQuotedIdent
+	tokString
+	tokNumber
+	tokOp
+	tokPunct
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string // the source, the unquoted value of strings and quoted idents
+	pos  int
+}
+
+var filterKeywords = map[string]struct{}{
+	"AND": {}, "OR": {}, "NOT": {}, "IN": {}, "BETWEEN": {}, "IS": {}, "NULL": {},
+	"TRUE": {}, "FALSE": {}, "PREFIX": {}, "CONTAINS": {}, "REGEX": {},
+	"ANY_OF": {}, "ALL_OF": {}, "NONE_OF": {}, "GEO_RADIUS": {}, "GEO_BBOX": {},
+}
+
+type filterParser struct {
+	src    string
+	tokens []filterToken
+	i      int
+	schema map[string]IndexFeature
+}
+
+// parseFilter parses the filter text into a FilterExpression,
+// checking every predicate against the index design
+func parseFilter(src string, schema map[string]IndexFeature) (*edgepb.FilterExpression, error) {
+	p := &filterParser{src: src, schema: schema}
+	if err := p.lex(); err != nil {
+		return nil, err
+	}
+	if p.peek().kind == tokEOF {
+		return nil, p.errorf(p.peek().pos, "empty filter")
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorf(tok.pos, "unexpected %s", describeToken(tok))
+	}
+	return expr, nil
+}
+
+func (p *filterParser) errorf(pos int, format string, args ...interface{}) error {
+	line, col := 1, 1
+	for _, r := range p.src[:pos] {
+		if r == '\n' {
+			line++
+			col = 1
+			continue
+		}
+		col++
+	}
+	return &filterError{line: line, col: col, msg: fmt.Sprintf(format, args...)}
+}
+
+func describeToken(tok filterToken) string {
+	switch tok.kind {
+	case tokEOF:
+		return "end of filter"
+	case tokString:
+		return strconv.Quote(tok.text)
+	case tokQuotedIdent:
+		return "`" + tok.text + "`"
+	}
+	return "'" + tok.text + "'"
+}
+
+func (p *filterParser) lex() error {
+	src := p.src
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(' || r == ')' || r == '[' || r == ']' || r == ',':
+			p.tokens = append(p.tokens, filterToken{kind: tokPunct, text: string(r), pos: i})
+			i++
+		case strings.ContainsRune("=!<>", r):
+			op := src[i : i+1]
+			if i+1 < len(src) {
+				switch two := src[i : i+2]; two {
+				case "==", "!=", "<>", "<=", ">=":
+					op = two
+				}
+			}
+			if op == "!" {
+				return p.errorf(i, "unexpected '!', did you mean '!='")
+			}
+			p.tokens = append(p.tokens, filterToken{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		case r == '"' || r == '\'':
+			end, value, err := scanFilterString(src, i)
+			if err != nil {
+				return p.errorf(i, "%s", err)
+			}
+			p.tokens = append(p.tokens, filterToken{kind: tokString, text: value, pos: i})
+			i = end
+		case r == '`':
+			end := strings.IndexByte(src[i+1:], '`')
+			if end < 0 {
+				return p.errorf(i, "unterminated quoted name")
+			}
+			p.tokens = append(p.tokens, filterToken{kind: tokQuotedIdent, text: src[i+1 : i+1+end], pos: i})
+			i += end + 2
+		case r == '-' || r == '+' || r == '.' || unicode.IsDigit(r):
+			end := i + 1
+			for end < len(src) {
+				c := src[end]
+				if ('0' <= c && c <= '9') || c == '.' || c == 'e' || c == 'E' || c == '_' ||
+					((c == '-' || c == '+') && (src[end-1] == 'e' || src[end-1] == 'E')) {
+					end++
+					continue
+				}
+				break
+			}
+			p.tokens = append(p.tokens, filterToken{kind: tokNumber, text: src[i:end], pos: i})
+			i = end
+		case r == '_' || unicode.IsLetter(r):
+			end := i + size
+			for end < len(src) {
+				c, n := utf8.DecodeRuneInString(src[end:])
+				if c != '_' && c != '.' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+					break
+				}
+				end += n
+			}
+			p.tokens = append(p.tokens, filterToken{kind: tokIdent, text: src[i:end], pos: i})
+			i = end
+		default:
+			return p.errorf(i, "unexpected character %q", r)
+		}
+	}
+	p.tokens = append(p.tokens, filterToken{kind: tokEOF, pos: len(src)})
+	return nil
+}
+
+// scanFilterString reads the quoted string at start and returns the offset after it
+func scanFilterString(src string, start int) (int, string, error) {
+	quote := src[start]
+	for i := start + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case quote:
+			raw := src[start : i+1]
+			if quote == '\'' {
+				// as a Go string, a lone " is escaped and \' unescaped
+				raw = `"` + strings.ReplaceAll(strings.ReplaceAll(raw[1:len(raw)-1], `\'`, `'`), `"`, `\"`) + `"`
+			}
+			value, err := strconv.Unquote(raw)
+			if err != nil {
+				return 0, "", fmt.Errorf("invalid string %s", src[start:i+1])
+			}
+			return i + 1, value, nil
+		}
+	}
+	return 0, "", fmt.Errorf("unterminated string")
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.i]
+}
+
+func (p *filterParser) next() filterToken {
+	tok := p.tokens[p.i]
+	if tok.kind != tokEOF {
+		p.i++
+	}
+	return tok
+}
+
+// keyword reports whether the next token is the keyword and consumes it
+func (p *filterParser) keyword(word string) bool {
+	if tok := p.peek(); tok.kind == tokIdent && strings.EqualFold(tok.text, word) {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) expect(punct string) error {
+	tok := p.next()
+	if tok.kind != tokPunct || tok.text != punct {
+		return p.errorf(tok.pos, "expected '%s', got %s", punct, describeToken(tok))
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (*edgepb.FilterExpression, error) {
+	return p.parseChain(edgepb.LogicalOperator_OR, "OR", p.parseAnd)
+}
+
+func (p *filterParser) parseAnd() (*edgepb.FilterExpression, error) {
+	return p.parseChain(edgepb.LogicalOperator_AND, "AND", p.parseUnary)
+}
+
+// parseChain folds operand { word operand } into one composite
+func (p *filterParser) parseChain(op edgepb.LogicalOperator, word string, operand func() (*edgepb.FilterExpression, error)) (*edgepb.FilterExpression, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	exprs := []*edgepb.FilterExpression{first}
+	for p.keyword(word) {
+		expr, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	if len(exprs) == 1 {
+		return first, nil
+	}
+	return compositeExpr(op, exprs...), nil
+}
+
+func compositeExpr(op edgepb.LogicalOperator, exprs ...*edgepb.FilterExpression) *edgepb.FilterExpression {
+	return &edgepb.FilterExpression{Expr: &edgepb.FilterExpression_Composite{
+		Composite: &edgepb.CompositeFilter{Op: op, Expressions: exprs},
+	}}
+}
+
+func (p *filterParser) parseUnary() (*edgepb.FilterExpression, error) {
+	if p.keyword("NOT") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return compositeExpr(edgepb.LogicalOperator_NOT, expr), nil
+	}
+	if tok := p.peek(); tok.kind == tokPunct && tok.text == "(" {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+	return p.parsePredicate()
+}
+
+// filterLiteral is a value of the filter text before it is checked against an index type
+type filterLiteral struct {
+	tok   filterToken
+	value interface{} // string, int64, float64 or bool
+}
+
+func (p *filterParser) parseValue() (filterLiteral, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokString:
+		return filterLiteral{tok: tok, value: tok.text}, nil
+	case tokNumber:
+		text := strings.ReplaceAll(tok.text, "_", "")
+		if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return filterLiteral{tok: tok, value: i}, nil
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return filterLiteral{}, p.errorf(tok.pos, "invalid number %s", tok.text)
+		}
+		return filterLiteral{tok: tok, value: f}, nil
+	case tokIdent:
+		switch strings.ToUpper(tok.text) {
+		case "TRUE":
+			return filterLiteral{tok: tok, value: true}, nil
+		case "FALSE":
+			return filterLiteral{tok: tok, value: false}, nil
+		}
+	}
+	return filterLiteral{}, p.errorf(tok.pos, "expected a value, got %s", describeToken(tok))
+}
+
+func (p *filterParser) parseList() ([]filterLiteral, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	var values []filterLiteral
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		tok := p.next()
+		if tok.kind == tokPunct && tok.text == "]" {
+			return values, nil
+		}
+		if tok.kind != tokPunct || tok.text != "," {
+			return nil, p.errorf(tok.pos, "expected ',' or ']', got %s", describeToken(tok))
+		}
+	}
+}
+
+// parseNumbers reads "(" number { "," number } ")" of n numbers
+func (p *filterParser) parseNumbers(n int) ([]float64, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	numbers := make([]float64, 0, n)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		switch v := value.value.(type) {
+		case int64:
+			numbers = append(numbers, float64(v))
+		case float64:
+			numbers = append(numbers, v)
+		default:
+			return nil, p.errorf(value.tok.pos, "expected a number, got %s", describeToken(value.tok))
+		}
+	}
+	return numbers, p.expect(")")
+}
+
+var filterCompareOps = map[string]edgepb.Op{
+	"=": edgepb.Op_EQ, "==": edgepb.Op_EQ, "!=": edgepb.Op_NEQ, "<>": edgepb.Op_NEQ,
+	">": edgepb.Op_GT, ">=": edgepb.Op_GTE, "<": edgepb.Op_LT, "<=": edgepb.Op_LTE,
+}
+
+func (p *filterParser) parsePredicate() (*edgepb.FilterExpression, error) {
+	fieldTok := p.next()
+	switch fieldTok.kind {
+	case tokQuotedIdent:
+	case tokIdent:
+		if _, ok := filterKeywords[strings.ToUpper(fieldTok.text)]; ok {
+			return nil, p.errorf(fieldTok.pos, "expected an index name, got keyword %s, quote the name with `` to use it", strings.ToUpper(fieldTok.text))
+		}
+	default:
+		return nil, p.errorf(fieldTok.pos, "expected an index name, got %s", describeToken(fieldTok))
+	}
+	column, ok := p.schema[fieldTok.text]
+	if !ok {
+		return nil, p.errorf(fieldTok.pos, "index [%s] is not defined", fieldTok.text)
+	}
+	if column.StoredOnly {
+		return nil, p.errorf(fieldTok.pos, "index [%s] is stored only and can't be filtered", fieldTok.text)
+	}
+	filter := &edgepb.SearchFilter{IndexName: column.IndexName}
+
+	opTok := p.peek()
+	if opTok.kind == tokOp {
+		p.next()
+		filter.Op = filterCompareOps[opTok.text]
+		if err := p.checkOp(column, filter.Op, opTok); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		fv, err := p.checkValue(column, value)
+		if err != nil {
+			return nil, err
+		}
+		setFilterValue(filter, fv)
+		return singleExpr(filter), nil
+	}
+	if opTok.kind != tokIdent {
+		return nil, p.errorf(opTok.pos, "expected an operator after [%s], got %s", fieldTok.text, describeToken(opTok))
+	}
+	p.next()
+	switch word := strings.ToUpper(opTok.text); word {
+	case "BETWEEN":
+		filter.Op = edgepb.Op_BETWEEN
+		if err := p.checkOp(column, filter.Op, opTok); err != nil {
+			return nil, err
+		}
+		lower, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if !p.keyword("AND") {
+			tok := p.peek()
+			return nil, p.errorf(tok.pos, "expected AND of BETWEEN, got %s", describeToken(tok))
+		}
+		upper, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if filter.Lower, err = p.checkValue(column, lower); err != nil {
+			return nil, err
+		}
+		if filter.Upper, err = p.checkValue(column, upper); err != nil {
+			return nil, err
+		}
+	case "NOT", "IN", "ANY_OF", "ALL_OF", "NONE_OF":
+		filter.Op = map[string]edgepb.Op{"IN": edgepb.Op_IN, "ANY_OF": edgepb.Op_ANY_OF, "ALL_OF": edgepb.Op_ALL_OF, "NONE_OF": edgepb.Op_NONE_OF}[word]
+		if word == "NOT" {
+			if !p.keyword("IN") {
+				tok := p.peek()
+				return nil, p.errorf(tok.pos, "expected IN after NOT, got %s", describeToken(tok))
+			}
+			filter.Op = edgepb.Op_NOT_IN
+		}
+		if err := p.checkOp(column, filter.Op, opTok); err != nil {
+			return nil, err
+		}
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			fv, err := p.checkValue(column, value)
+			if err != nil {
+				return nil, err
+			}
+			filter.Values = append(filter.Values, fv)
+		}
+	case "PREFIX", "CONTAINS", "REGEX":
+		filter.Op = map[string]edgepb.Op{"PREFIX": edgepb.Op_PREFIX, "CONTAINS": edgepb.Op_CONTAINS, "REGEX": edgepb.Op_REGEX}[word]
+		if err := p.checkOp(column, filter.Op, opTok); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		pattern, ok := value.value.(string)
+		if !ok {
+			return nil, p.errorf(value.tok.pos, "%s expects a string, got %s", word, describeToken(value.tok))
+		}
+		if filter.Op == edgepb.Op_REGEX {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return nil, p.errorf(value.tok.pos, "invalid regex: %v", err)
+			}
+		}
+		filter.Value = &edgepb.SearchFilter_StringVal{StringVal: pattern}
+	case "IS":
+		filter.Op = edgepb.Op_IS_NULL
+		if p.keyword("NOT") {
+			filter.Op = edgepb.Op_IS_NOT_NULL
+		}
+		if !p.keyword("NULL") {
+			tok := p.peek()
+			return nil, p.errorf(tok.pos, "expected NULL, got %s", describeToken(tok))
+		}
+	case "GEO_RADIUS":
+		filter.Op = edgepb.Op_GEO_RADIUS
+		if err := p.checkOp(column, filter.Op, opTok); err != nil {
+			return nil, err
+		}
+		numbers, err := p.parseNumbers(3)
+		if err != nil {
+			return nil, err
+		}
+		filter.Center = &edgepb.GeoLocation{Lat: numbers[0], Lon: numbers[1]}
+		filter.RadiusMeters = numbers[2]
+	case "GEO_BBOX":
+		filter.Op = edgepb.Op_GEO_BBOX
+		if err := p.checkOp(column, filter.Op, opTok); err != nil {
+			return nil, err
+		}
+		numbers, err := p.parseNumbers(4)
+		if err != nil {
+			return nil, err
+		}
+		filter.SouthWest = &edgepb.GeoLocation{Lat: numbers[0], Lon: numbers[1]}
+		filter.NorthEast = &edgepb.GeoLocation{Lat: numbers[2], Lon: numbers[3]}
+	default:
+		return nil, p.errorf(opTok.pos, "unknown operator %s", describeToken(opTok))
+	}
+	return singleExpr(filter), nil
+}
+
+func singleExpr(filter *edgepb.SearchFilter) *edgepb.FilterExpression {
+	return &edgepb.FilterExpression{Expr: &edgepb.FilterExpression_Filter{Filter: filter}}
+}
+
+// checkOp reports an operator the index type doesn't support
+func (p *filterParser) checkOp(column IndexFeature, op edgepb.Op, tok filterToken) error {
+	indexType := edgepb.IndexType(column.IndexType)
+	array := indexType >= edgepb.IndexType_StringArray && indexType <= edgepb.IndexType_BooleanArray
+	var ok bool
+	switch op {
+	case edgepb.Op_EQ, edgepb.Op_NEQ, edgepb.Op_IN, edgepb.Op_NOT_IN:
+		ok = indexType != edgepb.IndexType_GeoPoint
+	case edgepb.Op_GT, edgepb.Op_GTE, edgepb.Op_LT, edgepb.Op_LTE, edgepb.Op_BETWEEN:
+		ok = indexType == edgepb.IndexType_String || indexType == edgepb.IndexType_Integer || indexType == edgepb.IndexType_Float
+	case edgepb.Op_PREFIX, edgepb.Op_CONTAINS, edgepb.Op_REGEX:
+		ok = indexType == edgepb.IndexType_String
+	case edgepb.Op_ANY_OF, edgepb.Op_ALL_OF, edgepb.Op_NONE_OF:
+		ok = array
+	case edgepb.Op_GEO_RADIUS, edgepb.Op_GEO_BBOX:
+		ok = indexType == edgepb.IndexType_GeoPoint
+	}
+	if !ok {
+		return p.errorf(tok.pos, "operator %s is not supported by index [%s] of type %s", op, column.IndexName, indexType)
+	}
+	return nil
+}
+
+// checkValue converts the literal to the value type of the index, of its elements for arrays
+func (p *filterParser) checkValue(column IndexFeature, value filterLiteral) (*edgepb.FilterValue, error) {
+	indexType := edgepb.IndexType(column.IndexType)
+	if indexType >= edgepb.IndexType_StringArray && indexType <= edgepb.IndexType_BooleanArray {
+		indexType -= edgepb.IndexType_StringArray
+	}
+	switch v := value.value.(type) {
+	case string:
+		if indexType == edgepb.IndexType_String {
+			return &edgepb.FilterValue{Value: &edgepb.FilterValue_StringVal{StringVal: v}}, nil
+		}
+	case int64:
+		switch indexType {
+		case edgepb.IndexType_Integer:
+			return &edgepb.FilterValue{Value: &edgepb.FilterValue_IntVal{IntVal: v}}, nil
+		case edgepb.IndexType_Float:
+			return &edgepb.FilterValue{Value: &edgepb.FilterValue_FloatVal{FloatVal: float64(v)}}, nil
+		}
+	case float64:
+		switch indexType {
+		case edgepb.IndexType_Integer:
+			// integral floats as scalarAnalyzer takes them
+			if v == float64(int64(v)) {
+				return &edgepb.FilterValue{Value: &edgepb.FilterValue_IntVal{IntVal: int64(v)}}, nil
+			}
+		case edgepb.IndexType_Float:
+			return &edgepb.FilterValue{Value: &edgepb.FilterValue_FloatVal{FloatVal: v}}, nil
+		}
+	case bool:
+		if indexType == edgepb.IndexType_Boolean {
+			return &edgepb.FilterValue{Value: &edgepb.FilterValue_BoolVal{BoolVal: v}}, nil
+		}
+	}
+	return nil, p.errorf(value.tok.pos, "index [%s] of type %s can't be compared to %s", column.IndexName, edgepb.IndexType(column.IndexType), describeToken(value.tok))
+}
+
+func setFilterValue(filter *edgepb.SearchFilter, fv *edgepb.FilterValue) {
+	switch v := fv.Value.(type) {
+	case *edgepb.FilterValue_StringVal:
+		filter.Value = &edgepb.SearchFilter_StringVal{StringVal: v.StringVal}
+	case *edgepb.FilterValue_IntVal:
+		filter.Value = &edgepb.SearchFilter_IntVal{IntVal: v.IntVal}
+	case *edgepb.FilterValue_FloatVal:
+		filter.Value = &edgepb.SearchFilter_FloatVal{FloatVal: v.FloatVal}
+	case *edgepb.FilterValue_BoolVal:
+		filter.Value = &edgepb.SearchFilter_BoolVal{BoolVal: v.BoolVal}
+	}
+}
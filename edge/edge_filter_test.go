@@ -0,0 +1,204 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package edge
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sjy-dv/coltt/gen/protoc/v4/edgepb"
+)
+
+var filterTestSchema = map[string]IndexFeature{
+	"a":        {IndexName: "a", IndexType: int32(edgepb.IndexType_Integer)},
+	"b":        {IndexName: "b", IndexType: int32(edgepb.IndexType_Integer)},
+	"c":        {IndexName: "c", IndexType: int32(edgepb.IndexType_Integer)},
+	"price":    {IndexName: "price", IndexType: int32(edgepb.IndexType_Float)},
+	"name":     {IndexName: "name", IndexType: int32(edgepb.IndexType_String)},
+	"flag":     {IndexName: "flag", IndexType: int32(edgepb.IndexType_Boolean)},
+	"tags":     {IndexName: "tags", IndexType: int32(edgepb.IndexType_StringArray)},
+	"sizes":    {IndexName: "sizes", IndexType: int32(edgepb.IndexType_FloatArray)},
+	"loc":      {IndexName: "loc", IndexType: int32(edgepb.IndexType_GeoPoint)},
+	"raw":      {IndexName: "raw", IndexType: int32(edgepb.IndexType_String), StoredOnly: true},
+	"and":      {IndexName: "and", IndexType: int32(edgepb.IndexType_String)},
+	"my field": {IndexName: "my field", IndexType: int32(edgepb.IndexType_Integer)},
+}
+
+// formatFilter writes the expression as OP(expr, ...) and predicates as
+// "index OP values", floats are suffixed with f to tell them from integers
+func formatFilter(expr *edgepb.FilterExpression) string {
+	if composite := expr.GetComposite(); composite != nil {
+		args := make([]string, 0, len(composite.Expressions))
+		for _, e := range composite.Expressions {
+			args = append(args, formatFilter(e))
+		}
+		return fmt.Sprintf("%s(%s)", composite.Op, strings.Join(args, ", "))
+	}
+	filter := expr.GetFilter()
+	parts := []string{filter.IndexName, filter.Op.String()}
+	switch v := filter.Value.(type) {
+	case *edgepb.SearchFilter_StringVal:
+		parts = append(parts, fmt.Sprintf("%q", v.StringVal))
+	case *edgepb.SearchFilter_IntVal:
+		parts = append(parts, fmt.Sprintf("%d", v.IntVal))
+	case *edgepb.SearchFilter_FloatVal:
+		parts = append(parts, fmt.Sprintf("%gf", v.FloatVal))
+	case *edgepb.SearchFilter_BoolVal:
+		parts = append(parts, fmt.Sprintf("%t", v.BoolVal))
+	}
+	if filter.Values != nil {
+		values := make([]string, 0, len(filter.Values))
+		for _, fv := range filter.Values {
+			values = append(values, formatFilterValue(fv))
+		}
+		parts = append(parts, "["+strings.Join(values, " ")+"]")
+	}
+	if filter.Lower != nil {
+		parts = append(parts, formatFilterValue(filter.Lower), formatFilterValue(filter.Upper))
+	}
+	if filter.Center != nil {
+		parts = append(parts, fmt.Sprintf("(%g %g) %g", filter.Center.Lat, filter.Center.Lon, filter.RadiusMeters))
+	}
+	if filter.SouthWest != nil {
+		parts = append(parts, fmt.Sprintf("(%g %g) (%g %g)", filter.SouthWest.Lat, filter.SouthWest.Lon, filter.NorthEast.Lat, filter.NorthEast.Lon))
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatFilterValue(fv *edgepb.FilterValue) string {
+	switch v := fv.Value.(type) {
+	case *edgepb.FilterValue_StringVal:
+		return fmt.Sprintf("%q", v.StringVal)
+	case *edgepb.FilterValue_IntVal:
+		return fmt.Sprintf("%d", v.IntVal)
+	case *edgepb.FilterValue_FloatVal:
+		return fmt.Sprintf("%gf", v.FloatVal)
+	case *edgepb.FilterValue_BoolVal:
+		return fmt.Sprintf("%t", v.BoolVal)
+	}
+	return "?"
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		// precedence
+		{`a = 1 OR b = 2 AND c = 3`, `OR(a EQ 1, AND(b EQ 2, c EQ 3))`},
+		{`a = 1 AND b = 2 OR c = 3`, `OR(AND(a EQ 1, b EQ 2), c EQ 3)`},
+		{`(a = 1 OR b = 2) AND c = 3`, `AND(OR(a EQ 1, b EQ 2), c EQ 3)`},
+		{`a = 1 or b = 2 or c = 3`, `OR(a EQ 1, b EQ 2, c EQ 3)`},
+		{`NOT a = 1 AND b = 2`, `AND(NOT(a EQ 1), b EQ 2)`},
+		{`not (a = 1 OR b = 2)`, `NOT(OR(a EQ 1, b EQ 2))`},
+		{`NOT NOT a != 1`, `NOT(NOT(a NEQ 1))`},
+		// BETWEEN takes its AND before the logical one
+		{`a BETWEEN 1 AND 5 AND b <> 2`, `AND(a BETWEEN 1 5, b NEQ 2)`},
+		{`a between -3 and 3 OR c >= 1`, `OR(a BETWEEN -3 3, c GTE 1)`},
+		// comparisons
+		{`a == 1`, `a EQ 1`},
+		{`a < 1 AND a <= 2 AND a > 0 AND a >= 0`, `AND(a LT 1, a LTE 2, a GT 0, a GTE 0)`},
+		{`name = "x" AND name != 'y'`, `AND(name EQ "x", name NEQ "y")`},
+		{`name = 'it\'s "q"'`, `name EQ "it's \"q\""`},
+		{`flag = TRUE OR flag = false`, `OR(flag EQ true, flag EQ false)`},
+		// lists
+		{`a IN [1, 2, 3]`, `a IN [1 2 3]`},
+		{`name NOT IN ['x', "y"]`, `name NOT_IN ["x" "y"]`},
+		{`tags ANY_OF ['red', 'blue']`, `tags ANY_OF ["red" "blue"]`},
+		{`tags all_of ['red'] AND tags NONE_OF ['green']`, `AND(tags ALL_OF ["red"], tags NONE_OF ["green"])`},
+		{`sizes ANY_OF [1, 2.5]`, `sizes ANY_OF [1f 2.5f]`},
+		// quoted names and keywords
+		{"`and` = 'x'", `and EQ "x"`},
+		{"`my field` >= 2 AND `and` PREFIX 'a'", `AND(my field GTE 2, and PREFIX "a")`},
+		// int/float coercion
+		{`price > 1`, `price GT 1f`},
+		{`price BETWEEN 0.5 AND 2`, `price BETWEEN 0.5f 2f`},
+		{`price IN [1, 1.5]`, `price IN [1f 1.5f]`},
+		{`a = 2.0`, `a EQ 2`},
+		{`a = 1e3`, `a EQ 1000`},
+		{`a = 1_000`, `a EQ 1000`},
+		{`a IN [1, 2.0]`, `a IN [1 2]`},
+		// the other predicates
+		{`name PREFIX 'ab' OR name CONTAINS 'b' OR name REGEX '^a.*'`, `OR(name PREFIX "ab", name CONTAINS "b", name REGEX "^a.*")`},
+		{`name IS NULL OR name IS NOT NULL`, `OR(name IS_NULL, name IS_NOT_NULL)`},
+		{`loc GEO_RADIUS(37.5, 127, 300)`, `loc GEO_RADIUS (37.5 127) 300`},
+		{`loc GEO_BBOX(1, 2, 3, 4)`, `loc GEO_BBOX (1 2) (3 4)`},
+	}
+	for _, tt := range tests {
+		expr, err := parseFilter(tt.src, filterTestSchema)
+		if err != nil {
+			t.Errorf("parseFilter(%s): %v", tt.src, err)
+			continue
+		}
+		if got := formatFilter(expr); got != tt.want {
+			t.Errorf("parseFilter(%s)\n got %s\nwant %s", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{``, `filter 1:1: empty filter`},
+		{`a = 1 b`, `filter 1:7: unexpected 'b'`},
+		{`(a = 1`, `filter 1:7: expected ')', got end of filter`},
+		{`a ! 1`, `filter 1:3: unexpected '!', did you mean '!='`},
+		{`a = 1 AND # 2`, `filter 1:11: unexpected character '#'`},
+		{`name = 'abc`, `filter 1:8: unterminated string`},
+		{"`and = 1", "filter 1:1: unterminated quoted name"},
+		{`and = 1`, "filter 1:1: expected an index name, got keyword AND, quote the name with `` to use it"},
+		{`a = 1 AND nope = 2`, `filter 1:11: index [nope] is not defined`},
+		{`raw = 'x'`, `filter 1:1: index [raw] is stored only and can't be filtered`},
+		{`a`, `filter 1:2: expected an operator after [a], got end of filter`},
+		{`a LIKE 1`, `filter 1:3: unknown operator 'LIKE'`},
+		{`a BETWEEN 1 OR 2`, `filter 1:13: expected AND of BETWEEN, got 'OR'`},
+		{`a NOT 1`, `filter 1:7: expected IN after NOT, got '1'`},
+		{`a IN [1, 2`, `filter 1:11: expected ',' or ']', got end of filter`},
+		{`a IN 1`, `filter 1:6: expected '[', got '1'`},
+		{`name IS 1`, `filter 1:9: expected NULL, got '1'`},
+		{`name REGEX '('`, "filter 1:12: invalid regex: error parsing regexp: missing closing ): `(`"},
+		{`loc GEO_RADIUS(1, 'x', 3)`, `filter 1:19: expected a number, got "x"`},
+		// unsupported operators
+		{`price PREFIX 'a'`, `filter 1:7: operator PREFIX is not supported by index [price] of type Float`},
+		{`flag > true`, `filter 1:6: operator GT is not supported by index [flag] of type Boolean`},
+		{`a ANY_OF [1]`, `filter 1:3: operator ANY_OF is not supported by index [a] of type Integer`},
+		{`loc = 1`, `filter 1:5: operator EQ is not supported by index [loc] of type GeoPoint`},
+		// int/float coercion
+		{`a = 1.5`, `filter 1:5: index [a] of type Integer can't be compared to '1.5'`},
+		{`a BETWEEN 1 AND 2.5`, `filter 1:17: index [a] of type Integer can't be compared to '2.5'`},
+		{`price = 'x'`, `filter 1:9: index [price] of type Float can't be compared to "x"`},
+		{`name = 1`, `filter 1:8: index [name] of type String can't be compared to '1'`},
+		{`tags ANY_OF ['a', 1]`, `filter 1:19: index [tags] of type StringArray can't be compared to '1'`},
+		// lines and columns count runes
+		{"a = 1 AND\n  nope = 2", `filter 2:3: index [nope] is not defined`},
+		{"a = 1 OR\nname = 'é' AND\n\tb = 'x'", `filter 3:6: index [b] of type Integer can't be compared to "x"`},
+	}
+	for _, tt := range tests {
+		_, err := parseFilter(tt.src, filterTestSchema)
+		if err == nil {
+			t.Errorf("parseFilter(%s): expected an error", tt.src)
+			continue
+		}
+		if got := err.Error(); got != tt.want {
+			t.Errorf("parseFilter(%s)\n got %s\nwant %s", tt.src, got, tt.want)
+		}
+	}
+}
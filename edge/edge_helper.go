@@ -20,12 +20,16 @@ package edge
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/sjy-dv/coltt/gen/protoc/v4/edgepb"
 	"github.com/sjy-dv/coltt/pkg/inverted"
+	"github.com/sjy-dv/coltt/pkg/planner"
 )
 
 func (helper *Edge) LoadAuthorizationBuckets() error {
@@ -94,6 +98,22 @@ func (helper *Edge) saveInvertedIndexHelper(collectionName string, data []byte)
 	return helper.Storage.PutObject(collectionName, fmt.Sprintf("%s.inverted.raw", collectionName), bytes.NewReader(data), int64(len(data)))
 }
 
+func (helper *Edge) saveExpiryHelper(collectionName string) error {
+	data, err := helper.VectorStore.SavedExpiry(collectionName)
+	if err != nil {
+		return err
+	}
+	return helper.Storage.PutObject(collectionName, fmt.Sprintf("%s.ttl", collectionName), bytes.NewReader(data), int64(len(data)))
+}
+
+func (helper *Edge) saveFullTextHelper(collectionName string) error {
+	data, err := helper.VectorStore.SavedFullText(collectionName)
+	if err != nil {
+		return err
+	}
+	return helper.Storage.PutObject(collectionName, fmt.Sprintf("%s.fulltext", collectionName), bytes.NewReader(data), int64(len(data)))
+}
+
 func (helper *Edge) BucketLifeCycleJob(collectionName string) {
 	versioning, err := helper.Storage.IsVersionBucket(collectionName)
 	if err != nil {
@@ -116,6 +136,55 @@ func (helper *Edge) loadInvertedIndexHelper(collectionName string) ([]byte, erro
 	return helper.Storage.GetObject(collectionName, fmt.Sprintf("%s.inverted.raw", collectionName))
 }
 
+// collections created before ttl support have no expiry object
+func (helper *Edge) loadExpiryHelper(collectionName string) ([]byte, error) {
+	exists, err := helper.Storage.ObjectExists(collectionName, fmt.Sprintf("%s.ttl", collectionName))
+	if err != nil || !exists {
+		return nil, err
+	}
+	return helper.Storage.GetObject(collectionName, fmt.Sprintf("%s.ttl", collectionName))
+}
+
+// collections created before fulltext support have no fulltext object
+func (helper *Edge) loadFullTextHelper(collectionName string) ([]byte, error) {
+	exists, err := helper.Storage.ObjectExists(collectionName, fmt.Sprintf("%s.fulltext", collectionName))
+	if err != nil || !exists {
+		return nil, err
+	}
+	return helper.Storage.GetObject(collectionName, fmt.Sprintf("%s.fulltext", collectionName))
+}
+
+func loadedCollectionsHelper() []string {
+	stateManager.Load.Lock.RLock()
+	defer stateManager.Load.Lock.RUnlock()
+	collections := make([]string, 0, len(stateManager.Load.collections))
+	for col, status := range stateManager.Load.collections {
+		if status {
+			collections = append(collections, col)
+		}
+	}
+	return collections
+}
+
+func (helper *Edge) expiryReaper() {
+	ticker := time.NewTicker(expiryReapInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-helper.stopReaper:
+			return
+		case <-ticker.C:
+			for _, col := range loadedCollectionsHelper() {
+				removed, err := helper.VectorStore.ReapExpired(col)
+				if err != nil {
+					log.Error().Msgf("collection: %s reap expired vertex failed: %s", col, err.Error())
+				}
+				helper.publishRemovedHelper(col, removed)
+			}
+		}
+	}
+}
+
 func indexDesignAnalyze(indexDesign []*edgepb.Index) map[string]IndexFeature {
 	features := make(map[string]IndexFeature)
 	for _, column := range indexDesign {
@@ -123,6 +192,9 @@ func indexDesignAnalyze(indexDesign []*edgepb.Index) map[string]IndexFeature {
 			IndexName:  column.IndexName,
 			IndexType:  int32(column.IndexType),
 			EnableNull: column.EnableNull,
+			StoredOnly: column.StoredOnly,
+			FullText:   column.Fulltext,
+			Analyzer:   column.Analyzer,
 		}
 	}
 	return features
@@ -135,11 +207,88 @@ func reverseIndexDesign(features map[string]IndexFeature) []*edgepb.Index {
 			IndexName:  column.IndexName,
 			IndexType:  edgepb.IndexType(column.IndexType),
 			EnableNull: column.EnableNull,
+			StoredOnly: column.StoredOnly,
+			Fulltext:   column.FullText,
+			Analyzer:   column.Analyzer,
 		})
 	}
 	return design
 }
 
+// filterHelper analyzes the filter of a request, given as an expression or as text
+func (helper *Edge) filterHelper(collectionName string, expr *edgepb.FilterExpression, text string) (*inverted.FilterExpression, error) {
+	if strings.TrimSpace(text) != "" {
+		if expr != nil {
+			return nil, errors.New("filter_expression and filter are mutually exclusive")
+		}
+		parsed, err := parseFilter(text, helper.VectorStore.Indexer(collectionName))
+		if err != nil {
+			return nil, err
+		}
+		expr = parsed
+	}
+	return queryExprAnalyzer(expr)
+}
+
+// facetSearchHelper counts the requested facets over the rows matching expr,
+// nil when none are requested
+func (helper *Edge) facetSearchHelper(collectionName string, expr *inverted.FilterExpression, facets []*edgepb.FacetRequest) ([]*edgepb.FacetResult, error) {
+	if len(facets) == 0 {
+		return nil, nil
+	}
+	requests, err := facetAnalyzer(facets, helper.VectorStore.Indexer(collectionName))
+	if err != nil {
+		return nil, err
+	}
+	results, err := helper.VectorStore.Facets(collectionName, expr, requests)
+	if err != nil {
+		return nil, err
+	}
+	return facetHelper(results)
+}
+
+func facetHelper(results []inverted.FacetResult) ([]*edgepb.FacetResult, error) {
+	facets := make([]*edgepb.FacetResult, 0, len(results))
+	for _, result := range results {
+		facet := &edgepb.FacetResult{
+			IndexName: result.IndexName,
+			Counts:    make([]*edgepb.FacetCount, 0, len(result.Counts)),
+		}
+		for _, count := range result.Counts {
+			value := new(edgepb.FilterValue)
+			switch v := count.Value.(type) {
+			case string:
+				value.Value = &edgepb.FilterValue_StringVal{StringVal: v}
+			case int:
+				value.Value = &edgepb.FilterValue_IntVal{IntVal: int64(v)}
+			case int64:
+				value.Value = &edgepb.FilterValue_IntVal{IntVal: v}
+			case float64:
+				value.Value = &edgepb.FilterValue_FloatVal{FloatVal: v}
+			case bool:
+				value.Value = &edgepb.FilterValue_BoolVal{BoolVal: v}
+			default:
+				return nil, fmt.Errorf("facet: index [%s] unsupported value type %T", result.IndexName, v)
+			}
+			facet.Counts = append(facet.Counts, &edgepb.FacetCount{Value: value, Count: count.Count})
+		}
+		if result.Stats != nil {
+			facet.Stats = &edgepb.NumericStats{
+				Count: result.Stats.Count,
+				Min:   result.Stats.Min,
+				Max:   result.Stats.Max,
+				Sum:   result.Stats.Sum,
+				Avg:   result.Stats.Avg,
+			}
+		}
+		for _, bucket := range result.Histogram {
+			facet.Histogram = append(facet.Histogram, &edgepb.HistogramBucket{From: bucket.From, Count: bucket.Count})
+		}
+		facets = append(facets, facet)
+	}
+	return facets, nil
+}
+
 func scoreHelper(score float32, dist string) float32 {
 	if dist == T_COSINE {
 		return ((2 - score) / 2) * 100
@@ -161,14 +310,43 @@ func convertProtoOp(op edgepb.Op) inverted.FilterOp {
 		return inverted.OpLessThan
 	case edgepb.Op_LTE:
 		return inverted.OpLessThanEqual
+	case edgepb.Op_IN:
+		return inverted.OpIn
+	case edgepb.Op_NOT_IN:
+		return inverted.OpNotIn
+	case edgepb.Op_BETWEEN:
+		return inverted.OpBetween
+	case edgepb.Op_IS_NULL:
+		return inverted.OpIsNull
+	case edgepb.Op_IS_NOT_NULL:
+		return inverted.OpIsNotNull
+	case edgepb.Op_PREFIX:
+		return inverted.OpPrefix
+	case edgepb.Op_CONTAINS:
+		return inverted.OpContains
+	case edgepb.Op_REGEX:
+		return inverted.OpRegex
+	case edgepb.Op_ANY_OF:
+		return inverted.OpAnyOf
+	case edgepb.Op_ALL_OF:
+		return inverted.OpAllOf
+	case edgepb.Op_NONE_OF:
+		return inverted.OpNoneOf
+	case edgepb.Op_GEO_RADIUS:
+		return inverted.OpGeoRadius
+	case edgepb.Op_GEO_BBOX:
+		return inverted.OpGeoBBox
 	default:
 		return inverted.OpEqual
 	}
 }
 
 func convertProtoLogicalOperator(op edgepb.LogicalOperator) inverted.LogicalOp {
-	if op == edgepb.LogicalOperator_OR {
+	switch op {
+	case edgepb.LogicalOperator_OR:
 		return inverted.LogicalOr
+	case edgepb.LogicalOperator_NOT:
+		return inverted.LogicalNot
 	}
 	return inverted.LogicalAnd
 }
@@ -180,3 +358,15 @@ func convertBytesMetadata(data []byte) (edgepb.Quantization, error) {
 	}
 	return edgepb.Quantization(metadata.Quantization), nil
 }
+
+// explainHelper reports the plan of a filtered search, edge spaces scan their rows
+// so no candidates are fetched from a graph
+func explainHelper(plan planner.Plan) *edgepb.QueryPlan {
+	return &edgepb.QueryPlan{
+		Strategy:    plan.Strategy.String(),
+		Filtered:    plan.Filtered,
+		Total:       plan.Total,
+		Selectivity: plan.Selectivity,
+		Detail:      plan.String(),
+	}
+}
@@ -0,0 +1,229 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package edge
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/sjy-dv/coltt/gen/protoc/v4/edgepb"
+	"github.com/sjy-dv/coltt/pkg/fulltext"
+	"github.com/sjy-dv/coltt/pkg/inverted"
+)
+
+const (
+	// hybrid search ranks this many times topK candidates of each side
+	// before fusing them
+	hybridCandidateFactor = 4
+	defaultRRFK           = 60
+)
+
+// TextQuery is the keyword part of TEXT and HYBRID searches
+type TextQuery struct {
+	Query        string
+	Fields       []string
+	Fusion       edgepb.FusionMethod
+	VectorWeight float32
+	RRFK         uint32
+}
+
+// newFullText creates the fulltext index of the String fields flagged fulltext
+func newFullText(features map[string]IndexFeature) (*fulltext.Index, error) {
+	fields := make(map[string]string)
+	for _, feature := range features {
+		if !feature.FullText {
+			continue
+		}
+		if edgepb.IndexType(feature.IndexType) != edgepb.IndexType_String {
+			return nil, fmt.Errorf("index: [%s] fulltext requires a String index", feature.IndexName)
+		}
+		fields[feature.IndexName] = feature.Analyzer
+	}
+	return fulltext.New(fields)
+}
+
+// fullTextFields picks the texts of the fulltext fields out of the metadata
+func fullTextFields(metadata map[string]interface{}, features map[string]IndexFeature) map[string]string {
+	texts := make(map[string]string)
+	for _, feature := range features {
+		if !feature.FullText {
+			continue
+		}
+		parent, key := metadataField(metadata, feature.IndexName)
+		if text, ok := parent[key].(string); ok {
+			texts[feature.IndexName] = text
+		}
+	}
+	return texts
+}
+
+// textHits runs the BM25 query over the live rows matching the filter
+func (vs *Vectorstore) textHits(collectionName string, filter *inverted.FilterExpression, topK int, query TextQuery) ([]fulltext.Hit, error) {
+	text := vs.fullText(collectionName)
+	if text == nil || text.Fields() == 0 {
+		return nil, fmt.Errorf("collection: %s has no fulltext index", collectionName)
+	}
+	if query.Query == "" {
+		return nil, errors.New("text query is empty")
+	}
+	live := vs.live(collectionName)
+	var matched map[uint64]struct{}
+	if filter != nil {
+		ids, err := vs.Space[collectionName].FilterIds(filter)
+		if err != nil {
+			return nil, err
+		}
+		matched = make(map[uint64]struct{}, len(ids))
+		for _, id := range ids {
+			matched[id] = struct{}{}
+		}
+	}
+	allow := func(id uint64) bool {
+		if live != nil && !live(id) {
+			return false
+		}
+		if matched != nil {
+			_, ok := matched[id]
+			return ok
+		}
+		return true
+	}
+	return text.Search(query.Query, query.Fields, topK, allow)
+}
+
+// TextSearch ranks the rows by the BM25 score of the query
+func (vs *Vectorstore) TextSearch(collectionName string, filter *inverted.FilterExpression, topK uint64, query TextQuery) ([]*SearchResultItem, error) {
+	hits, err := vs.textHits(collectionName, filter, int(topK), query)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*SearchResultItem, 0, len(hits))
+	for _, hit := range hits {
+		metadata, ok := vs.Space[collectionName].VertexMetadata(hit.Id)
+		if !ok {
+			continue
+		}
+		items = append(items, &SearchResultItem{Id: hit.Id, Metadata: metadata, Score: float32(hit.Score)})
+	}
+	return items, nil
+}
+
+// HybridSearch fuses the vector ranking and the BM25 ranking of the query,
+// by reciprocal rank fusion or by a weighted sum of min-max normalized scores
+func (vs *Vectorstore) HybridSearch(collectionName string, filter *inverted.FilterExpression, topK uint64, vector Vector, highCpu bool, query TextQuery) ([]*SearchResultItem, error) {
+	candidates := topK * hybridCandidateFactor
+	hits, err := vs.textHits(collectionName, filter, int(candidates), query)
+	if err != nil {
+		return nil, err
+	}
+	var recalls []*SearchResultItem
+	if filter == nil {
+		recalls, err = vs.VertexSearch(collectionName, candidates, vector, highCpu)
+	} else {
+		recalls, _, err = vs.FilterableVertexSearch(collectionName, filter, candidates, vector, highCpu)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make(map[uint64]map[string]any, len(recalls))
+	scores := make(map[uint64]float64, len(recalls)+len(hits))
+	switch query.Fusion {
+	case edgepb.FusionMethod_RRF:
+		k := float64(query.RRFK)
+		if k == 0 {
+			k = defaultRRFK
+		}
+		for rank, item := range recalls {
+			metadata[item.Id] = item.Metadata
+			scores[item.Id] += 1 / (k + float64(rank+1))
+		}
+		for rank, hit := range hits {
+			scores[hit.Id] += 1 / (k + float64(rank+1))
+		}
+	case edgepb.FusionMethod_WEIGHTED:
+		if query.VectorWeight < 0 || query.VectorWeight > 1 {
+			return nil, fmt.Errorf("vector weight %v is out of [0, 1]", query.VectorWeight)
+		}
+		w := float64(query.VectorWeight)
+		dist := EUCLIDEAN
+		if vs.Distance(collectionName) == edgepb.Distance_Cosine {
+			dist = T_COSINE
+		}
+		similarities := make([]float64, len(recalls))
+		for i, item := range recalls {
+			similarities[i] = float64(scoreHelper(item.Score, dist))
+		}
+		for i, score := range minMax(similarities) {
+			metadata[recalls[i].Id] = recalls[i].Metadata
+			scores[recalls[i].Id] += w * score
+		}
+		bm25 := make([]float64, len(hits))
+		for i, hit := range hits {
+			bm25[i] = hit.Score
+		}
+		for i, score := range minMax(bm25) {
+			scores[hits[i].Id] += (1 - w) * score
+		}
+	default:
+		return nil, fmt.Errorf("unsupported fusion method %v", query.Fusion)
+	}
+
+	items := make([]*SearchResultItem, 0, len(scores))
+	for id, score := range scores {
+		meta, ok := metadata[id]
+		if !ok {
+			if meta, ok = vs.Space[collectionName].VertexMetadata(id); !ok {
+				continue
+			}
+		}
+		items = append(items, &SearchResultItem{Id: id, Metadata: meta, Score: float32(score)})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return items[i].Id < items[j].Id
+	})
+	if uint64(len(items)) > topK {
+		items = items[:topK]
+	}
+	return items, nil
+}
+
+// minMax scales the scores to [0, 1], equal scores all become 1
+func minMax(scores []float64) []float64 {
+	if len(scores) == 0 {
+		return scores
+	}
+	lo, hi := scores[0], scores[0]
+	for _, s := range scores {
+		lo = min(lo, s)
+		hi = max(hi, s)
+	}
+	out := make([]float64, len(scores))
+	for i, s := range scores {
+		if hi == lo {
+			out[i] = 1
+			continue
+		}
+		out[i] = (s - lo) / (hi - lo)
+	}
+	return out
+}
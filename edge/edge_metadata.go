@@ -17,7 +17,11 @@
 
 package edge
 
-import "github.com/sjy-dv/coltt/gen/protoc/v4/edgepb"
+import (
+	"time"
+
+	"github.com/sjy-dv/coltt/gen/protoc/v4/edgepb"
+)
 
 type Metadata struct {
 	Dim          uint32                  `json:"dim"`
@@ -25,6 +29,7 @@ type Metadata struct {
 	Quantization int32                   `json:"quantization"`
 	IndexType    map[string]IndexFeature `json:"index_type"`
 	Versioning   bool                    `json:"versioning"`
+	DefaultTTL   uint64                  `json:"default_ttl_seconds"`
 }
 
 type IndexFeature struct {
@@ -32,6 +37,9 @@ type IndexFeature struct {
 	IndexType  int32  `json:"index_type"`
 	EnableNull bool   `json:"enable_null"`
 	PrimaryKey bool   `json:"primary_key"`
+	StoredOnly bool   `json:"stored_only"`
+	FullText   bool   `json:"fulltext"`
+	Analyzer   string `json:"analyzer"`
 }
 
 func (metadata *Metadata) Dimensional() uint32 {
@@ -53,3 +61,7 @@ func (metadata *Metadata) IndexFeatures(index string) IndexFeature {
 func (metadata *Metadata) Versional() bool {
 	return metadata.Versioning
 }
+
+func (metadata *Metadata) DefaultTTLer() time.Duration {
+	return time.Duration(metadata.DefaultTTL) * time.Second
+}
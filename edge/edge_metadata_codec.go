@@ -0,0 +1,228 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package edge
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// metadata value tags of SaveVertex/LoadVertex, objects and arrays nest
+const (
+	metaTagInt64 byte = iota
+	metaTagString
+	metaTagFloat64
+	metaTagBool
+	metaTagArray
+	metaTagObject
+	metaTagNull
+	metaTagLongString // strings over 64KiB, such as stored-only text
+)
+
+func writeMetadataValue(buf *bytes.Buffer, metaVal interface{}) error {
+	switch v := metaVal.(type) {
+	case int64:
+		if err := buf.WriteByte(metaTagInt64); err != nil {
+			return err
+		}
+		return binary.Write(buf, binary.BigEndian, v)
+	case string:
+		strBytes := []byte(v)
+		if len(strBytes) > 65535 {
+			if len(strBytes) > math.MaxUint32 {
+				return fmt.Errorf("metadata string too long: %d bytes", len(strBytes))
+			}
+			if err := buf.WriteByte(metaTagLongString); err != nil {
+				return err
+			}
+			if err := binary.Write(buf, binary.BigEndian, uint32(len(strBytes))); err != nil {
+				return err
+			}
+			_, err := buf.Write(strBytes)
+			return err
+		}
+		if err := buf.WriteByte(metaTagString); err != nil {
+			return err
+		}
+		if err := binary.Write(buf, binary.BigEndian, uint16(len(strBytes))); err != nil {
+			return err
+		}
+		_, err := buf.Write(strBytes)
+		return err
+	case float32:
+		if err := buf.WriteByte(metaTagFloat64); err != nil {
+			return err
+		}
+		return binary.Write(buf, binary.BigEndian, float64(v))
+	case float64:
+		if err := buf.WriteByte(metaTagFloat64); err != nil {
+			return err
+		}
+		return binary.Write(buf, binary.BigEndian, v)
+	case bool:
+		if err := buf.WriteByte(metaTagBool); err != nil {
+			return err
+		}
+		var b byte = 0
+		if v {
+			b = 1
+		}
+		return buf.WriteByte(b)
+	case []interface{}:
+		if err := buf.WriteByte(metaTagArray); err != nil {
+			return err
+		}
+		if len(v) > math.MaxUint32 {
+			return fmt.Errorf("metadata array too long: %d", len(v))
+		}
+		if err := binary.Write(buf, binary.BigEndian, uint32(len(v))); err != nil {
+			return err
+		}
+		for _, elem := range v {
+			if err := writeMetadataValue(buf, elem); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]interface{}:
+		if err := buf.WriteByte(metaTagObject); err != nil {
+			return err
+		}
+		if len(v) > math.MaxUint32 {
+			return fmt.Errorf("metadata object too large: %d", len(v))
+		}
+		if err := binary.Write(buf, binary.BigEndian, uint32(len(v))); err != nil {
+			return err
+		}
+		for key, elem := range v {
+			keyBytes := []byte(key)
+			if len(keyBytes) > 65535 {
+				return fmt.Errorf("metadata key too long: %s", key)
+			}
+			if err := binary.Write(buf, binary.BigEndian, uint16(len(keyBytes))); err != nil {
+				return err
+			}
+			if _, err := buf.Write(keyBytes); err != nil {
+				return err
+			}
+			if err := writeMetadataValue(buf, elem); err != nil {
+				return err
+			}
+		}
+		return nil
+	case nil:
+		return buf.WriteByte(metaTagNull)
+	default:
+		return fmt.Errorf("unsupported metadata type: %T", v)
+	}
+}
+
+func readMetadataValue(buf *bytes.Reader) (interface{}, error) {
+	typ, err := buf.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	switch typ {
+	case metaTagInt64:
+		var val int64
+		if err := binary.Read(buf, binary.BigEndian, &val); err != nil {
+			return nil, err
+		}
+		return val, nil
+	case metaTagString:
+		var strLen uint16
+		if err := binary.Read(buf, binary.BigEndian, &strLen); err != nil {
+			return nil, err
+		}
+		strBytes := make([]byte, strLen)
+		if _, err := io.ReadFull(buf, strBytes); err != nil {
+			return nil, err
+		}
+		return string(strBytes), nil
+	case metaTagFloat64:
+		var val float64
+		if err := binary.Read(buf, binary.BigEndian, &val); err != nil {
+			return nil, err
+		}
+		return val, nil
+	case metaTagBool:
+		boolByte, err := buf.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		return boolByte != 0, nil
+	case metaTagArray:
+		var count uint32
+		if err := binary.Read(buf, binary.BigEndian, &count); err != nil {
+			return nil, err
+		}
+		if int64(count) > int64(buf.Len()) {
+			return nil, fmt.Errorf("metadata array length %d exceeds the data", count)
+		}
+		arr := make([]interface{}, count)
+		for i := range arr {
+			if arr[i], err = readMetadataValue(buf); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	case metaTagObject:
+		var count uint32
+		if err := binary.Read(buf, binary.BigEndian, &count); err != nil {
+			return nil, err
+		}
+		if int64(count) > int64(buf.Len()) {
+			return nil, fmt.Errorf("metadata object size %d exceeds the data", count)
+		}
+		obj := make(map[string]interface{}, count)
+		for i := uint32(0); i < count; i++ {
+			var keyLen uint16
+			if err := binary.Read(buf, binary.BigEndian, &keyLen); err != nil {
+				return nil, err
+			}
+			keyBytes := make([]byte, keyLen)
+			if _, err := io.ReadFull(buf, keyBytes); err != nil {
+				return nil, err
+			}
+			if obj[string(keyBytes)], err = readMetadataValue(buf); err != nil {
+				return nil, err
+			}
+		}
+		return obj, nil
+	case metaTagNull:
+		return nil, nil
+	case metaTagLongString:
+		var strLen uint32
+		if err := binary.Read(buf, binary.BigEndian, &strLen); err != nil {
+			return nil, err
+		}
+		if int64(strLen) > int64(buf.Len()) {
+			return nil, fmt.Errorf("metadata string length %d exceeds the data", strLen)
+		}
+		strBytes := make([]byte, strLen)
+		if _, err := io.ReadFull(buf, strBytes); err != nil {
+			return nil, err
+		}
+		return string(strBytes), nil
+	default:
+		return nil, fmt.Errorf("unsupported metadata type tag: %d", typ)
+	}
+}
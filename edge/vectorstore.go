@@ -18,22 +18,32 @@
 package edge
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"sync"
+	"time"
 
 	"github.com/sjy-dv/coltt/gen/protoc/v4/edgepb"
+	"github.com/sjy-dv/coltt/pkg/expiry"
+	"github.com/sjy-dv/coltt/pkg/fulltext"
 	"github.com/sjy-dv/coltt/pkg/inverted"
+	"github.com/sjy-dv/coltt/pkg/planner"
 )
 
 type vectorspace interface {
-	ChangedVertex(updateID string, Id uint64, edge ENode) error
-	RemoveVertex(dropFilter map[string]interface{}) error
-	VertexSearch(target Vector, topK int, highCpu bool) (
-		[]*SearchResultItem, error)
-	FilterableVertexSearch(filter *inverted.FilterExpression, target Vector, topK int, highCpu bool) (
+	ChangedVertex(updateID string, Id uint64, edge ENode) (uint64, error)
+	RemoveVertex(dropFilter map[string]interface{}) ([]uint64, []map[string]interface{}, error)
+	RemoveVertexIds(ids []uint64) ([]map[string]interface{}, error)
+	// allow hides the rows it rejects from the searches, nil allows every row
+	VertexSearch(target Vector, topK int, highCpu bool, allow func(id uint64) bool) (
 		[]*SearchResultItem, error)
+	FilterableVertexSearch(filter *inverted.FilterExpression, target Vector, topK int, highCpu bool, allow func(id uint64) bool) (
+		[]*SearchResultItem, planner.Plan, error)
+	FilterIds(filter *inverted.FilterExpression) ([]uint64, error)
+	Facets(filter *inverted.FilterExpression, exclude []uint64, requests []inverted.FacetRequest) ([]inverted.FacetResult, error)
+	VertexMetadata(id uint64) (map[string]interface{}, bool)
 	SaveVertexMetadata() ([]byte, error)
 	LoadVertexMetadata(collectionName string, data []byte) error
 	SaveVertexInverted() ([]byte, error)
@@ -49,13 +59,19 @@ type vectorspace interface {
 }
 
 type Vectorstore struct {
-	Space map[string]vectorspace
-	slock sync.RWMutex
+	Space    map[string]vectorspace
+	slock    sync.RWMutex
+	Expiries map[string]*expiry.Table
+	elock    sync.RWMutex
+	Texts    map[string]*fulltext.Index
+	tlock    sync.RWMutex
 }
 
 func NewVectorstore() *Vectorstore {
 	return &Vectorstore{
-		Space: make(map[string]vectorspace),
+		Space:    make(map[string]vectorspace),
+		Expiries: make(map[string]*expiry.Table),
+		Texts:    make(map[string]*fulltext.Index),
 	}
 }
 
@@ -78,12 +94,46 @@ func (vs *Vectorstore) CreateCollection(collectionName string, metadata Metadata
 	} else {
 		return errors.New("not support quantization type")
 	}
+	text, err := newFullText(metadata.IndexType)
+	if err != nil {
+		return err
+	}
 	vs.slock.Lock()
 	vs.Space[collectionName] = vectorstore
 	vs.slock.Unlock()
+	vs.setExpiry(collectionName, expiry.NewTable(metadata.DefaultTTLer()))
+	vs.setFullText(collectionName, text)
 	return nil
 }
 
+func (vs *Vectorstore) setExpiry(collectionName string, table *expiry.Table) {
+	vs.elock.Lock()
+	vs.Expiries[collectionName] = table
+	vs.elock.Unlock()
+}
+
+func (vs *Vectorstore) expiry(collectionName string) *expiry.Table {
+	vs.elock.RLock()
+	defer vs.elock.RUnlock()
+	return vs.Expiries[collectionName]
+}
+
+func (vs *Vectorstore) setFullText(collectionName string, text *fulltext.Index) {
+	vs.tlock.Lock()
+	vs.Texts[collectionName] = text
+	vs.tlock.Unlock()
+}
+
+func (vs *Vectorstore) fullText(collectionName string) *fulltext.Index {
+	vs.tlock.RLock()
+	defer vs.tlock.RUnlock()
+	return vs.Texts[collectionName]
+}
+
+func (vs *Vectorstore) DefaultTTL(collectionName string) time.Duration {
+	return vs.expiry(collectionName).DefaultTTL()
+}
+
 func (vs *Vectorstore) Quantization(collectionName string) edgepb.Quantization {
 	return vs.Space[collectionName].Quantization()
 }
@@ -116,8 +166,46 @@ func (vs *Vectorstore) SavedInverted(collectionName string) ([]byte, error) {
 	return vs.Space[collectionName].SaveVertexInverted()
 }
 
+func (vs *Vectorstore) SavedExpiry(collectionName string) ([]byte, error) {
+	return vs.expiry(collectionName).MarshalBinary()
+}
+
+func (vs *Vectorstore) SavedFullText(collectionName string) ([]byte, error) {
+	return vs.fullText(collectionName).MarshalBinary()
+}
+
 func (vs *Vectorstore) LoadedMetadata(collectionName string, data []byte) error {
-	return vs.Space[collectionName].LoadVertexMetadata(collectionName, data)
+	if err := vs.Space[collectionName].LoadVertexMetadata(collectionName, data); err != nil {
+		return err
+	}
+	var metadata Metadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return err
+	}
+	text, err := newFullText(metadata.IndexType)
+	if err != nil {
+		return err
+	}
+	vs.setExpiry(collectionName, expiry.NewTable(metadata.DefaultTTLer()))
+	vs.setFullText(collectionName, text)
+	return nil
+}
+
+// LoadedExpiry restores the expiry table, collections saved before ttl support have none
+func (vs *Vectorstore) LoadedExpiry(collectionName string, data []byte) error {
+	if data == nil {
+		return nil
+	}
+	return vs.expiry(collectionName).UnmarshalBinary(data)
+}
+
+// LoadedFullText restores the fulltext postings, collections saved before
+// fulltext support have none
+func (vs *Vectorstore) LoadedFullText(collectionName string, data []byte) error {
+	if data == nil {
+		return nil
+	}
+	return vs.fullText(collectionName).UnmarshalBinary(data)
 }
 
 func (vs *Vectorstore) LoadedVertex(collectionName string, data []byte) error {
@@ -132,26 +220,146 @@ func (vs *Vectorstore) DestroySpace(collectionName string) {
 	vs.slock.Lock()
 	delete(vs.Space, collectionName)
 	vs.slock.Unlock()
+	vs.elock.Lock()
+	delete(vs.Expiries, collectionName)
+	vs.elock.Unlock()
+	vs.tlock.Lock()
+	delete(vs.Texts, collectionName)
+	vs.tlock.Unlock()
 }
 
-func (vs *Vectorstore) ChangedVertex(collectioName string, updateID string, Id uint64, metadata map[string]interface{}, vector Vector) error {
+// ChangedVertex inserts the vertex, or overwrites the one with the same
+// primary key and reports true
+func (vs *Vectorstore) ChangedVertex(collectioName string, updateID string, Id uint64, metadata map[string]interface{}, vector Vector, ttl time.Duration) (bool, error) {
 	newVertex := ENode{
 		Vector:   vector,
 		Metadata: metadata,
 	}
-	return vs.Space[collectioName].ChangedVertex(updateID, Id, newVertex)
+	id, err := vs.Space[collectioName].ChangedVertex(updateID, Id, newVertex)
+	if err != nil {
+		return false, err
+	}
+	table := vs.expiry(collectioName)
+	table.Set(id, table.ExpireAt(ttl))
+	if text := vs.fullText(collectioName); text != nil {
+		text.Add(id, fullTextFields(metadata, vs.Space[collectioName].Indexer()))
+	}
+	return id != Id, nil
 }
 
-func (vs *Vectorstore) RemoveVertex(collectionName string, dropfilter map[string]interface{}) error {
-	return vs.Space[collectionName].RemoveVertex(dropfilter)
+func (vs *Vectorstore) RemoveVertex(collectionName string, dropfilter map[string]interface{}) ([]map[string]interface{}, error) {
+	ids, removed, err := vs.Space[collectionName].RemoveVertex(dropfilter)
+	if text := vs.fullText(collectionName); text != nil {
+		text.Remove(ids...)
+	}
+	return removed, err
+}
+
+// PrimaryKey returns the primary key value of the vertex metadata
+func (vs *Vectorstore) PrimaryKey(collectionName string, metadata map[string]interface{}) string {
+	vs.slock.RLock()
+	space, ok := vs.Space[collectionName]
+	vs.slock.RUnlock()
+	if !ok {
+		return ""
+	}
+	for _, indexer := range space.Indexer() {
+		if indexer.PrimaryKey {
+			if value, ok := metadata[indexer.IndexName]; ok {
+				return fmt.Sprint(value)
+			}
+		}
+	}
+	return ""
 }
 
 func (vs *Vectorstore) VertexSearch(collectioName string, topK uint64, vector Vector, highCpu bool) ([]*SearchResultItem, error) {
-	return vs.Space[collectioName].VertexSearch(vector, int(topK), highCpu)
+	return vs.Space[collectioName].VertexSearch(vector, int(topK), highCpu, vs.live(collectioName))
 }
 
-func (vs *Vectorstore) FilterableVertexSearch(collectioName string, filter *inverted.FilterExpression, topK uint64, vector Vector, highCpu bool) ([]*SearchResultItem, error) {
-	return vs.Space[collectioName].FilterableVertexSearch(filter, vector, int(topK), highCpu)
+func (vs *Vectorstore) FilterableVertexSearch(collectioName string, filter *inverted.FilterExpression, topK uint64, vector Vector, highCpu bool) ([]*SearchResultItem, planner.Plan, error) {
+	return vs.Space[collectioName].FilterableVertexSearch(filter, vector, int(topK), highCpu, vs.live(collectioName))
+}
+
+// live returns the filter hiding the expired rows, which stay in the space
+// until they are reaped. It is nil when the collection has no row with a ttl.
+func (vs *Vectorstore) live(collectionName string) func(id uint64) bool {
+	table := vs.expiry(collectionName)
+	if table == nil || table.Len() == 0 {
+		return nil
+	}
+	now := time.Now().UnixNano()
+	return func(id uint64) bool {
+		return !table.IsExpired(id, now)
+	}
+}
+
+// Facets counts the index values of the live rows matching the filter
+func (vs *Vectorstore) Facets(collectionName string, filter *inverted.FilterExpression, requests []inverted.FacetRequest) ([]inverted.FacetResult, error) {
+	expired := vs.expiry(collectionName).Expired(time.Now().UnixNano())
+	exclude := make([]uint64, 0, len(expired))
+	for id := range expired {
+		exclude = append(exclude, id)
+	}
+	return vs.Space[collectionName].Facets(filter, exclude, requests)
+}
+
+// Query returns the live rows matching the filter by ascending id, every row
+// when filter is nil
+func (vs *Vectorstore) Query(collectionName string, filter *inverted.FilterExpression, offset, limit uint64) ([]*SearchResultItem, error) {
+	ids, err := vs.Space[collectionName].FilterIds(filter)
+	if err != nil {
+		return nil, err
+	}
+	live := vs.live(collectionName)
+	items := make([]*SearchResultItem, 0, min(uint64(len(ids)), limit))
+	for _, id := range ids {
+		if uint64(len(items)) == limit {
+			break
+		}
+		if live != nil && !live(id) {
+			continue
+		}
+		metadata, ok := vs.Space[collectionName].VertexMetadata(id)
+		if !ok {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		items = append(items, &SearchResultItem{Id: id, Metadata: metadata})
+	}
+	return items, nil
+}
+
+// ReapExpired removes the expired rows of a collection from its vertices and
+// inverted index and returns their metadata
+func (vs *Vectorstore) ReapExpired(collectionName string) ([]map[string]interface{}, error) {
+	vs.slock.RLock()
+	space, ok := vs.Space[collectionName]
+	vs.slock.RUnlock()
+	table := vs.expiry(collectionName)
+	if !ok || table == nil {
+		return nil, nil
+	}
+	expired := table.Expired(time.Now().UnixNano())
+	if len(expired) == 0 {
+		return nil, nil
+	}
+	ids := make([]uint64, 0, len(expired))
+	for id := range expired {
+		ids = append(ids, id)
+	}
+	removed, err := space.RemoveVertexIds(ids)
+	if err != nil {
+		return removed, err
+	}
+	table.Del(ids...)
+	if text := vs.fullText(collectionName); text != nil {
+		text.Remove(ids...)
+	}
+	return removed, nil
 }
 
 func (vs *Vectorstore) FillEmpty(collectionName string, quantization edgepb.Quantization) {
@@ -39,6 +39,52 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type RebuildSource int32
+
+const (
+	RebuildSource_GRAPH      RebuildSource = 0 // vectors of the current graph, decoded when quantized
+	RebuildSource_COMMIT_LOG RebuildSource = 1 // raw vectors of the commit log
+)
+
+// Enum value maps for RebuildSource.
+var (
+	RebuildSource_name = map[int32]string{
+		0: "GRAPH",
+		1: "COMMIT_LOG",
+	}
+	RebuildSource_value = map[string]int32{
+		"GRAPH":      0,
+		"COMMIT_LOG": 1,
+	}
+)
+
+func (x RebuildSource) Enum() *RebuildSource {
+	p := new(RebuildSource)
+	*p = x
+	return p
+}
+
+func (x RebuildSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RebuildSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_proto_v3_core_proto_enumTypes[0].Descriptor()
+}
+
+func (RebuildSource) Type() protoreflect.EnumType {
+	return &file_idl_proto_v3_core_proto_enumTypes[0]
+}
+
+func (x RebuildSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RebuildSource.Descriptor instead.
+func (RebuildSource) EnumDescriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{0}
+}
+
 type SearchAlgorithm int32
 
 const (
@@ -69,11 +115,11 @@ func (x SearchAlgorithm) String() string {
 }
 
 func (SearchAlgorithm) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v3_core_proto_enumTypes[0].Descriptor()
+	return file_idl_proto_v3_core_proto_enumTypes[1].Descriptor()
 }
 
 func (SearchAlgorithm) Type() protoreflect.EnumType {
-	return &file_idl_proto_v3_core_proto_enumTypes[0]
+	return &file_idl_proto_v3_core_proto_enumTypes[1]
 }
 
 func (x SearchAlgorithm) Number() protoreflect.EnumNumber {
@@ -82,7 +128,59 @@ func (x SearchAlgorithm) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SearchAlgorithm.Descriptor instead.
 func (SearchAlgorithm) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{0}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{1}
+}
+
+type SearchProfile int32
+
+const (
+	SearchProfile_DEFAULT     SearchProfile = 0
+	SearchProfile_HIGH_RECALL SearchProfile = 1
+	SearchProfile_BALANCED    SearchProfile = 2
+	SearchProfile_LOW_LATENCY SearchProfile = 3
+)
+
+// Enum value maps for SearchProfile.
+var (
+	SearchProfile_name = map[int32]string{
+		0: "DEFAULT",
+		1: "HIGH_RECALL",
+		2: "BALANCED",
+		3: "LOW_LATENCY",
+	}
+	SearchProfile_value = map[string]int32{
+		"DEFAULT":     0,
+		"HIGH_RECALL": 1,
+		"BALANCED":    2,
+		"LOW_LATENCY": 3,
+	}
+)
+
+func (x SearchProfile) Enum() *SearchProfile {
+	p := new(SearchProfile)
+	*p = x
+	return p
+}
+
+func (x SearchProfile) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchProfile) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_proto_v3_core_proto_enumTypes[2].Descriptor()
+}
+
+func (SearchProfile) Type() protoreflect.EnumType {
+	return &file_idl_proto_v3_core_proto_enumTypes[2]
+}
+
+func (x SearchProfile) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchProfile.Descriptor instead.
+func (SearchProfile) EnumDescriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{2}
 }
 
 type Distance int32
@@ -115,11 +213,11 @@ func (x Distance) String() string {
 }
 
 func (Distance) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v3_core_proto_enumTypes[1].Descriptor()
+	return file_idl_proto_v3_core_proto_enumTypes[3].Descriptor()
 }
 
 func (Distance) Type() protoreflect.EnumType {
-	return &file_idl_proto_v3_core_proto_enumTypes[1]
+	return &file_idl_proto_v3_core_proto_enumTypes[3]
 }
 
 func (x Distance) Number() protoreflect.EnumNumber {
@@ -128,7 +226,7 @@ func (x Distance) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Distance.Descriptor instead.
 func (Distance) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{1}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{3}
 }
 
 type Quantization int32
@@ -173,11 +271,11 @@ func (x Quantization) String() string {
 }
 
 func (Quantization) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v3_core_proto_enumTypes[2].Descriptor()
+	return file_idl_proto_v3_core_proto_enumTypes[4].Descriptor()
 }
 
 func (Quantization) Type() protoreflect.EnumType {
-	return &file_idl_proto_v3_core_proto_enumTypes[2]
+	return &file_idl_proto_v3_core_proto_enumTypes[4]
 }
 
 func (x Quantization) Number() protoreflect.EnumNumber {
@@ -186,7 +284,7 @@ func (x Quantization) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Quantization.Descriptor instead.
 func (Quantization) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{2}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{4}
 }
 
 type ErrorCode int32
@@ -231,11 +329,11 @@ func (x ErrorCode) String() string {
 }
 
 func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v3_core_proto_enumTypes[3].Descriptor()
+	return file_idl_proto_v3_core_proto_enumTypes[5].Descriptor()
 }
 
 func (ErrorCode) Type() protoreflect.EnumType {
-	return &file_idl_proto_v3_core_proto_enumTypes[3]
+	return &file_idl_proto_v3_core_proto_enumTypes[5]
 }
 
 func (x ErrorCode) Number() protoreflect.EnumNumber {
@@ -244,7 +342,7 @@ func (x ErrorCode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ErrorCode.Descriptor instead.
 func (ErrorCode) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{3}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{5}
 }
 
 type IndexChangeTypes int32
@@ -280,11 +378,11 @@ func (x IndexChangeTypes) String() string {
 }
 
 func (IndexChangeTypes) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v3_core_proto_enumTypes[4].Descriptor()
+	return file_idl_proto_v3_core_proto_enumTypes[6].Descriptor()
 }
 
 func (IndexChangeTypes) Type() protoreflect.EnumType {
-	return &file_idl_proto_v3_core_proto_enumTypes[4]
+	return &file_idl_proto_v3_core_proto_enumTypes[6]
 }
 
 func (x IndexChangeTypes) Number() protoreflect.EnumNumber {
@@ -293,7 +391,7 @@ func (x IndexChangeTypes) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use IndexChangeTypes.Descriptor instead.
 func (IndexChangeTypes) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{4}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{6}
 }
 
 type CompXyDist struct {
@@ -407,11 +505,13 @@ type DatasetChange struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id               string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CollectionName   string           `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	Vector           []float32        `protobuf:"fixed32,3,rep,packed,name=vector,proto3" json:"vector,omitempty"`
-	Metadata         *structpb.Struct `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	IndexChangeTypes IndexChangeTypes `protobuf:"varint,5,opt,name=index_change_types,json=indexChangeTypes,proto3,enum=coreproto.IndexChangeTypes" json:"index_change_types,omitempty"`
+	Id               string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CollectionName   string                  `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Vector           []float32               `protobuf:"fixed32,3,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+	Metadata         *structpb.Struct        `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	IndexChangeTypes IndexChangeTypes        `protobuf:"varint,5,opt,name=index_change_types,json=indexChangeTypes,proto3,enum=coreproto.IndexChangeTypes" json:"index_change_types,omitempty"`
+	Vectors          map[string]*NamedVector `protobuf:"bytes,6,rep,name=vectors,proto3" json:"vectors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // named vector fields
+	TtlSeconds       uint64                  `protobuf:"varint,7,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`                                                                // 0 => collection default_ttl_seconds
 }
 
 func (x *DatasetChange) Reset() {
@@ -479,6 +579,65 @@ func (x *DatasetChange) GetIndexChangeTypes() IndexChangeTypes {
 	return IndexChangeTypes_INSERT
 }
 
+func (x *DatasetChange) GetVectors() map[string]*NamedVector {
+	if x != nil {
+		return x.Vectors
+	}
+	return nil
+}
+
+func (x *DatasetChange) GetTtlSeconds() uint64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type NamedVector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vector []float32 `protobuf:"fixed32,1,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+}
+
+func (x *NamedVector) Reset() {
+	*x = NamedVector{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamedVector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamedVector) ProtoMessage() {}
+
+func (x *NamedVector) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamedVector.ProtoReflect.Descriptor instead.
+func (*NamedVector) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *NamedVector) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
 type CollectionName struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -490,7 +649,7 @@ type CollectionName struct {
 
 func (x *CollectionName) Reset() {
 	*x = CollectionName{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[3]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -502,7 +661,7 @@ func (x *CollectionName) String() string {
 func (*CollectionName) ProtoMessage() {}
 
 func (x *CollectionName) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[3]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -515,7 +674,7 @@ func (x *CollectionName) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionName.ProtoReflect.Descriptor instead.
 func (*CollectionName) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{3}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CollectionName) GetCollectionName() string {
@@ -544,7 +703,7 @@ type CollectionResponse struct {
 
 func (x *CollectionResponse) Reset() {
 	*x = CollectionResponse{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[4]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -556,7 +715,7 @@ func (x *CollectionResponse) String() string {
 func (*CollectionResponse) ProtoMessage() {}
 
 func (x *CollectionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[4]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -569,7 +728,7 @@ func (x *CollectionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionResponse.ProtoReflect.Descriptor instead.
 func (*CollectionResponse) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{4}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CollectionResponse) GetStatus() bool {
@@ -598,16 +757,19 @@ type CollectionSpec struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CollectionName    string       `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	CollectionConfig  *HnswConfig  `protobuf:"bytes,2,opt,name=collection_config,json=collectionConfig,proto3" json:"collection_config,omitempty"`
-	VectorDimension   uint32       `protobuf:"varint,3,opt,name=vector_dimension,json=vectorDimension,proto3" json:"vector_dimension,omitempty"`
-	Distance          Distance     `protobuf:"varint,4,opt,name=distance,proto3,enum=coreproto.Distance" json:"distance,omitempty"`
-	CompressionHelper Quantization `protobuf:"varint,5,opt,name=compression_helper,json=compressionHelper,proto3,enum=coreproto.Quantization" json:"compression_helper,omitempty"`
+	CollectionName     string              `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	CollectionConfig   *HnswConfig         `protobuf:"bytes,2,opt,name=collection_config,json=collectionConfig,proto3" json:"collection_config,omitempty"`
+	VectorDimension    uint32              `protobuf:"varint,3,opt,name=vector_dimension,json=vectorDimension,proto3" json:"vector_dimension,omitempty"`
+	Distance           Distance            `protobuf:"varint,4,opt,name=distance,proto3,enum=coreproto.Distance" json:"distance,omitempty"`
+	CompressionHelper  Quantization        `protobuf:"varint,5,opt,name=compression_helper,json=compressionHelper,proto3,enum=coreproto.Quantization" json:"compression_helper,omitempty"`
+	QuantizationConfig *QuantizationConfig `protobuf:"bytes,6,opt,name=quantization_config,json=quantizationConfig,proto3" json:"quantization_config,omitempty"`
+	VectorFields       []*VectorField      `protobuf:"bytes,7,rep,name=vector_fields,json=vectorFields,proto3" json:"vector_fields,omitempty"`
+	DefaultTtlSeconds  uint64              `protobuf:"varint,8,opt,name=default_ttl_seconds,json=defaultTtlSeconds,proto3" json:"default_ttl_seconds,omitempty"` // 0 => datasets never expire
 }
 
 func (x *CollectionSpec) Reset() {
 	*x = CollectionSpec{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[5]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -619,7 +781,7 @@ func (x *CollectionSpec) String() string {
 func (*CollectionSpec) ProtoMessage() {}
 
 func (x *CollectionSpec) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[5]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -632,7 +794,7 @@ func (x *CollectionSpec) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionSpec.ProtoReflect.Descriptor instead.
 func (*CollectionSpec) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{5}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CollectionSpec) GetCollectionName() string {
@@ -670,37 +832,56 @@ func (x *CollectionSpec) GetCompressionHelper() Quantization {
 	return Quantization_None
 }
 
-type HnswConfig struct {
+func (x *CollectionSpec) GetQuantizationConfig() *QuantizationConfig {
+	if x != nil {
+		return x.QuantizationConfig
+	}
+	return nil
+}
+
+func (x *CollectionSpec) GetVectorFields() []*VectorField {
+	if x != nil {
+		return x.VectorFields
+	}
+	return nil
+}
+
+func (x *CollectionSpec) GetDefaultTtlSeconds() uint64 {
+	if x != nil {
+		return x.DefaultTtlSeconds
+	}
+	return 0
+}
+
+// named vector field, every field owns its own hnsw graph
+type VectorField struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SearchAlgorithm           SearchAlgorithm `protobuf:"varint,1,opt,name=search_algorithm,json=searchAlgorithm,proto3,enum=coreproto.SearchAlgorithm" json:"search_algorithm,omitempty"`
-	LevelMultiplier           float32         `protobuf:"fixed32,2,opt,name=level_multiplier,json=levelMultiplier,proto3" json:"level_multiplier,omitempty"`
-	Ef                        int32           `protobuf:"varint,3,opt,name=ef,proto3" json:"ef,omitempty"`
-	EfConstruction            int32           `protobuf:"varint,4,opt,name=ef_construction,json=efConstruction,proto3" json:"ef_construction,omitempty"`
-	M                         int32           `protobuf:"varint,5,opt,name=m,proto3" json:"m,omitempty"`
-	MMax                      int32           `protobuf:"varint,6,opt,name=m_max,json=mMax,proto3" json:"m_max,omitempty"`
-	MMax0                     int32           `protobuf:"varint,7,opt,name=m_max0,json=mMax0,proto3" json:"m_max0,omitempty"`
-	HeuristicExtendCandidates bool            `protobuf:"varint,8,opt,name=heuristic_extend_candidates,json=heuristicExtendCandidates,proto3" json:"heuristic_extend_candidates,omitempty"`
-	HeuristicKeepPruned       bool            `protobuf:"varint,9,opt,name=heuristic_keep_pruned,json=heuristicKeepPruned,proto3" json:"heuristic_keep_pruned,omitempty"`
+	Name               string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CollectionConfig   *HnswConfig         `protobuf:"bytes,2,opt,name=collection_config,json=collectionConfig,proto3" json:"collection_config,omitempty"`
+	VectorDimension    uint32              `protobuf:"varint,3,opt,name=vector_dimension,json=vectorDimension,proto3" json:"vector_dimension,omitempty"`
+	Distance           Distance            `protobuf:"varint,4,opt,name=distance,proto3,enum=coreproto.Distance" json:"distance,omitempty"`
+	CompressionHelper  Quantization        `protobuf:"varint,5,opt,name=compression_helper,json=compressionHelper,proto3,enum=coreproto.Quantization" json:"compression_helper,omitempty"`
+	QuantizationConfig *QuantizationConfig `protobuf:"bytes,6,opt,name=quantization_config,json=quantizationConfig,proto3" json:"quantization_config,omitempty"`
 }
 
-func (x *HnswConfig) Reset() {
-	*x = HnswConfig{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[6]
+func (x *VectorField) Reset() {
+	*x = VectorField{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HnswConfig) String() string {
+func (x *VectorField) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HnswConfig) ProtoMessage() {}
+func (*VectorField) ProtoMessage() {}
 
-func (x *HnswConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[6]
+func (x *VectorField) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -711,99 +892,79 @@ func (x *HnswConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HnswConfig.ProtoReflect.Descriptor instead.
-func (*HnswConfig) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *HnswConfig) GetSearchAlgorithm() SearchAlgorithm {
-	if x != nil {
-		return x.SearchAlgorithm
-	}
-	return SearchAlgorithm_Simple
-}
-
-func (x *HnswConfig) GetLevelMultiplier() float32 {
-	if x != nil {
-		return x.LevelMultiplier
-	}
-	return 0
-}
-
-func (x *HnswConfig) GetEf() int32 {
-	if x != nil {
-		return x.Ef
-	}
-	return 0
+// Deprecated: Use VectorField.ProtoReflect.Descriptor instead.
+func (*VectorField) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *HnswConfig) GetEfConstruction() int32 {
+func (x *VectorField) GetName() string {
 	if x != nil {
-		return x.EfConstruction
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *HnswConfig) GetM() int32 {
+func (x *VectorField) GetCollectionConfig() *HnswConfig {
 	if x != nil {
-		return x.M
+		return x.CollectionConfig
 	}
-	return 0
+	return nil
 }
 
-func (x *HnswConfig) GetMMax() int32 {
+func (x *VectorField) GetVectorDimension() uint32 {
 	if x != nil {
-		return x.MMax
+		return x.VectorDimension
 	}
 	return 0
 }
 
-func (x *HnswConfig) GetMMax0() int32 {
+func (x *VectorField) GetDistance() Distance {
 	if x != nil {
-		return x.MMax0
+		return x.Distance
 	}
-	return 0
+	return Distance_Cosine
 }
 
-func (x *HnswConfig) GetHeuristicExtendCandidates() bool {
+func (x *VectorField) GetCompressionHelper() Quantization {
 	if x != nil {
-		return x.HeuristicExtendCandidates
+		return x.CompressionHelper
 	}
-	return false
+	return Quantization_None
 }
 
-func (x *HnswConfig) GetHeuristicKeepPruned() bool {
+func (x *VectorField) GetQuantizationConfig() *QuantizationConfig {
 	if x != nil {
-		return x.HeuristicKeepPruned
+		return x.QuantizationConfig
 	}
-	return false
+	return nil
 }
 
-type ResponseWithMessage struct {
+// only used by PQ, zero values => defaults
+type QuantizationConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status  bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Error   *Error `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	PqSubspaces uint32 `protobuf:"varint,1,opt,name=pq_subspaces,json=pqSubspaces,proto3" json:"pq_subspaces,omitempty"`
+	PqCentroids uint32 `protobuf:"varint,2,opt,name=pq_centroids,json=pqCentroids,proto3" json:"pq_centroids,omitempty"`
+	PqTrainSize uint32 `protobuf:"varint,3,opt,name=pq_train_size,json=pqTrainSize,proto3" json:"pq_train_size,omitempty"`
 }
 
-func (x *ResponseWithMessage) Reset() {
-	*x = ResponseWithMessage{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[7]
+func (x *QuantizationConfig) Reset() {
+	*x = QuantizationConfig{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResponseWithMessage) String() string {
+func (x *QuantizationConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResponseWithMessage) ProtoMessage() {}
+func (*QuantizationConfig) ProtoMessage() {}
 
-func (x *ResponseWithMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[7]
+func (x *QuantizationConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -814,28 +975,432 @@ func (x *ResponseWithMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResponseWithMessage.ProtoReflect.Descriptor instead.
-func (*ResponseWithMessage) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use QuantizationConfig.ProtoReflect.Descriptor instead.
+func (*QuantizationConfig) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ResponseWithMessage) GetStatus() bool {
+func (x *QuantizationConfig) GetPqSubspaces() uint32 {
 	if x != nil {
-		return x.Status
+		return x.PqSubspaces
 	}
-	return false
+	return 0
 }
 
-func (x *ResponseWithMessage) GetMessage() string {
+func (x *QuantizationConfig) GetPqCentroids() uint32 {
 	if x != nil {
-		return x.Message
+		return x.PqCentroids
 	}
-	return ""
+	return 0
 }
 
-func (x *ResponseWithMessage) GetError() *Error {
+func (x *QuantizationConfig) GetPqTrainSize() uint32 {
 	if x != nil {
-		return x.Error
+		return x.PqTrainSize
+	}
+	return 0
+}
+
+// only search-time options, graph layout (m, ef_construction...) needs a rebuild
+type CollectionConfigUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CollectionName            string           `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Ef                        *int32           `protobuf:"varint,2,opt,name=ef,proto3,oneof" json:"ef,omitempty"`
+	SearchAlgorithm           *SearchAlgorithm `protobuf:"varint,3,opt,name=search_algorithm,json=searchAlgorithm,proto3,enum=coreproto.SearchAlgorithm,oneof" json:"search_algorithm,omitempty"`
+	HeuristicExtendCandidates *bool            `protobuf:"varint,4,opt,name=heuristic_extend_candidates,json=heuristicExtendCandidates,proto3,oneof" json:"heuristic_extend_candidates,omitempty"`
+	HeuristicKeepPruned       *bool            `protobuf:"varint,5,opt,name=heuristic_keep_pruned,json=heuristicKeepPruned,proto3,oneof" json:"heuristic_keep_pruned,omitempty"`
+	VectorField               string           `protobuf:"bytes,6,opt,name=vector_field,json=vectorField,proto3" json:"vector_field,omitempty"` // empty => default vector
+}
+
+func (x *CollectionConfigUpdate) Reset() {
+	*x = CollectionConfigUpdate{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionConfigUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionConfigUpdate) ProtoMessage() {}
+
+func (x *CollectionConfigUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionConfigUpdate.ProtoReflect.Descriptor instead.
+func (*CollectionConfigUpdate) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CollectionConfigUpdate) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *CollectionConfigUpdate) GetEf() int32 {
+	if x != nil && x.Ef != nil {
+		return *x.Ef
+	}
+	return 0
+}
+
+func (x *CollectionConfigUpdate) GetSearchAlgorithm() SearchAlgorithm {
+	if x != nil && x.SearchAlgorithm != nil {
+		return *x.SearchAlgorithm
+	}
+	return SearchAlgorithm_Simple
+}
+
+func (x *CollectionConfigUpdate) GetHeuristicExtendCandidates() bool {
+	if x != nil && x.HeuristicExtendCandidates != nil {
+		return *x.HeuristicExtendCandidates
+	}
+	return false
+}
+
+func (x *CollectionConfigUpdate) GetHeuristicKeepPruned() bool {
+	if x != nil && x.HeuristicKeepPruned != nil {
+		return *x.HeuristicKeepPruned
+	}
+	return false
+}
+
+func (x *CollectionConfigUpdate) GetVectorField() string {
+	if x != nil {
+		return x.VectorField
+	}
+	return ""
+}
+
+// rebuilds the graph of a collection or vector field in the background,
+// searches use the current graph until the new one is swapped in
+type RebuildIndexRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CollectionName   string        `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	VectorField      string        `protobuf:"bytes,2,opt,name=vector_field,json=vectorField,proto3" json:"vector_field,omitempty"`                // empty => default vector
+	CollectionConfig *HnswConfig   `protobuf:"bytes,3,opt,name=collection_config,json=collectionConfig,proto3" json:"collection_config,omitempty"` // unset => current config
+	Source           RebuildSource `protobuf:"varint,4,opt,name=source,proto3,enum=coreproto.RebuildSource" json:"source,omitempty"`
+}
+
+func (x *RebuildIndexRequest) Reset() {
+	*x = RebuildIndexRequest{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildIndexRequest) ProtoMessage() {}
+
+func (x *RebuildIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildIndexRequest.ProtoReflect.Descriptor instead.
+func (*RebuildIndexRequest) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RebuildIndexRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *RebuildIndexRequest) GetVectorField() string {
+	if x != nil {
+		return x.VectorField
+	}
+	return ""
+}
+
+func (x *RebuildIndexRequest) GetCollectionConfig() *HnswConfig {
+	if x != nil {
+		return x.CollectionConfig
+	}
+	return nil
+}
+
+func (x *RebuildIndexRequest) GetSource() RebuildSource {
+	if x != nil {
+		return x.Source
+	}
+	return RebuildSource_GRAPH
+}
+
+type RebuildProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Running     bool   `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	VectorField string `protobuf:"bytes,2,opt,name=vector_field,json=vectorField,proto3" json:"vector_field,omitempty"`
+	Processed   uint64 `protobuf:"varint,3,opt,name=processed,proto3" json:"processed,omitempty"`
+	Total       uint64 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Error       string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *RebuildProgress) Reset() {
+	*x = RebuildProgress{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildProgress) ProtoMessage() {}
+
+func (x *RebuildProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildProgress.ProtoReflect.Descriptor instead.
+func (*RebuildProgress) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RebuildProgress) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *RebuildProgress) GetVectorField() string {
+	if x != nil {
+		return x.VectorField
+	}
+	return ""
+}
+
+func (x *RebuildProgress) GetProcessed() uint64 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *RebuildProgress) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *RebuildProgress) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type HnswConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SearchAlgorithm           SearchAlgorithm `protobuf:"varint,1,opt,name=search_algorithm,json=searchAlgorithm,proto3,enum=coreproto.SearchAlgorithm" json:"search_algorithm,omitempty"`
+	LevelMultiplier           float32         `protobuf:"fixed32,2,opt,name=level_multiplier,json=levelMultiplier,proto3" json:"level_multiplier,omitempty"`
+	Ef                        int32           `protobuf:"varint,3,opt,name=ef,proto3" json:"ef,omitempty"`
+	EfConstruction            int32           `protobuf:"varint,4,opt,name=ef_construction,json=efConstruction,proto3" json:"ef_construction,omitempty"`
+	M                         int32           `protobuf:"varint,5,opt,name=m,proto3" json:"m,omitempty"`
+	MMax                      int32           `protobuf:"varint,6,opt,name=m_max,json=mMax,proto3" json:"m_max,omitempty"`
+	MMax0                     int32           `protobuf:"varint,7,opt,name=m_max0,json=mMax0,proto3" json:"m_max0,omitempty"`
+	HeuristicExtendCandidates bool            `protobuf:"varint,8,opt,name=heuristic_extend_candidates,json=heuristicExtendCandidates,proto3" json:"heuristic_extend_candidates,omitempty"`
+	HeuristicKeepPruned       bool            `protobuf:"varint,9,opt,name=heuristic_keep_pruned,json=heuristicKeepPruned,proto3" json:"heuristic_keep_pruned,omitempty"`
+}
+
+func (x *HnswConfig) Reset() {
+	*x = HnswConfig{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HnswConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HnswConfig) ProtoMessage() {}
+
+func (x *HnswConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HnswConfig.ProtoReflect.Descriptor instead.
+func (*HnswConfig) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HnswConfig) GetSearchAlgorithm() SearchAlgorithm {
+	if x != nil {
+		return x.SearchAlgorithm
+	}
+	return SearchAlgorithm_Simple
+}
+
+func (x *HnswConfig) GetLevelMultiplier() float32 {
+	if x != nil {
+		return x.LevelMultiplier
+	}
+	return 0
+}
+
+func (x *HnswConfig) GetEf() int32 {
+	if x != nil {
+		return x.Ef
+	}
+	return 0
+}
+
+func (x *HnswConfig) GetEfConstruction() int32 {
+	if x != nil {
+		return x.EfConstruction
+	}
+	return 0
+}
+
+func (x *HnswConfig) GetM() int32 {
+	if x != nil {
+		return x.M
+	}
+	return 0
+}
+
+func (x *HnswConfig) GetMMax() int32 {
+	if x != nil {
+		return x.MMax
+	}
+	return 0
+}
+
+func (x *HnswConfig) GetMMax0() int32 {
+	if x != nil {
+		return x.MMax0
+	}
+	return 0
+}
+
+func (x *HnswConfig) GetHeuristicExtendCandidates() bool {
+	if x != nil {
+		return x.HeuristicExtendCandidates
+	}
+	return false
+}
+
+func (x *HnswConfig) GetHeuristicKeepPruned() bool {
+	if x != nil {
+		return x.HeuristicKeepPruned
+	}
+	return false
+}
+
+type ResponseWithMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status  bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Error   *Error `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *ResponseWithMessage) Reset() {
+	*x = ResponseWithMessage{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseWithMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseWithMessage) ProtoMessage() {}
+
+func (x *ResponseWithMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseWithMessage.ProtoReflect.Descriptor instead.
+func (*ResponseWithMessage) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ResponseWithMessage) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+func (x *ResponseWithMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ResponseWithMessage) GetError() *Error {
+	if x != nil {
+		return x.Error
 	}
 	return nil
 }
@@ -851,7 +1416,7 @@ type Response struct {
 
 func (x *Response) Reset() {
 	*x = Response{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[8]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -863,7 +1428,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[8]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -876,7 +1441,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{8}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Response) GetStatus() bool {
@@ -904,7 +1469,7 @@ type Error struct {
 
 func (x *Error) Reset() {
 	*x = Error{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[9]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -916,7 +1481,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[9]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -929,51 +1494,173 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{9}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Error) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Error) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_UNDEFINED
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CollectionName    string            `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Vector            []float32         `protobuf:"fixed32,2,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+	TopK              uint64            `protobuf:"varint,3,opt,name=topK,proto3" json:"topK,omitempty"`
+	MinScoreThreshold float32           `protobuf:"fixed32,4,opt,name=min_score_threshold,json=minScoreThreshold,proto3" json:"min_score_threshold,omitempty"`
+	Filter            map[string]string `protobuf:"bytes,5,rep,name=filter,proto3" json:"filter,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	WithLatency       bool              `protobuf:"varint,6,opt,name=with_latency,json=withLatency,proto3" json:"with_latency,omitempty"`
+	Ef                int32             `protobuf:"varint,7,opt,name=ef,proto3" json:"ef,omitempty"` // 0 => collection default or profile
+	Profile           SearchProfile     `protobuf:"varint,8,opt,name=profile,proto3,enum=coreproto.SearchProfile" json:"profile,omitempty"`
+	RerankCandidates  uint32            `protobuf:"varint,9,opt,name=rerank_candidates,json=rerankCandidates,proto3" json:"rerank_candidates,omitempty"` // PQ/BQ only, 0 => no exact re-ranking
+	VectorField       string            `protobuf:"bytes,10,opt,name=vector_field,json=vectorField,proto3" json:"vector_field,omitempty"`                // empty => default vector
+	FieldQueries      []*FieldQuery     `protobuf:"bytes,11,rep,name=field_queries,json=fieldQueries,proto3" json:"field_queries,omitempty"`             // fused search over several vector fields
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetTopK() uint64 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetMinScoreThreshold() float32 {
+	if x != nil {
+		return x.MinScoreThreshold
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetFilter() map[string]string {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetWithLatency() bool {
+	if x != nil {
+		return x.WithLatency
+	}
+	return false
+}
+
+func (x *SearchRequest) GetEf() int32 {
+	if x != nil {
+		return x.Ef
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetProfile() SearchProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return SearchProfile_DEFAULT
 }
 
-func (x *Error) GetErrorMessage() string {
+func (x *SearchRequest) GetRerankCandidates() uint32 {
 	if x != nil {
-		return x.ErrorMessage
+		return x.RerankCandidates
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetVectorField() string {
+	if x != nil {
+		return x.VectorField
 	}
 	return ""
 }
 
-func (x *Error) GetErrorCode() ErrorCode {
+func (x *SearchRequest) GetFieldQueries() []*FieldQuery {
 	if x != nil {
-		return x.ErrorCode
+		return x.FieldQueries
 	}
-	return ErrorCode_UNDEFINED
+	return nil
 }
 
-type SearchRequest struct {
+type FieldQuery struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CollectionName    string            `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	Vector            []float32         `protobuf:"fixed32,2,rep,packed,name=vector,proto3" json:"vector,omitempty"`
-	TopK              uint64            `protobuf:"varint,3,opt,name=topK,proto3" json:"topK,omitempty"`
-	MinScoreThreshold float32           `protobuf:"fixed32,4,opt,name=min_score_threshold,json=minScoreThreshold,proto3" json:"min_score_threshold,omitempty"`
-	Filter            map[string]string `protobuf:"bytes,5,rep,name=filter,proto3" json:"filter,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	WithLatency       bool              `protobuf:"varint,6,opt,name=with_latency,json=withLatency,proto3" json:"with_latency,omitempty"`
+	VectorField string    `protobuf:"bytes,1,opt,name=vector_field,json=vectorField,proto3" json:"vector_field,omitempty"` // empty => default vector
+	Vector      []float32 `protobuf:"fixed32,2,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+	Weight      float32   `protobuf:"fixed32,3,opt,name=weight,proto3" json:"weight,omitempty"` // 0 => 1
 }
 
-func (x *SearchRequest) Reset() {
-	*x = SearchRequest{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[10]
+func (x *FieldQuery) Reset() {
+	*x = FieldQuery{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchRequest) String() string {
+func (x *FieldQuery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchRequest) ProtoMessage() {}
+func (*FieldQuery) ProtoMessage() {}
 
-func (x *SearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[10]
+func (x *FieldQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -984,53 +1671,32 @@ func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
-func (*SearchRequest) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use FieldQuery.ProtoReflect.Descriptor instead.
+func (*FieldQuery) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *SearchRequest) GetCollectionName() string {
+func (x *FieldQuery) GetVectorField() string {
 	if x != nil {
-		return x.CollectionName
+		return x.VectorField
 	}
 	return ""
 }
 
-func (x *SearchRequest) GetVector() []float32 {
+func (x *FieldQuery) GetVector() []float32 {
 	if x != nil {
 		return x.Vector
 	}
 	return nil
 }
 
-func (x *SearchRequest) GetTopK() uint64 {
-	if x != nil {
-		return x.TopK
-	}
-	return 0
-}
-
-func (x *SearchRequest) GetMinScoreThreshold() float32 {
+func (x *FieldQuery) GetWeight() float32 {
 	if x != nil {
-		return x.MinScoreThreshold
+		return x.Weight
 	}
 	return 0
 }
 
-func (x *SearchRequest) GetFilter() map[string]string {
-	if x != nil {
-		return x.Filter
-	}
-	return nil
-}
-
-func (x *SearchRequest) GetWithLatency() bool {
-	if x != nil {
-		return x.WithLatency
-	}
-	return false
-}
-
 type Candidates struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1043,7 +1709,7 @@ type Candidates struct {
 
 func (x *Candidates) Reset() {
 	*x = Candidates{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[11]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1055,7 +1721,7 @@ func (x *Candidates) String() string {
 func (*Candidates) ProtoMessage() {}
 
 func (x *Candidates) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[11]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1068,7 +1734,7 @@ func (x *Candidates) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Candidates.ProtoReflect.Descriptor instead.
 func (*Candidates) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{11}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *Candidates) GetId() string {
@@ -1101,11 +1767,12 @@ type SearchResponse struct {
 	Error      *Error        `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	Candidates []*Candidates `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`
 	Latency    string        `protobuf:"bytes,4,opt,name=latency,proto3" json:"latency,omitempty"`
+	Explain    *QueryPlan    `protobuf:"bytes,5,opt,name=explain,proto3" json:"explain,omitempty"`
 }
 
 func (x *SearchResponse) Reset() {
 	*x = SearchResponse{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[12]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1117,7 +1784,7 @@ func (x *SearchResponse) String() string {
 func (*SearchResponse) ProtoMessage() {}
 
 func (x *SearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[12]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1130,7 +1797,7 @@ func (x *SearchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
 func (*SearchResponse) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{12}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *SearchResponse) GetStatus() bool {
@@ -1161,6 +1828,99 @@ func (x *SearchResponse) GetLatency() string {
 	return ""
 }
 
+func (x *SearchResponse) GetExplain() *QueryPlan {
+	if x != nil {
+		return x.Explain
+	}
+	return nil
+}
+
+// QueryPlan explains how a filtered search visited the rows
+type QueryPlan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Strategy    string  `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Filtered    uint64  `protobuf:"varint,2,opt,name=filtered,proto3" json:"filtered,omitempty"`
+	Total       uint64  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Selectivity float64 `protobuf:"fixed64,4,opt,name=selectivity,proto3" json:"selectivity,omitempty"`
+	Fetch       uint64  `protobuf:"varint,5,opt,name=fetch,proto3" json:"fetch,omitempty"` // candidates asked of the ann graph, 0 when the rows are scanned
+	Detail      string  `protobuf:"bytes,6,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *QueryPlan) Reset() {
+	*x = QueryPlan{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPlan) ProtoMessage() {}
+
+func (x *QueryPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPlan.ProtoReflect.Descriptor instead.
+func (*QueryPlan) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *QueryPlan) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *QueryPlan) GetFiltered() uint64 {
+	if x != nil {
+		return x.Filtered
+	}
+	return 0
+}
+
+func (x *QueryPlan) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *QueryPlan) GetSelectivity() float64 {
+	if x != nil {
+		return x.Selectivity
+	}
+	return 0
+}
+
+func (x *QueryPlan) GetFetch() uint64 {
+	if x != nil {
+		return x.Fetch
+	}
+	return 0
+}
+
+func (x *QueryPlan) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
 type CollectionMsg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1173,7 +1933,7 @@ type CollectionMsg struct {
 
 func (x *CollectionMsg) Reset() {
 	*x = CollectionMsg{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[13]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1185,7 +1945,7 @@ func (x *CollectionMsg) String() string {
 func (*CollectionMsg) ProtoMessage() {}
 
 func (x *CollectionMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[13]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1198,7 +1958,7 @@ func (x *CollectionMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionMsg.ProtoReflect.Descriptor instead.
 func (*CollectionMsg) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{13}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *CollectionMsg) GetStatus() bool {
@@ -1227,18 +1987,21 @@ type CollectionInfo struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CollectionName    string       `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	CollectionConfig  *HnswConfig  `protobuf:"bytes,2,opt,name=collection_config,json=collectionConfig,proto3" json:"collection_config,omitempty"`
-	VectorDimension   uint32       `protobuf:"varint,3,opt,name=vector_dimension,json=vectorDimension,proto3" json:"vector_dimension,omitempty"`
-	Distance          Distance     `protobuf:"varint,4,opt,name=distance,proto3,enum=coreproto.Distance" json:"distance,omitempty"`
-	CompressionHelper Quantization `protobuf:"varint,5,opt,name=compression_helper,json=compressionHelper,proto3,enum=coreproto.Quantization" json:"compression_helper,omitempty"`
-	CollectionSize    string       `protobuf:"bytes,6,opt,name=collection_size,json=collectionSize,proto3" json:"collection_size,omitempty"`
-	CollectionLength  uint64       `protobuf:"varint,7,opt,name=collection_length,json=collectionLength,proto3" json:"collection_length,omitempty"`
+	CollectionName    string           `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	CollectionConfig  *HnswConfig      `protobuf:"bytes,2,opt,name=collection_config,json=collectionConfig,proto3" json:"collection_config,omitempty"`
+	VectorDimension   uint32           `protobuf:"varint,3,opt,name=vector_dimension,json=vectorDimension,proto3" json:"vector_dimension,omitempty"`
+	Distance          Distance         `protobuf:"varint,4,opt,name=distance,proto3,enum=coreproto.Distance" json:"distance,omitempty"`
+	CompressionHelper Quantization     `protobuf:"varint,5,opt,name=compression_helper,json=compressionHelper,proto3,enum=coreproto.Quantization" json:"compression_helper,omitempty"`
+	CollectionSize    string           `protobuf:"bytes,6,opt,name=collection_size,json=collectionSize,proto3" json:"collection_size,omitempty"`
+	CollectionLength  uint64           `protobuf:"varint,7,opt,name=collection_length,json=collectionLength,proto3" json:"collection_length,omitempty"`
+	VectorFields      []*VectorField   `protobuf:"bytes,8,rep,name=vector_fields,json=vectorFields,proto3" json:"vector_fields,omitempty"`
+	Rebuild           *RebuildProgress `protobuf:"bytes,9,opt,name=rebuild,proto3" json:"rebuild,omitempty"` // last rebuild of the collection
+	DefaultTtlSeconds uint64           `protobuf:"varint,10,opt,name=default_ttl_seconds,json=defaultTtlSeconds,proto3" json:"default_ttl_seconds,omitempty"`
 }
 
 func (x *CollectionInfo) Reset() {
 	*x = CollectionInfo{}
-	mi := &file_idl_proto_v3_core_proto_msgTypes[14]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1250,7 +2013,7 @@ func (x *CollectionInfo) String() string {
 func (*CollectionInfo) ProtoMessage() {}
 
 func (x *CollectionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v3_core_proto_msgTypes[14]
+	mi := &file_idl_proto_v3_core_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1263,7 +2026,7 @@ func (x *CollectionInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectionInfo.ProtoReflect.Descriptor instead.
 func (*CollectionInfo) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{14}
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *CollectionInfo) GetCollectionName() string {
@@ -1315,6 +2078,173 @@ func (x *CollectionInfo) GetCollectionLength() uint64 {
 	return 0
 }
 
+func (x *CollectionInfo) GetVectorFields() []*VectorField {
+	if x != nil {
+		return x.VectorFields
+	}
+	return nil
+}
+
+func (x *CollectionInfo) GetRebuild() *RebuildProgress {
+	if x != nil {
+		return x.Rebuild
+	}
+	return nil
+}
+
+func (x *CollectionInfo) GetDefaultTtlSeconds() uint64 {
+	if x != nil {
+		return x.DefaultTtlSeconds
+	}
+	return 0
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CollectionName string `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	FromPosition   uint64 `protobuf:"varint,2,opt,name=from_position,json=fromPosition,proto3" json:"from_position,omitempty"` // 0 => only the changes from now on
+	WithVector     bool   `protobuf:"varint,3,opt,name=with_vector,json=withVector,proto3" json:"with_vector,omitempty"`
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SubscribeRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *SubscribeRequest) GetFromPosition() uint64 {
+	if x != nil {
+		return x.FromPosition
+	}
+	return 0
+}
+
+func (x *SubscribeRequest) GetWithVector() bool {
+	if x != nil {
+		return x.WithVector
+	}
+	return false
+}
+
+type ChangeEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Position   uint64                  `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"` // resume with from_position=position
+	ChangeType IndexChangeTypes        `protobuf:"varint,2,opt,name=change_type,json=changeType,proto3,enum=coreproto.IndexChangeTypes" json:"change_type,omitempty"`
+	Id         string                  `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Metadata   *structpb.Struct        `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Vector     []float32               `protobuf:"fixed32,5,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+	Vectors    map[string]*NamedVector `protobuf:"bytes,6,rep,name=vectors,proto3" json:"vectors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ChangeEvent) Reset() {
+	*x = ChangeEvent{}
+	mi := &file_idl_proto_v3_core_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEvent) ProtoMessage() {}
+
+func (x *ChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_core_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEvent.ProtoReflect.Descriptor instead.
+func (*ChangeEvent) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_core_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ChangeEvent) GetPosition() uint64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetChangeType() IndexChangeTypes {
+	if x != nil {
+		return x.ChangeType
+	}
+	return IndexChangeTypes_INSERT
+}
+
+func (x *ChangeEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetVectors() map[string]*NamedVector {
+	if x != nil {
+		return x.Vectors
+	}
+	return nil
+}
+
 var File_idl_proto_v3_core_proto protoreflect.FileDescriptor
 
 var file_idl_proto_v3_core_proto_rawDesc = []byte{
@@ -1332,7 +2262,7 @@ var file_idl_proto_v3_core_proto_rawDesc = []byte{
 	0x0e, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69,
 	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x04, 0x64, 0x69, 0x73, 0x74, 0x22, 0x1e, 0x0a, 0x06,
 	0x58, 0x79, 0x44, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0xe0, 0x01, 0x0a,
+	0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x96, 0x03, 0x0a,
 	0x0d, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x0e,
 	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27,
 	0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d,
@@ -1346,22 +2276,262 @@ var file_idl_proto_v3_core_proto_rawDesc = []byte{
 	0x61, 0x6e, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
 	0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x64,
 	0x65, 0x78, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x10, 0x69,
-	0x6e, 0x64, 0x65, 0x78, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x22,
-	0x56, 0x0a, 0x0e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x69,
-	0x74, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x77,
-	0x69, 0x74, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x12, 0x43, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16,
+	0x6e, 0x64, 0x65, 0x78, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12,
+	0x3f, 0x0a, 0x07, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x74, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x1a, 0x52, 0x0a, 0x0c, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e,
+	0x61, 0x6d, 0x65, 0x64, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x25, 0x0a, 0x0b, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x56, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x56, 0x0a, 0x0e,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x27,
+	0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x69, 0x74, 0x68, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x77, 0x69, 0x74, 0x68,
+	0x53, 0x69, 0x7a, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x12, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70,
+	0x65, 0x63, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xde, 0x03, 0x0a, 0x0e, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x70, 0x65, 0x63, 0x12, 0x27, 0x0a,
+	0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x42, 0x0a, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x6e,
+	0x73, 0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x29, 0x0a, 0x10, 0x76, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x5f, 0x64, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x44, 0x69, 0x6d, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x08, 0x64, 0x69,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x46, 0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51,
+	0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x63, 0x6f, 0x6d,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x12, 0x4e,
+	0x0a, 0x13, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x12, 0x71, 0x75, 0x61, 0x6e,
+	0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3b,
+	0x0a, 0x0d, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x0c, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x74, 0x74, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x54, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0xd9, 0x02, 0x0a, 0x0b,
+	0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x42, 0x0a, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x6e, 0x73, 0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x29, 0x0a, 0x10, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x64, 0x69,
+	0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x44, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f,
+	0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x46, 0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x68,
+	0x65, 0x6c, 0x70, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x48, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x12, 0x4e, 0x0a, 0x13, 0x71, 0x75, 0x61, 0x6e, 0x74,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x12, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x7e, 0x0a, 0x12, 0x51, 0x75, 0x61, 0x6e, 0x74,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x21, 0x0a,
+	0x0c, 0x70, 0x71, 0x5f, 0x73, 0x75, 0x62, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x71, 0x53, 0x75, 0x62, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x70, 0x71, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x6f, 0x69, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x71, 0x43, 0x65, 0x6e, 0x74, 0x72, 0x6f,
+	0x69, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x71, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x71, 0x54, 0x72,
+	0x61, 0x69, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x99, 0x03, 0x0a, 0x16, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x13, 0x0a, 0x02, 0x65,
+	0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x02, 0x65, 0x66, 0x88, 0x01, 0x01,
+	0x12, 0x4a, 0x0a, 0x10, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72,
+	0x69, 0x74, 0x68, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x48, 0x01, 0x52, 0x0f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x43, 0x0a, 0x1b,
+	0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x5f, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x48, 0x02, 0x52, 0x19, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x45, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x88, 0x01,
+	0x01, 0x12, 0x37, 0x0a, 0x15, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x6b,
+	0x65, 0x65, 0x70, 0x5f, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x48, 0x03, 0x52, 0x13, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x4b, 0x65, 0x65,
+	0x70, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x42, 0x05, 0x0a,
+	0x03, 0x5f, 0x65, 0x66, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f,
+	0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x68, 0x65,
+	0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x5f, 0x63,
+	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x68, 0x65,
+	0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x70, 0x72, 0x75,
+	0x6e, 0x65, 0x64, 0x22, 0xd7, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x76, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x42, 0x0a, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48,
+	0x6e, 0x73, 0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x30, 0x0a, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x98, 0x01,
+	0x0a, 0x0f, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x21, 0x0a, 0x0c, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xe5, 0x02, 0x0a, 0x0a, 0x48, 0x6e, 0x73,
+	0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x45, 0x0a, 0x10, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x0f, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x29,
+	0x0a, 0x10, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x4d,
+	0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x65, 0x66, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x65, 0x66, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x66, 0x5f,
+	0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0e, 0x65, 0x66, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x6d,
+	0x12, 0x13, 0x0a, 0x05, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x6d, 0x4d, 0x61, 0x78, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x30, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6d, 0x4d, 0x61, 0x78, 0x30, 0x12, 0x3e, 0x0a, 0x1b,
+	0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x5f, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x19, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x45, 0x78, 0x74, 0x65,
+	0x6e, 0x64, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15,
+	0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x70,
+	0x72, 0x75, 0x6e, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x68, 0x65, 0x75,
+	0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x4b, 0x65, 0x65, 0x70, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x64,
+	0x22, 0x6f, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x57, 0x69, 0x74, 0x68,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x22, 0x4a, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x61, 0x0a,
+	0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x0a, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x22, 0x80, 0x04, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x6f, 0x70, 0x4b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4b, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x69, 0x6e, 0x5f, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x11, 0x6d, 0x69, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x3c, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6c, 0x61,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x77, 0x69, 0x74,
+	0x68, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x65, 0x66, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x65, 0x66, 0x12, 0x32, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x11,
+	0x72, 0x65, 0x72, 0x61, 0x6e, 0x6b, 0x5f, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x72, 0x65, 0x72, 0x61, 0x6e, 0x6b, 0x43,
+	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x3a, 0x0a, 0x0d,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0b, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x0c, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x5f, 0x0a, 0x0a, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x22, 0x67, 0x0a, 0x0a, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0xd1, 0x01,
+	0x0a, 0x0e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x35, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x0a, 0x63, 0x61, 0x6e,
+	0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x12, 0x2e, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69,
+	0x6e, 0x22, 0xa9, 0x01, 0x0a, 0x09, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x6c, 0x61, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x20, 0x0a,
+	0x0b, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0b, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x65, 0x74, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x66, 0x65, 0x74, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x7e, 0x0a,
+	0x0d, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x67, 0x12, 0x16,
 	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x02,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x70, 0x65, 0x63, 0x52,
-	0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03,
+	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xa1, 0x02,
-	0x0a, 0x0e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x70, 0x65, 0x63,
+	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x9a, 0x04,
+	0x0a, 0x0e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
 	0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e,
 	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
 	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x42, 0x0a, 0x11, 0x63, 0x6f, 0x6c,
@@ -1379,193 +2549,153 @@ var file_idl_proto_v3_core_proto_rawDesc = []byte{
 	0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11,
 	0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x6c, 0x70, 0x65,
-	0x72, 0x22, 0xe5, 0x02, 0x0a, 0x0a, 0x48, 0x6e, 0x73, 0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x12, 0x45, 0x0a, 0x10, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72,
-	0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x72,
-	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c, 0x67,
-	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x0f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c,
-	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x65, 0x76, 0x65, 0x6c,
-	0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x02, 0x52, 0x0f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69,
-	0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
-	0x65, 0x66, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x66, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x65, 0x66, 0x43,
-	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x6d,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x6d, 0x12, 0x13, 0x0a, 0x05, 0x6d, 0x5f, 0x6d,
-	0x61, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6d, 0x4d, 0x61, 0x78, 0x12, 0x15,
-	0x0a, 0x06, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x30, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
-	0x6d, 0x4d, 0x61, 0x78, 0x30, 0x12, 0x3e, 0x0a, 0x1b, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74,
-	0x69, 0x63, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64,
-	0x61, 0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x19, 0x68, 0x65, 0x75, 0x72,
-	0x69, 0x73, 0x74, 0x69, 0x63, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x43, 0x61, 0x6e, 0x64, 0x69,
-	0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74,
-	0x69, 0x63, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x18, 0x09,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x4b,
-	0x65, 0x65, 0x70, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x22, 0x6f, 0x0a, 0x13, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x4a, 0x0a, 0x08, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26,
-	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
-	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x61, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
-	0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f,
-	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x22, 0xb0, 0x02, 0x0a, 0x0d, 0x53, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63,
-	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04,
-	0x74, 0x6f, 0x70, 0x4b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4b,
-	0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x74, 0x68,
-	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x11, 0x6d,
-	0x69, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
-	0x12, 0x3c, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x24, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65,
-	0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x21,
-	0x0a, 0x0c, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x77, 0x69, 0x74, 0x68, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63,
-	0x79, 0x1a, 0x39, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x67, 0x0a, 0x0a,
-	0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
-	0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
-	0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05,
-	0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0xa1, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f,
-	0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x35, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64,
-	0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63,
-	0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61,
-	0x74, 0x65, 0x73, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12,
-	0x18, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x7e, 0x0a, 0x0d, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66,
-	0x6f, 0x12, 0x26, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x10, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xf7, 0x02, 0x0a, 0x0e, 0x43, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x27, 0x0a, 0x0f,
-	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x42, 0x0a, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x6e, 0x73,
-	0x77, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x29, 0x0a, 0x10, 0x76, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x5f, 0x64, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x0f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x44, 0x69, 0x6d, 0x65, 0x6e,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x08, 0x64, 0x69, 0x73,
-	0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x46, 0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75,
-	0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x63, 0x6f, 0x6d, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x12, 0x27, 0x0a,
-	0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x6e,
-	0x67, 0x74, 0x68, 0x2a, 0x2c, 0x0a, 0x0f, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c, 0x67,
-	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65,
-	0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x48, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x10,
-	0x01, 0x2a, 0x25, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x0a, 0x0a,
-	0x06, 0x43, 0x6f, 0x73, 0x69, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x75, 0x63,
-	0x6c, 0x69, 0x64, 0x65, 0x61, 0x6e, 0x10, 0x01, 0x2a, 0x43, 0x0a, 0x0c, 0x51, 0x75, 0x61, 0x6e,
-	0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x6f, 0x6e, 0x65,
-	0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x46, 0x31, 0x36, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x46,
-	0x38, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x46, 0x31, 0x36, 0x10, 0x03, 0x12, 0x06, 0x0a,
-	0x02, 0x50, 0x51, 0x10, 0x04, 0x12, 0x06, 0x0a, 0x02, 0x42, 0x51, 0x10, 0x05, 0x2a, 0x97, 0x01,
-	0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x55,
-	0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x50,
-	0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x43, 0x4f, 0x4d,
-	0x4d, 0x55, 0x4e, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x44,
-	0x5f, 0x52, 0x50, 0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19,
-	0x43, 0x4f, 0x4d, 0x4d, 0x55, 0x4e, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x48,
-	0x41, 0x52, 0x44, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x4d,
-	0x41, 0x52, 0x53, 0x48, 0x41, 0x4c, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x04, 0x12, 0x17,
-	0x0a, 0x13, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x46, 0x55, 0x4e, 0x43, 0x5f,
-	0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x05, 0x2a, 0x36, 0x0a, 0x10, 0x49, 0x6e, 0x64, 0x65, 0x78,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x0a, 0x0a, 0x06, 0x49,
-	0x4e, 0x53, 0x45, 0x52, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x44, 0x41, 0x54,
-	0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x32,
-	0xfd, 0x06, 0x0a, 0x07, 0x43, 0x6f, 0x72, 0x65, 0x52, 0x70, 0x63, 0x12, 0x38, 0x0a, 0x04, 0x50,
-	0x69, 0x6e, 0x67, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43,
-	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x53, 0x70, 0x65, 0x63, 0x1a, 0x1d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0e, 0x44, 0x72, 0x6f, 0x70, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61,
-	0x6d, 0x65, 0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0f, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x66, 0x12, 0x19, 0x2e, 0x63,
-	0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73,
-	0x67, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0e, 0x4c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65,
-	0x1a, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x67, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x11,
-	0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x1e, 0x2e, 0x63,
+	0x72, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x3b, 0x0a, 0x0d, 0x76, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x0c, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x52, 0x07, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x74, 0x74, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x54, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x81, 0x01, 0x0a, 0x10, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a,
+	0x0b, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x77, 0x69, 0x74, 0x68, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0xd7,
+	0x02, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c, 0x0a, 0x0b, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x0a, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x3d, 0x0a, 0x07, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x76, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x1a, 0x52, 0x0a, 0x0c, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x2a, 0x0a, 0x0d, 0x52, 0x65, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x52, 0x41,
+	0x50, 0x48, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4f, 0x4d, 0x4d, 0x49, 0x54, 0x5f, 0x4c,
+	0x4f, 0x47, 0x10, 0x01, 0x2a, 0x2c, 0x0a, 0x0f, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x69, 0x6d, 0x70, 0x6c,
+	0x65, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x48, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63,
+	0x10, 0x01, 0x2a, 0x4c, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00,
+	0x12, 0x0f, 0x0a, 0x0b, 0x48, 0x49, 0x47, 0x48, 0x5f, 0x52, 0x45, 0x43, 0x41, 0x4c, 0x4c, 0x10,
+	0x01, 0x12, 0x0c, 0x0a, 0x08, 0x42, 0x41, 0x4c, 0x41, 0x4e, 0x43, 0x45, 0x44, 0x10, 0x02, 0x12,
+	0x0f, 0x0a, 0x0b, 0x4c, 0x4f, 0x57, 0x5f, 0x4c, 0x41, 0x54, 0x45, 0x4e, 0x43, 0x59, 0x10, 0x03,
+	0x2a, 0x25, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x0a, 0x0a, 0x06,
+	0x43, 0x6f, 0x73, 0x69, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x75, 0x63, 0x6c,
+	0x69, 0x64, 0x65, 0x61, 0x6e, 0x10, 0x01, 0x2a, 0x43, 0x0a, 0x0c, 0x51, 0x75, 0x61, 0x6e, 0x74,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x6f, 0x6e, 0x65, 0x10,
+	0x00, 0x12, 0x07, 0x0a, 0x03, 0x46, 0x31, 0x36, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x46, 0x38,
+	0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x46, 0x31, 0x36, 0x10, 0x03, 0x12, 0x06, 0x0a, 0x02,
+	0x50, 0x51, 0x10, 0x04, 0x12, 0x06, 0x0a, 0x02, 0x42, 0x51, 0x10, 0x05, 0x2a, 0x97, 0x01, 0x0a,
+	0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x4e,
+	0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x50, 0x43,
+	0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x43, 0x4f, 0x4d, 0x4d,
+	0x55, 0x4e, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x44, 0x5f,
+	0x52, 0x50, 0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x43,
+	0x4f, 0x4d, 0x4d, 0x55, 0x4e, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x48, 0x41,
+	0x52, 0x44, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x41,
+	0x52, 0x53, 0x48, 0x41, 0x4c, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x04, 0x12, 0x17, 0x0a,
+	0x13, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x46, 0x55, 0x4e, 0x43, 0x5f, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x10, 0x05, 0x2a, 0x36, 0x0a, 0x10, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x0a, 0x0a, 0x06, 0x49, 0x4e,
+	0x53, 0x45, 0x52, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45,
+	0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x32, 0xee,
+	0x08, 0x0a, 0x07, 0x43, 0x6f, 0x72, 0x65, 0x52, 0x70, 0x63, 0x12, 0x38, 0x0a, 0x04, 0x50, 0x69,
+	0x6e, 0x67, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x70, 0x65, 0x63, 0x1a, 0x1d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0e, 0x44, 0x72, 0x6f, 0x70, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
+	0x65, 0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0f, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x66, 0x12, 0x19, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x67,
+	0x22, 0x00, 0x12, 0x47, 0x0a, 0x0e, 0x4c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a,
+	0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x67, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x11, 0x52,
+	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x1e, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a,
+	0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x21, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x1a, 0x18, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x4d, 0x73, 0x67, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x0c, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1e, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x57, 0x69, 0x74, 0x68, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x06, 0x49, 0x6e, 0x73, 0x65,
+	0x72, 0x74, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x13, 0x2e, 0x63,
 	0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x39,
-	0x0a, 0x06, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70,
+	0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39,
+	0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e,
 	0x67, 0x65, 0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x06, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x13, 0x2e,
-	0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x18,
-	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73,
-	0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x45, 0x0a, 0x0c, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12,
-	0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a,
-	0x0c, 0x48, 0x79, 0x62, 0x72, 0x69, 0x64, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x18, 0x2e,
-	0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x44,
-	0x69, 0x73, 0x74, 0x12, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x43, 0x6f, 0x6d, 0x70, 0x58, 0x79, 0x44, 0x69, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x63, 0x6f, 0x72,
-	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x58, 0x79, 0x44, 0x69, 0x73, 0x74, 0x22, 0x00, 0x42,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x56, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x45, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x48, 0x79, 0x62, 0x72, 0x69,
+	0x64, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39,
+	0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x44, 0x69, 0x73, 0x74, 0x12, 0x15, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x58, 0x79,
+	0x44, 0x69, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x58, 0x79, 0x44, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x09, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x42,
 	0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
@@ -1582,86 +2712,126 @@ func file_idl_proto_v3_core_proto_rawDescGZIP() []byte {
 	return file_idl_proto_v3_core_proto_rawDescData
 }
 
-var file_idl_proto_v3_core_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_idl_proto_v3_core_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_idl_proto_v3_core_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_idl_proto_v3_core_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_idl_proto_v3_core_proto_goTypes = []any{
-	(SearchAlgorithm)(0),        // 0: coreproto.SearchAlgorithm
-	(Distance)(0),               // 1: coreproto.Distance
-	(Quantization)(0),           // 2: coreproto.Quantization
-	(ErrorCode)(0),              // 3: coreproto.ErrorCode
-	(IndexChangeTypes)(0),       // 4: coreproto.IndexChangeTypes
-	(*CompXyDist)(nil),          // 5: coreproto.CompXyDist
-	(*XyDist)(nil),              // 6: coreproto.XyDist
-	(*DatasetChange)(nil),       // 7: coreproto.DatasetChange
-	(*CollectionName)(nil),      // 8: coreproto.CollectionName
-	(*CollectionResponse)(nil),  // 9: coreproto.CollectionResponse
-	(*CollectionSpec)(nil),      // 10: coreproto.CollectionSpec
-	(*HnswConfig)(nil),          // 11: coreproto.HnswConfig
-	(*ResponseWithMessage)(nil), // 12: coreproto.ResponseWithMessage
-	(*Response)(nil),            // 13: coreproto.Response
-	(*Error)(nil),               // 14: coreproto.Error
-	(*SearchRequest)(nil),       // 15: coreproto.SearchRequest
-	(*Candidates)(nil),          // 16: coreproto.Candidates
-	(*SearchResponse)(nil),      // 17: coreproto.SearchResponse
-	(*CollectionMsg)(nil),       // 18: coreproto.CollectionMsg
-	(*CollectionInfo)(nil),      // 19: coreproto.CollectionInfo
-	nil,                         // 20: coreproto.SearchRequest.FilterEntry
-	(*structpb.Struct)(nil),     // 21: google.protobuf.Struct
-	(*emptypb.Empty)(nil),       // 22: google.protobuf.Empty
+	(RebuildSource)(0),             // 0: coreproto.RebuildSource
+	(SearchAlgorithm)(0),           // 1: coreproto.SearchAlgorithm
+	(SearchProfile)(0),             // 2: coreproto.SearchProfile
+	(Distance)(0),                  // 3: coreproto.Distance
+	(Quantization)(0),              // 4: coreproto.Quantization
+	(ErrorCode)(0),                 // 5: coreproto.ErrorCode
+	(IndexChangeTypes)(0),          // 6: coreproto.IndexChangeTypes
+	(*CompXyDist)(nil),             // 7: coreproto.CompXyDist
+	(*XyDist)(nil),                 // 8: coreproto.XyDist
+	(*DatasetChange)(nil),          // 9: coreproto.DatasetChange
+	(*NamedVector)(nil),            // 10: coreproto.NamedVector
+	(*CollectionName)(nil),         // 11: coreproto.CollectionName
+	(*CollectionResponse)(nil),     // 12: coreproto.CollectionResponse
+	(*CollectionSpec)(nil),         // 13: coreproto.CollectionSpec
+	(*VectorField)(nil),            // 14: coreproto.VectorField
+	(*QuantizationConfig)(nil),     // 15: coreproto.QuantizationConfig
+	(*CollectionConfigUpdate)(nil), // 16: coreproto.CollectionConfigUpdate
+	(*RebuildIndexRequest)(nil),    // 17: coreproto.RebuildIndexRequest
+	(*RebuildProgress)(nil),        // 18: coreproto.RebuildProgress
+	(*HnswConfig)(nil),             // 19: coreproto.HnswConfig
+	(*ResponseWithMessage)(nil),    // 20: coreproto.ResponseWithMessage
+	(*Response)(nil),               // 21: coreproto.Response
+	(*Error)(nil),                  // 22: coreproto.Error
+	(*SearchRequest)(nil),          // 23: coreproto.SearchRequest
+	(*FieldQuery)(nil),             // 24: coreproto.FieldQuery
+	(*Candidates)(nil),             // 25: coreproto.Candidates
+	(*SearchResponse)(nil),         // 26: coreproto.SearchResponse
+	(*QueryPlan)(nil),              // 27: coreproto.QueryPlan
+	(*CollectionMsg)(nil),          // 28: coreproto.CollectionMsg
+	(*CollectionInfo)(nil),         // 29: coreproto.CollectionInfo
+	(*SubscribeRequest)(nil),       // 30: coreproto.SubscribeRequest
+	(*ChangeEvent)(nil),            // 31: coreproto.ChangeEvent
+	nil,                            // 32: coreproto.DatasetChange.VectorsEntry
+	nil,                            // 33: coreproto.SearchRequest.FilterEntry
+	nil,                            // 34: coreproto.ChangeEvent.VectorsEntry
+	(*structpb.Struct)(nil),        // 35: google.protobuf.Struct
+	(*emptypb.Empty)(nil),          // 36: google.protobuf.Empty
 }
 var file_idl_proto_v3_core_proto_depIdxs = []int32{
-	1,  // 0: coreproto.CompXyDist.dist:type_name -> coreproto.Distance
-	21, // 1: coreproto.DatasetChange.metadata:type_name -> google.protobuf.Struct
-	4,  // 2: coreproto.DatasetChange.index_change_types:type_name -> coreproto.IndexChangeTypes
-	10, // 3: coreproto.CollectionResponse.spec:type_name -> coreproto.CollectionSpec
-	14, // 4: coreproto.CollectionResponse.error:type_name -> coreproto.Error
-	11, // 5: coreproto.CollectionSpec.collection_config:type_name -> coreproto.HnswConfig
-	1,  // 6: coreproto.CollectionSpec.distance:type_name -> coreproto.Distance
-	2,  // 7: coreproto.CollectionSpec.compression_helper:type_name -> coreproto.Quantization
-	0,  // 8: coreproto.HnswConfig.search_algorithm:type_name -> coreproto.SearchAlgorithm
-	14, // 9: coreproto.ResponseWithMessage.error:type_name -> coreproto.Error
-	14, // 10: coreproto.Response.error:type_name -> coreproto.Error
-	3,  // 11: coreproto.Error.error_code:type_name -> coreproto.ErrorCode
-	20, // 12: coreproto.SearchRequest.filter:type_name -> coreproto.SearchRequest.FilterEntry
-	21, // 13: coreproto.Candidates.metadata:type_name -> google.protobuf.Struct
-	14, // 14: coreproto.SearchResponse.error:type_name -> coreproto.Error
-	16, // 15: coreproto.SearchResponse.candidates:type_name -> coreproto.Candidates
-	19, // 16: coreproto.CollectionMsg.info:type_name -> coreproto.CollectionInfo
-	14, // 17: coreproto.CollectionMsg.error:type_name -> coreproto.Error
-	11, // 18: coreproto.CollectionInfo.collection_config:type_name -> coreproto.HnswConfig
-	1,  // 19: coreproto.CollectionInfo.distance:type_name -> coreproto.Distance
-	2,  // 20: coreproto.CollectionInfo.compression_helper:type_name -> coreproto.Quantization
-	22, // 21: coreproto.CoreRpc.Ping:input_type -> google.protobuf.Empty
-	10, // 22: coreproto.CoreRpc.CreateCollection:input_type -> coreproto.CollectionSpec
-	8,  // 23: coreproto.CoreRpc.DropCollection:input_type -> coreproto.CollectionName
-	8,  // 24: coreproto.CoreRpc.CollectionInfof:input_type -> coreproto.CollectionName
-	8,  // 25: coreproto.CoreRpc.LoadCollection:input_type -> coreproto.CollectionName
-	8,  // 26: coreproto.CoreRpc.ReleaseCollection:input_type -> coreproto.CollectionName
-	7,  // 27: coreproto.CoreRpc.Insert:input_type -> coreproto.DatasetChange
-	7,  // 28: coreproto.CoreRpc.Update:input_type -> coreproto.DatasetChange
-	7,  // 29: coreproto.CoreRpc.Delete:input_type -> coreproto.DatasetChange
-	15, // 30: coreproto.CoreRpc.VectorSearch:input_type -> coreproto.SearchRequest
-	15, // 31: coreproto.CoreRpc.FilterSearch:input_type -> coreproto.SearchRequest
-	15, // 32: coreproto.CoreRpc.HybridSearch:input_type -> coreproto.SearchRequest
-	5,  // 33: coreproto.CoreRpc.CompareDist:input_type -> coreproto.CompXyDist
-	22, // 34: coreproto.CoreRpc.Ping:output_type -> google.protobuf.Empty
-	9,  // 35: coreproto.CoreRpc.CreateCollection:output_type -> coreproto.CollectionResponse
-	13, // 36: coreproto.CoreRpc.DropCollection:output_type -> coreproto.Response
-	18, // 37: coreproto.CoreRpc.CollectionInfof:output_type -> coreproto.CollectionMsg
-	18, // 38: coreproto.CoreRpc.LoadCollection:output_type -> coreproto.CollectionMsg
-	12, // 39: coreproto.CoreRpc.ReleaseCollection:output_type -> coreproto.ResponseWithMessage
-	13, // 40: coreproto.CoreRpc.Insert:output_type -> coreproto.Response
-	13, // 41: coreproto.CoreRpc.Update:output_type -> coreproto.Response
-	13, // 42: coreproto.CoreRpc.Delete:output_type -> coreproto.Response
-	17, // 43: coreproto.CoreRpc.VectorSearch:output_type -> coreproto.SearchResponse
-	17, // 44: coreproto.CoreRpc.FilterSearch:output_type -> coreproto.SearchResponse
-	17, // 45: coreproto.CoreRpc.HybridSearch:output_type -> coreproto.SearchResponse
-	6,  // 46: coreproto.CoreRpc.CompareDist:output_type -> coreproto.XyDist
-	34, // [34:47] is the sub-list for method output_type
-	21, // [21:34] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	3,  // 0: coreproto.CompXyDist.dist:type_name -> coreproto.Distance
+	35, // 1: coreproto.DatasetChange.metadata:type_name -> google.protobuf.Struct
+	6,  // 2: coreproto.DatasetChange.index_change_types:type_name -> coreproto.IndexChangeTypes
+	32, // 3: coreproto.DatasetChange.vectors:type_name -> coreproto.DatasetChange.VectorsEntry
+	13, // 4: coreproto.CollectionResponse.spec:type_name -> coreproto.CollectionSpec
+	22, // 5: coreproto.CollectionResponse.error:type_name -> coreproto.Error
+	19, // 6: coreproto.CollectionSpec.collection_config:type_name -> coreproto.HnswConfig
+	3,  // 7: coreproto.CollectionSpec.distance:type_name -> coreproto.Distance
+	4,  // 8: coreproto.CollectionSpec.compression_helper:type_name -> coreproto.Quantization
+	15, // 9: coreproto.CollectionSpec.quantization_config:type_name -> coreproto.QuantizationConfig
+	14, // 10: coreproto.CollectionSpec.vector_fields:type_name -> coreproto.VectorField
+	19, // 11: coreproto.VectorField.collection_config:type_name -> coreproto.HnswConfig
+	3,  // 12: coreproto.VectorField.distance:type_name -> coreproto.Distance
+	4,  // 13: coreproto.VectorField.compression_helper:type_name -> coreproto.Quantization
+	15, // 14: coreproto.VectorField.quantization_config:type_name -> coreproto.QuantizationConfig
+	1,  // 15: coreproto.CollectionConfigUpdate.search_algorithm:type_name -> coreproto.SearchAlgorithm
+	19, // 16: coreproto.RebuildIndexRequest.collection_config:type_name -> coreproto.HnswConfig
+	0,  // 17: coreproto.RebuildIndexRequest.source:type_name -> coreproto.RebuildSource
+	1,  // 18: coreproto.HnswConfig.search_algorithm:type_name -> coreproto.SearchAlgorithm
+	22, // 19: coreproto.ResponseWithMessage.error:type_name -> coreproto.Error
+	22, // 20: coreproto.Response.error:type_name -> coreproto.Error
+	5,  // 21: coreproto.Error.error_code:type_name -> coreproto.ErrorCode
+	33, // 22: coreproto.SearchRequest.filter:type_name -> coreproto.SearchRequest.FilterEntry
+	2,  // 23: coreproto.SearchRequest.profile:type_name -> coreproto.SearchProfile
+	24, // 24: coreproto.SearchRequest.field_queries:type_name -> coreproto.FieldQuery
+	35, // 25: coreproto.Candidates.metadata:type_name -> google.protobuf.Struct
+	22, // 26: coreproto.SearchResponse.error:type_name -> coreproto.Error
+	25, // 27: coreproto.SearchResponse.candidates:type_name -> coreproto.Candidates
+	27, // 28: coreproto.SearchResponse.explain:type_name -> coreproto.QueryPlan
+	29, // 29: coreproto.CollectionMsg.info:type_name -> coreproto.CollectionInfo
+	22, // 30: coreproto.CollectionMsg.error:type_name -> coreproto.Error
+	19, // 31: coreproto.CollectionInfo.collection_config:type_name -> coreproto.HnswConfig
+	3,  // 32: coreproto.CollectionInfo.distance:type_name -> coreproto.Distance
+	4,  // 33: coreproto.CollectionInfo.compression_helper:type_name -> coreproto.Quantization
+	14, // 34: coreproto.CollectionInfo.vector_fields:type_name -> coreproto.VectorField
+	18, // 35: coreproto.CollectionInfo.rebuild:type_name -> coreproto.RebuildProgress
+	6,  // 36: coreproto.ChangeEvent.change_type:type_name -> coreproto.IndexChangeTypes
+	35, // 37: coreproto.ChangeEvent.metadata:type_name -> google.protobuf.Struct
+	34, // 38: coreproto.ChangeEvent.vectors:type_name -> coreproto.ChangeEvent.VectorsEntry
+	10, // 39: coreproto.DatasetChange.VectorsEntry.value:type_name -> coreproto.NamedVector
+	10, // 40: coreproto.ChangeEvent.VectorsEntry.value:type_name -> coreproto.NamedVector
+	36, // 41: coreproto.CoreRpc.Ping:input_type -> google.protobuf.Empty
+	13, // 42: coreproto.CoreRpc.CreateCollection:input_type -> coreproto.CollectionSpec
+	11, // 43: coreproto.CoreRpc.DropCollection:input_type -> coreproto.CollectionName
+	11, // 44: coreproto.CoreRpc.CollectionInfof:input_type -> coreproto.CollectionName
+	11, // 45: coreproto.CoreRpc.LoadCollection:input_type -> coreproto.CollectionName
+	11, // 46: coreproto.CoreRpc.ReleaseCollection:input_type -> coreproto.CollectionName
+	16, // 47: coreproto.CoreRpc.UpdateCollectionConfig:input_type -> coreproto.CollectionConfigUpdate
+	17, // 48: coreproto.CoreRpc.RebuildIndex:input_type -> coreproto.RebuildIndexRequest
+	9,  // 49: coreproto.CoreRpc.Insert:input_type -> coreproto.DatasetChange
+	9,  // 50: coreproto.CoreRpc.Update:input_type -> coreproto.DatasetChange
+	9,  // 51: coreproto.CoreRpc.Delete:input_type -> coreproto.DatasetChange
+	23, // 52: coreproto.CoreRpc.VectorSearch:input_type -> coreproto.SearchRequest
+	23, // 53: coreproto.CoreRpc.FilterSearch:input_type -> coreproto.SearchRequest
+	23, // 54: coreproto.CoreRpc.HybridSearch:input_type -> coreproto.SearchRequest
+	7,  // 55: coreproto.CoreRpc.CompareDist:input_type -> coreproto.CompXyDist
+	30, // 56: coreproto.CoreRpc.Subscribe:input_type -> coreproto.SubscribeRequest
+	36, // 57: coreproto.CoreRpc.Ping:output_type -> google.protobuf.Empty
+	12, // 58: coreproto.CoreRpc.CreateCollection:output_type -> coreproto.CollectionResponse
+	21, // 59: coreproto.CoreRpc.DropCollection:output_type -> coreproto.Response
+	28, // 60: coreproto.CoreRpc.CollectionInfof:output_type -> coreproto.CollectionMsg
+	28, // 61: coreproto.CoreRpc.LoadCollection:output_type -> coreproto.CollectionMsg
+	20, // 62: coreproto.CoreRpc.ReleaseCollection:output_type -> coreproto.ResponseWithMessage
+	28, // 63: coreproto.CoreRpc.UpdateCollectionConfig:output_type -> coreproto.CollectionMsg
+	20, // 64: coreproto.CoreRpc.RebuildIndex:output_type -> coreproto.ResponseWithMessage
+	21, // 65: coreproto.CoreRpc.Insert:output_type -> coreproto.Response
+	21, // 66: coreproto.CoreRpc.Update:output_type -> coreproto.Response
+	21, // 67: coreproto.CoreRpc.Delete:output_type -> coreproto.Response
+	26, // 68: coreproto.CoreRpc.VectorSearch:output_type -> coreproto.SearchResponse
+	26, // 69: coreproto.CoreRpc.FilterSearch:output_type -> coreproto.SearchResponse
+	26, // 70: coreproto.CoreRpc.HybridSearch:output_type -> coreproto.SearchResponse
+	8,  // 71: coreproto.CoreRpc.CompareDist:output_type -> coreproto.XyDist
+	31, // 72: coreproto.CoreRpc.Subscribe:output_type -> coreproto.ChangeEvent
+	57, // [57:73] is the sub-list for method output_type
+	41, // [41:57] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
 }
 
 func init() { file_idl_proto_v3_core_proto_init() }
@@ -1669,13 +2839,14 @@ func file_idl_proto_v3_core_proto_init() {
 	if File_idl_proto_v3_core_proto != nil {
 		return
 	}
+	file_idl_proto_v3_core_proto_msgTypes[9].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_idl_proto_v3_core_proto_rawDesc,
-			NumEnums:      5,
-			NumMessages:   16,
+			NumEnums:      7,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
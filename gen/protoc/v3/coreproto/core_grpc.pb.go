@@ -37,19 +37,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CoreRpc_Ping_FullMethodName              = "/coreproto.CoreRpc/Ping"
-	CoreRpc_CreateCollection_FullMethodName  = "/coreproto.CoreRpc/CreateCollection"
-	CoreRpc_DropCollection_FullMethodName    = "/coreproto.CoreRpc/DropCollection"
-	CoreRpc_CollectionInfof_FullMethodName   = "/coreproto.CoreRpc/CollectionInfof"
-	CoreRpc_LoadCollection_FullMethodName    = "/coreproto.CoreRpc/LoadCollection"
-	CoreRpc_ReleaseCollection_FullMethodName = "/coreproto.CoreRpc/ReleaseCollection"
-	CoreRpc_Insert_FullMethodName            = "/coreproto.CoreRpc/Insert"
-	CoreRpc_Update_FullMethodName            = "/coreproto.CoreRpc/Update"
-	CoreRpc_Delete_FullMethodName            = "/coreproto.CoreRpc/Delete"
-	CoreRpc_VectorSearch_FullMethodName      = "/coreproto.CoreRpc/VectorSearch"
-	CoreRpc_FilterSearch_FullMethodName      = "/coreproto.CoreRpc/FilterSearch"
-	CoreRpc_HybridSearch_FullMethodName      = "/coreproto.CoreRpc/HybridSearch"
-	CoreRpc_CompareDist_FullMethodName       = "/coreproto.CoreRpc/CompareDist"
+	CoreRpc_Ping_FullMethodName                   = "/coreproto.CoreRpc/Ping"
+	CoreRpc_CreateCollection_FullMethodName       = "/coreproto.CoreRpc/CreateCollection"
+	CoreRpc_DropCollection_FullMethodName         = "/coreproto.CoreRpc/DropCollection"
+	CoreRpc_CollectionInfof_FullMethodName        = "/coreproto.CoreRpc/CollectionInfof"
+	CoreRpc_LoadCollection_FullMethodName         = "/coreproto.CoreRpc/LoadCollection"
+	CoreRpc_ReleaseCollection_FullMethodName      = "/coreproto.CoreRpc/ReleaseCollection"
+	CoreRpc_UpdateCollectionConfig_FullMethodName = "/coreproto.CoreRpc/UpdateCollectionConfig"
+	CoreRpc_RebuildIndex_FullMethodName           = "/coreproto.CoreRpc/RebuildIndex"
+	CoreRpc_Insert_FullMethodName                 = "/coreproto.CoreRpc/Insert"
+	CoreRpc_Update_FullMethodName                 = "/coreproto.CoreRpc/Update"
+	CoreRpc_Delete_FullMethodName                 = "/coreproto.CoreRpc/Delete"
+	CoreRpc_VectorSearch_FullMethodName           = "/coreproto.CoreRpc/VectorSearch"
+	CoreRpc_FilterSearch_FullMethodName           = "/coreproto.CoreRpc/FilterSearch"
+	CoreRpc_HybridSearch_FullMethodName           = "/coreproto.CoreRpc/HybridSearch"
+	CoreRpc_CompareDist_FullMethodName            = "/coreproto.CoreRpc/CompareDist"
+	CoreRpc_Subscribe_FullMethodName              = "/coreproto.CoreRpc/Subscribe"
 )
 
 // CoreRpcClient is the client API for CoreRpc service.
@@ -62,6 +65,8 @@ type CoreRpcClient interface {
 	CollectionInfof(ctx context.Context, in *CollectionName, opts ...grpc.CallOption) (*CollectionMsg, error)
 	LoadCollection(ctx context.Context, in *CollectionName, opts ...grpc.CallOption) (*CollectionMsg, error)
 	ReleaseCollection(ctx context.Context, in *CollectionName, opts ...grpc.CallOption) (*ResponseWithMessage, error)
+	UpdateCollectionConfig(ctx context.Context, in *CollectionConfigUpdate, opts ...grpc.CallOption) (*CollectionMsg, error)
+	RebuildIndex(ctx context.Context, in *RebuildIndexRequest, opts ...grpc.CallOption) (*ResponseWithMessage, error)
 	Insert(ctx context.Context, in *DatasetChange, opts ...grpc.CallOption) (*Response, error)
 	Update(ctx context.Context, in *DatasetChange, opts ...grpc.CallOption) (*Response, error)
 	Delete(ctx context.Context, in *DatasetChange, opts ...grpc.CallOption) (*Response, error)
@@ -69,6 +74,7 @@ type CoreRpcClient interface {
 	FilterSearch(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
 	HybridSearch(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
 	CompareDist(ctx context.Context, in *CompXyDist, opts ...grpc.CallOption) (*XyDist, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangeEvent], error)
 }
 
 type coreRpcClient struct {
@@ -139,6 +145,26 @@ func (c *coreRpcClient) ReleaseCollection(ctx context.Context, in *CollectionNam
 	return out, nil
 }
 
+func (c *coreRpcClient) UpdateCollectionConfig(ctx context.Context, in *CollectionConfigUpdate, opts ...grpc.CallOption) (*CollectionMsg, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionMsg)
+	err := c.cc.Invoke(ctx, CoreRpc_UpdateCollectionConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreRpcClient) RebuildIndex(ctx context.Context, in *RebuildIndexRequest, opts ...grpc.CallOption) (*ResponseWithMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseWithMessage)
+	err := c.cc.Invoke(ctx, CoreRpc_RebuildIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *coreRpcClient) Insert(ctx context.Context, in *DatasetChange, opts ...grpc.CallOption) (*Response, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Response)
@@ -209,6 +235,25 @@ func (c *coreRpcClient) CompareDist(ctx context.Context, in *CompXyDist, opts ..
 	return out, nil
 }
 
+func (c *coreRpcClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangeEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CoreRpc_ServiceDesc.Streams[0], CoreRpc_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, ChangeEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CoreRpc_SubscribeClient = grpc.ServerStreamingClient[ChangeEvent]
+
 // CoreRpcServer is the server API for CoreRpc service.
 // All implementations should embed UnimplementedCoreRpcServer
 // for forward compatibility.
@@ -219,6 +264,8 @@ type CoreRpcServer interface {
 	CollectionInfof(context.Context, *CollectionName) (*CollectionMsg, error)
 	LoadCollection(context.Context, *CollectionName) (*CollectionMsg, error)
 	ReleaseCollection(context.Context, *CollectionName) (*ResponseWithMessage, error)
+	UpdateCollectionConfig(context.Context, *CollectionConfigUpdate) (*CollectionMsg, error)
+	RebuildIndex(context.Context, *RebuildIndexRequest) (*ResponseWithMessage, error)
 	Insert(context.Context, *DatasetChange) (*Response, error)
 	Update(context.Context, *DatasetChange) (*Response, error)
 	Delete(context.Context, *DatasetChange) (*Response, error)
@@ -226,6 +273,7 @@ type CoreRpcServer interface {
 	FilterSearch(context.Context, *SearchRequest) (*SearchResponse, error)
 	HybridSearch(context.Context, *SearchRequest) (*SearchResponse, error)
 	CompareDist(context.Context, *CompXyDist) (*XyDist, error)
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[ChangeEvent]) error
 }
 
 // UnimplementedCoreRpcServer should be embedded to have
@@ -253,6 +301,12 @@ func (UnimplementedCoreRpcServer) LoadCollection(context.Context, *CollectionNam
 func (UnimplementedCoreRpcServer) ReleaseCollection(context.Context, *CollectionName) (*ResponseWithMessage, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReleaseCollection not implemented")
 }
+func (UnimplementedCoreRpcServer) UpdateCollectionConfig(context.Context, *CollectionConfigUpdate) (*CollectionMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCollectionConfig not implemented")
+}
+func (UnimplementedCoreRpcServer) RebuildIndex(context.Context, *RebuildIndexRequest) (*ResponseWithMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildIndex not implemented")
+}
 func (UnimplementedCoreRpcServer) Insert(context.Context, *DatasetChange) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Insert not implemented")
 }
@@ -274,6 +328,9 @@ func (UnimplementedCoreRpcServer) HybridSearch(context.Context, *SearchRequest)
 func (UnimplementedCoreRpcServer) CompareDist(context.Context, *CompXyDist) (*XyDist, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CompareDist not implemented")
 }
+func (UnimplementedCoreRpcServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[ChangeEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
 func (UnimplementedCoreRpcServer) testEmbeddedByValue() {}
 
 // UnsafeCoreRpcServer may be embedded to opt out of forward compatibility for this service.
@@ -402,6 +459,42 @@ func _CoreRpc_ReleaseCollection_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CoreRpc_UpdateCollectionConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionConfigUpdate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreRpcServer).UpdateCollectionConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreRpc_UpdateCollectionConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreRpcServer).UpdateCollectionConfig(ctx, req.(*CollectionConfigUpdate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoreRpc_RebuildIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebuildIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreRpcServer).RebuildIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoreRpc_RebuildIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreRpcServer).RebuildIndex(ctx, req.(*RebuildIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CoreRpc_Insert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DatasetChange)
 	if err := dec(in); err != nil {
@@ -528,6 +621,17 @@ func _CoreRpc_CompareDist_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CoreRpc_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CoreRpcServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, ChangeEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CoreRpc_SubscribeServer = grpc.ServerStreamingServer[ChangeEvent]
+
 // CoreRpc_ServiceDesc is the grpc.ServiceDesc for CoreRpc service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -559,6 +663,14 @@ var CoreRpc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReleaseCollection",
 			Handler:    _CoreRpc_ReleaseCollection_Handler,
 		},
+		{
+			MethodName: "UpdateCollectionConfig",
+			Handler:    _CoreRpc_UpdateCollectionConfig_Handler,
+		},
+		{
+			MethodName: "RebuildIndex",
+			Handler:    _CoreRpc_RebuildIndex_Handler,
+		},
 		{
 			MethodName: "Insert",
 			Handler:    _CoreRpc_Insert_Handler,
@@ -588,6 +700,12 @@ var CoreRpc_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _CoreRpc_CompareDist_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _CoreRpc_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "idl/proto/v3/core.proto",
 }
@@ -44,19 +44,24 @@ type Collection struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CollectionName            string  `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	LevelMultiplier           float32 `protobuf:"fixed32,2,opt,name=level_multiplier,json=levelMultiplier,proto3" json:"level_multiplier,omitempty"`
-	Ef                        int32   `protobuf:"varint,3,opt,name=ef,proto3" json:"ef,omitempty"`
-	EfConstruction            int32   `protobuf:"varint,4,opt,name=ef_construction,json=efConstruction,proto3" json:"ef_construction,omitempty"`
-	M                         int32   `protobuf:"varint,5,opt,name=m,proto3" json:"m,omitempty"`
-	MMax                      int32   `protobuf:"varint,6,opt,name=m_max,json=mMax,proto3" json:"m_max,omitempty"`
-	MMax0                     int32   `protobuf:"varint,7,opt,name=m_max0,json=mMax0,proto3" json:"m_max0,omitempty"`
-	HeuristicExtendCandidates bool    `protobuf:"varint,8,opt,name=heuristic_extend_candidates,json=heuristicExtendCandidates,proto3" json:"heuristic_extend_candidates,omitempty"`
-	HeuristicKeepPruned       bool    `protobuf:"varint,9,opt,name=heuristic_keep_pruned,json=heuristicKeepPruned,proto3" json:"heuristic_keep_pruned,omitempty"`
-	SearchAlgorithm           string  `protobuf:"bytes,10,opt,name=search_algorithm,json=searchAlgorithm,proto3" json:"search_algorithm,omitempty"`
-	VectorDimension           uint32  `protobuf:"varint,11,opt,name=vector_dimension,json=vectorDimension,proto3" json:"vector_dimension,omitempty"`
-	Distance                  string  `protobuf:"bytes,12,opt,name=distance,proto3" json:"distance,omitempty"`
-	Quantization              string  `protobuf:"bytes,13,opt,name=quantization,proto3" json:"quantization,omitempty"`
+	CollectionName            string        `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	LevelMultiplier           float32       `protobuf:"fixed32,2,opt,name=level_multiplier,json=levelMultiplier,proto3" json:"level_multiplier,omitempty"`
+	Ef                        int32         `protobuf:"varint,3,opt,name=ef,proto3" json:"ef,omitempty"`
+	EfConstruction            int32         `protobuf:"varint,4,opt,name=ef_construction,json=efConstruction,proto3" json:"ef_construction,omitempty"`
+	M                         int32         `protobuf:"varint,5,opt,name=m,proto3" json:"m,omitempty"`
+	MMax                      int32         `protobuf:"varint,6,opt,name=m_max,json=mMax,proto3" json:"m_max,omitempty"`
+	MMax0                     int32         `protobuf:"varint,7,opt,name=m_max0,json=mMax0,proto3" json:"m_max0,omitempty"`
+	HeuristicExtendCandidates bool          `protobuf:"varint,8,opt,name=heuristic_extend_candidates,json=heuristicExtendCandidates,proto3" json:"heuristic_extend_candidates,omitempty"`
+	HeuristicKeepPruned       bool          `protobuf:"varint,9,opt,name=heuristic_keep_pruned,json=heuristicKeepPruned,proto3" json:"heuristic_keep_pruned,omitempty"`
+	SearchAlgorithm           string        `protobuf:"bytes,10,opt,name=search_algorithm,json=searchAlgorithm,proto3" json:"search_algorithm,omitempty"`
+	VectorDimension           uint32        `protobuf:"varint,11,opt,name=vector_dimension,json=vectorDimension,proto3" json:"vector_dimension,omitempty"`
+	Distance                  string        `protobuf:"bytes,12,opt,name=distance,proto3" json:"distance,omitempty"`
+	Quantization              string        `protobuf:"bytes,13,opt,name=quantization,proto3" json:"quantization,omitempty"`
+	PqSubspaces               uint32        `protobuf:"varint,14,opt,name=pq_subspaces,json=pqSubspaces,proto3" json:"pq_subspaces,omitempty"`
+	PqCentroids               uint32        `protobuf:"varint,15,opt,name=pq_centroids,json=pqCentroids,proto3" json:"pq_centroids,omitempty"`
+	PqTrainSize               uint32        `protobuf:"varint,16,opt,name=pq_train_size,json=pqTrainSize,proto3" json:"pq_train_size,omitempty"`
+	VectorFields              []*Collection `protobuf:"bytes,17,rep,name=vector_fields,json=vectorFields,proto3" json:"vector_fields,omitempty"` // collection_name => field name
+	DefaultTtlSeconds         uint64        `protobuf:"varint,18,opt,name=default_ttl_seconds,json=defaultTtlSeconds,proto3" json:"default_ttl_seconds,omitempty"`
 }
 
 func (x *Collection) Reset() {
@@ -180,15 +185,52 @@ func (x *Collection) GetQuantization() string {
 	return ""
 }
 
+func (x *Collection) GetPqSubspaces() uint32 {
+	if x != nil {
+		return x.PqSubspaces
+	}
+	return 0
+}
+
+func (x *Collection) GetPqCentroids() uint32 {
+	if x != nil {
+		return x.PqCentroids
+	}
+	return 0
+}
+
+func (x *Collection) GetPqTrainSize() uint32 {
+	if x != nil {
+		return x.PqTrainSize
+	}
+	return 0
+}
+
+func (x *Collection) GetVectorFields() []*Collection {
+	if x != nil {
+		return x.VectorFields
+	}
+	return nil
+}
+
+func (x *Collection) GetDefaultTtlSeconds() uint64 {
+	if x != nil {
+		return x.DefaultTtlSeconds
+	}
+	return 0
+}
+
 type Dataset struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CollectionUniqueId uint64           `protobuf:"varint,1,opt,name=collection_unique_id,json=collectionUniqueId,proto3" json:"collection_unique_id,omitempty"`
-	UserSpecificId     string           `protobuf:"bytes,2,opt,name=user_specific_id,json=userSpecificId,proto3" json:"user_specific_id,omitempty"`
-	Vector             []float32        `protobuf:"fixed32,3,rep,packed,name=vector,proto3" json:"vector,omitempty"`
-	Metadata           *structpb.Struct `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	CollectionUniqueId uint64                  `protobuf:"varint,1,opt,name=collection_unique_id,json=collectionUniqueId,proto3" json:"collection_unique_id,omitempty"`
+	UserSpecificId     string                  `protobuf:"bytes,2,opt,name=user_specific_id,json=userSpecificId,proto3" json:"user_specific_id,omitempty"`
+	Vector             []float32               `protobuf:"fixed32,3,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+	Metadata           *structpb.Struct        `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Vectors            map[string]*NamedVector `protobuf:"bytes,5,rep,name=vectors,proto3" json:"vectors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ExpireAt           int64                   `protobuf:"varint,6,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"` // unix nano, 0 => never
 }
 
 func (x *Dataset) Reset() {
@@ -249,6 +291,65 @@ func (x *Dataset) GetMetadata() *structpb.Struct {
 	return nil
 }
 
+func (x *Dataset) GetVectors() map[string]*NamedVector {
+	if x != nil {
+		return x.Vectors
+	}
+	return nil
+}
+
+func (x *Dataset) GetExpireAt() int64 {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return 0
+}
+
+type NamedVector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vector []float32 `protobuf:"fixed32,1,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+}
+
+func (x *NamedVector) Reset() {
+	*x = NamedVector{}
+	mi := &file_idl_proto_v3_disk_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamedVector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamedVector) ProtoMessage() {}
+
+func (x *NamedVector) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v3_disk_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamedVector.ProtoReflect.Descriptor instead.
+func (*NamedVector) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v3_disk_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NamedVector) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
 var File_idl_proto_v3_disk_proto protoreflect.FileDescriptor
 
 var file_idl_proto_v3_disk_proto_rawDesc = []byte{
@@ -256,7 +357,7 @@ var file_idl_proto_v3_disk_proto_rawDesc = []byte{
 	0x69, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x22, 0xdd, 0x03, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x74, 0x6f, 0x22, 0xb3, 0x05, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
 	0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
 	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c,
 	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x65,
@@ -286,19 +387,46 @@ var file_idl_proto_v3_disk_proto_rawDesc = []byte{
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x22,
 	0x0a, 0x0c, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x22, 0xb2, 0x01, 0x0a, 0x07, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x30,
-	0x0a, 0x14, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x6e, 0x69,
-	0x71, 0x75, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x63, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x49, 0x64,
-	0x12, 0x28, 0x0a, 0x10, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69,
-	0x63, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x75, 0x73, 0x65, 0x72,
-	0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x64, 0x69, 0x73,
-	0x6b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x71, 0x5f, 0x73, 0x75, 0x62, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x71, 0x53, 0x75, 0x62, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x71, 0x5f, 0x63, 0x65, 0x6e, 0x74,
+	0x72, 0x6f, 0x69, 0x64, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x71, 0x43,
+	0x65, 0x6e, 0x74, 0x72, 0x6f, 0x69, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x71, 0x5f, 0x74,
+	0x72, 0x61, 0x69, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x70, 0x71, 0x54, 0x72, 0x61, 0x69, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x3a, 0x0a, 0x0d,
+	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x11, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x69, 0x73, 0x6b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x76, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x5f, 0x74, 0x74, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x12, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x54, 0x74,
+	0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0xde, 0x02, 0x0a, 0x07, 0x44, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x12, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e,
+	0x69, 0x71, 0x75, 0x65, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x73,
+	0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x75, 0x73, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x02,
+	0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x39, 0x0a,
+	0x07, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x64, 0x69, 0x73, 0x6b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73,
+	0x65, 0x74, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x07, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x41, 0x74, 0x1a, 0x52, 0x0a, 0x0c, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64, 0x69, 0x73, 0x6b, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x25, 0x0a, 0x0b, 0x4e, 0x61, 0x6d,
+	0x65, 0x64, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x64, 0x69, 0x73, 0x6b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -313,19 +441,24 @@ func file_idl_proto_v3_disk_proto_rawDescGZIP() []byte {
 	return file_idl_proto_v3_disk_proto_rawDescData
 }
 
-var file_idl_proto_v3_disk_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_idl_proto_v3_disk_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_idl_proto_v3_disk_proto_goTypes = []any{
 	(*Collection)(nil),      // 0: diskproto.Collection
 	(*Dataset)(nil),         // 1: diskproto.Dataset
-	(*structpb.Struct)(nil), // 2: google.protobuf.Struct
+	(*NamedVector)(nil),     // 2: diskproto.NamedVector
+	nil,                     // 3: diskproto.Dataset.VectorsEntry
+	(*structpb.Struct)(nil), // 4: google.protobuf.Struct
 }
 var file_idl_proto_v3_disk_proto_depIdxs = []int32{
-	2, // 0: diskproto.Dataset.metadata:type_name -> google.protobuf.Struct
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0, // 0: diskproto.Collection.vector_fields:type_name -> diskproto.Collection
+	4, // 1: diskproto.Dataset.metadata:type_name -> google.protobuf.Struct
+	3, // 2: diskproto.Dataset.vectors:type_name -> diskproto.Dataset.VectorsEntry
+	2, // 3: diskproto.Dataset.VectorsEntry.value:type_name -> diskproto.NamedVector
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_idl_proto_v3_disk_proto_init() }
@@ -339,7 +472,7 @@ func file_idl_proto_v3_disk_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_idl_proto_v3_disk_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
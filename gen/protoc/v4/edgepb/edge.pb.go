@@ -42,10 +42,15 @@ const (
 type IndexType int32
 
 const (
-	IndexType_String  IndexType = 0
-	IndexType_Integer IndexType = 1
-	IndexType_Float   IndexType = 2
-	IndexType_Boolean IndexType = 3
+	IndexType_String       IndexType = 0
+	IndexType_Integer      IndexType = 1
+	IndexType_Float        IndexType = 2
+	IndexType_Boolean      IndexType = 3
+	IndexType_StringArray  IndexType = 4 // each element is indexed
+	IndexType_IntegerArray IndexType = 5
+	IndexType_FloatArray   IndexType = 6
+	IndexType_BooleanArray IndexType = 7
+	IndexType_GeoPoint     IndexType = 8 // {"lat": degrees, "lon": degrees}
 )
 
 // Enum value maps for IndexType.
@@ -55,12 +60,22 @@ var (
 		1: "Integer",
 		2: "Float",
 		3: "Boolean",
+		4: "StringArray",
+		5: "IntegerArray",
+		6: "FloatArray",
+		7: "BooleanArray",
+		8: "GeoPoint",
 	}
 	IndexType_value = map[string]int32{
-		"String":  0,
-		"Integer": 1,
-		"Float":   2,
-		"Boolean": 3,
+		"String":       0,
+		"Integer":      1,
+		"Float":        2,
+		"Boolean":      3,
+		"StringArray":  4,
+		"IntegerArray": 5,
+		"FloatArray":   6,
+		"BooleanArray": 7,
+		"GeoPoint":     8,
 	}
 )
 
@@ -250,7 +265,7 @@ func (ErrorCode) EnumDescriptor() ([]byte, []int) {
 type IndexChagedType int32
 
 const (
-	// Insert Or Update
+	//Insert Or Update
 	IndexChagedType_CHANGED IndexChagedType = 0
 	IndexChagedType_DELETE  IndexChagedType = 1
 )
@@ -294,11 +309,107 @@ func (IndexChagedType) EnumDescriptor() ([]byte, []int) {
 	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{4}
 }
 
+type SearchMode int32
+
+const (
+	SearchMode_VECTOR SearchMode = 0
+	SearchMode_TEXT   SearchMode = 1 // BM25 over the fulltext indexes
+	SearchMode_HYBRID SearchMode = 2 // vector and BM25 rankings fused
+)
+
+// Enum value maps for SearchMode.
+var (
+	SearchMode_name = map[int32]string{
+		0: "VECTOR",
+		1: "TEXT",
+		2: "HYBRID",
+	}
+	SearchMode_value = map[string]int32{
+		"VECTOR": 0,
+		"TEXT":   1,
+		"HYBRID": 2,
+	}
+)
+
+func (x SearchMode) Enum() *SearchMode {
+	p := new(SearchMode)
+	*p = x
+	return p
+}
+
+func (x SearchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_proto_v4_edge_proto_enumTypes[5].Descriptor()
+}
+
+func (SearchMode) Type() protoreflect.EnumType {
+	return &file_idl_proto_v4_edge_proto_enumTypes[5]
+}
+
+func (x SearchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchMode.Descriptor instead.
+func (SearchMode) EnumDescriptor() ([]byte, []int) {
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{5}
+}
+
+type FusionMethod int32
+
+const (
+	FusionMethod_RRF      FusionMethod = 0 // reciprocal rank fusion, sum of 1 / (rrf_k + rank)
+	FusionMethod_WEIGHTED FusionMethod = 1 // weighted sum of the min-max normalized scores
+)
+
+// Enum value maps for FusionMethod.
+var (
+	FusionMethod_name = map[int32]string{
+		0: "RRF",
+		1: "WEIGHTED",
+	}
+	FusionMethod_value = map[string]int32{
+		"RRF":      0,
+		"WEIGHTED": 1,
+	}
+)
+
+func (x FusionMethod) Enum() *FusionMethod {
+	p := new(FusionMethod)
+	*p = x
+	return p
+}
+
+func (x FusionMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FusionMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_proto_v4_edge_proto_enumTypes[6].Descriptor()
+}
+
+func (FusionMethod) Type() protoreflect.EnumType {
+	return &file_idl_proto_v4_edge_proto_enumTypes[6]
+}
+
+func (x FusionMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FusionMethod.Descriptor instead.
+func (FusionMethod) EnumDescriptor() ([]byte, []int) {
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{6}
+}
+
 type LogicalOperator int32
 
 const (
 	LogicalOperator_AND LogicalOperator = 0
 	LogicalOperator_OR  LogicalOperator = 1
+	LogicalOperator_NOT LogicalOperator = 2 // complement of the single expression
 )
 
 // Enum value maps for LogicalOperator.
@@ -306,10 +417,12 @@ var (
 	LogicalOperator_name = map[int32]string{
 		0: "AND",
 		1: "OR",
+		2: "NOT",
 	}
 	LogicalOperator_value = map[string]int32{
 		"AND": 0,
 		"OR":  1,
+		"NOT": 2,
 	}
 )
 
@@ -324,11 +437,11 @@ func (x LogicalOperator) String() string {
 }
 
 func (LogicalOperator) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v4_edge_proto_enumTypes[5].Descriptor()
+	return file_idl_proto_v4_edge_proto_enumTypes[7].Descriptor()
 }
 
 func (LogicalOperator) Type() protoreflect.EnumType {
-	return &file_idl_proto_v4_edge_proto_enumTypes[5]
+	return &file_idl_proto_v4_edge_proto_enumTypes[7]
 }
 
 func (x LogicalOperator) Number() protoreflect.EnumNumber {
@@ -337,37 +450,76 @@ func (x LogicalOperator) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use LogicalOperator.Descriptor instead.
 func (LogicalOperator) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{5}
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{7}
 }
 
 type Op int32
 
 const (
-	Op_EQ  Op = 0 // equal  ==
-	Op_NEQ Op = 1 // Not Equal !=
-	Op_GT  Op = 2 // greater than >
-	Op_GTE Op = 3 // =>
-	Op_LT  Op = 4 //less than <
-	Op_LTE Op = 5 // <=
+	Op_EQ          Op = 0  // equal  ==
+	Op_NEQ         Op = 1  // Not Equal !=
+	Op_GT          Op = 2  // greater than >
+	Op_GTE         Op = 3  // =>
+	Op_LT          Op = 4  //less than <
+	Op_LTE         Op = 5  // <=
+	Op_IN          Op = 6  // any of values
+	Op_NOT_IN      Op = 7  // none of values
+	Op_BETWEEN     Op = 8  // lower <= x <= upper
+	Op_IS_NULL     Op = 9  // index is not set
+	Op_IS_NOT_NULL Op = 10 // index is set
+	Op_PREFIX      Op = 11 // string starts with string_val
+	Op_CONTAINS    Op = 12 // string contains string_val
+	Op_REGEX       Op = 13 // string fully matches the string_val pattern
+	Op_ANY_OF      Op = 14 // array has any of values
+	Op_ALL_OF      Op = 15 // array has all of values
+	Op_NONE_OF     Op = 16 // array has none of values
+	Op_GEO_RADIUS  Op = 17 // point within radius_meters of center
+	Op_GEO_BBOX    Op = 18 // point inside south_west and north_east
 )
 
 // Enum value maps for Op.
 var (
 	Op_name = map[int32]string{
-		0: "EQ",
-		1: "NEQ",
-		2: "GT",
-		3: "GTE",
-		4: "LT",
-		5: "LTE",
+		0:  "EQ",
+		1:  "NEQ",
+		2:  "GT",
+		3:  "GTE",
+		4:  "LT",
+		5:  "LTE",
+		6:  "IN",
+		7:  "NOT_IN",
+		8:  "BETWEEN",
+		9:  "IS_NULL",
+		10: "IS_NOT_NULL",
+		11: "PREFIX",
+		12: "CONTAINS",
+		13: "REGEX",
+		14: "ANY_OF",
+		15: "ALL_OF",
+		16: "NONE_OF",
+		17: "GEO_RADIUS",
+		18: "GEO_BBOX",
 	}
 	Op_value = map[string]int32{
-		"EQ":  0,
-		"NEQ": 1,
-		"GT":  2,
-		"GTE": 3,
-		"LT":  4,
-		"LTE": 5,
+		"EQ":          0,
+		"NEQ":         1,
+		"GT":          2,
+		"GTE":         3,
+		"LT":          4,
+		"LTE":         5,
+		"IN":          6,
+		"NOT_IN":      7,
+		"BETWEEN":     8,
+		"IS_NULL":     9,
+		"IS_NOT_NULL": 10,
+		"PREFIX":      11,
+		"CONTAINS":    12,
+		"REGEX":       13,
+		"ANY_OF":      14,
+		"ALL_OF":      15,
+		"NONE_OF":     16,
+		"GEO_RADIUS":  17,
+		"GEO_BBOX":    18,
 	}
 )
 
@@ -382,11 +534,11 @@ func (x Op) String() string {
 }
 
 func (Op) Descriptor() protoreflect.EnumDescriptor {
-	return file_idl_proto_v4_edge_proto_enumTypes[6].Descriptor()
+	return file_idl_proto_v4_edge_proto_enumTypes[8].Descriptor()
 }
 
 func (Op) Type() protoreflect.EnumType {
-	return &file_idl_proto_v4_edge_proto_enumTypes[6]
+	return &file_idl_proto_v4_edge_proto_enumTypes[8]
 }
 
 func (x Op) Number() protoreflect.EnumNumber {
@@ -395,7 +547,56 @@ func (x Op) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Op.Descriptor instead.
 func (Op) EnumDescriptor() ([]byte, []int) {
-	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{6}
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{8}
+}
+
+type ChangeEventType int32
+
+const (
+	ChangeEventType_CHANGE_INSERT ChangeEventType = 0
+	ChangeEventType_CHANGE_UPDATE ChangeEventType = 1
+	ChangeEventType_CHANGE_DELETE ChangeEventType = 2
+)
+
+// Enum value maps for ChangeEventType.
+var (
+	ChangeEventType_name = map[int32]string{
+		0: "CHANGE_INSERT",
+		1: "CHANGE_UPDATE",
+		2: "CHANGE_DELETE",
+	}
+	ChangeEventType_value = map[string]int32{
+		"CHANGE_INSERT": 0,
+		"CHANGE_UPDATE": 1,
+		"CHANGE_DELETE": 2,
+	}
+)
+
+func (x ChangeEventType) Enum() *ChangeEventType {
+	p := new(ChangeEventType)
+	*p = x
+	return p
+}
+
+func (x ChangeEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_idl_proto_v4_edge_proto_enumTypes[9].Descriptor()
+}
+
+func (ChangeEventType) Type() protoreflect.EnumType {
+	return &file_idl_proto_v4_edge_proto_enumTypes[9]
+}
+
+func (x ChangeEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeEventType.Descriptor instead.
+func (ChangeEventType) EnumDescriptor() ([]byte, []int) {
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{9}
 }
 
 type CollectionName struct {
@@ -456,12 +657,13 @@ type Collection struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CollectionName string       `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	Index          []*Index     `protobuf:"bytes,2,rep,name=index,proto3" json:"index,omitempty"`
-	Distance       Distance     `protobuf:"varint,3,opt,name=distance,proto3,enum=edgepb.Distance" json:"distance,omitempty"`
-	Quantization   Quantization `protobuf:"varint,4,opt,name=quantization,proto3,enum=edgepb.Quantization" json:"quantization,omitempty"`
-	Dim            uint32       `protobuf:"varint,5,opt,name=dim,proto3" json:"dim,omitempty"`
-	Versioning     bool         `protobuf:"varint,6,opt,name=versioning,proto3" json:"versioning,omitempty"`
+	CollectionName    string       `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Index             []*Index     `protobuf:"bytes,2,rep,name=index,proto3" json:"index,omitempty"`
+	Distance          Distance     `protobuf:"varint,3,opt,name=distance,proto3,enum=edgepb.Distance" json:"distance,omitempty"`
+	Quantization      Quantization `protobuf:"varint,4,opt,name=quantization,proto3,enum=edgepb.Quantization" json:"quantization,omitempty"`
+	Dim               uint32       `protobuf:"varint,5,opt,name=dim,proto3" json:"dim,omitempty"`
+	Versioning        bool         `protobuf:"varint,6,opt,name=versioning,proto3" json:"versioning,omitempty"`
+	DefaultTtlSeconds uint64       `protobuf:"varint,7,opt,name=default_ttl_seconds,json=defaultTtlSeconds,proto3" json:"default_ttl_seconds,omitempty"` // 0 => documents never expire
 }
 
 func (x *Collection) Reset() {
@@ -536,6 +738,13 @@ func (x *Collection) GetVersioning() bool {
 	return false
 }
 
+func (x *Collection) GetDefaultTtlSeconds() uint64 {
+	if x != nil {
+		return x.DefaultTtlSeconds
+	}
+	return 0
+}
+
 type CollectionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -606,6 +815,11 @@ type Index struct {
 	IndexType  IndexType `protobuf:"varint,2,opt,name=index_type,json=indexType,proto3,enum=edgepb.IndexType" json:"index_type,omitempty"`
 	EnableNull bool      `protobuf:"varint,3,opt,name=enable_null,json=enableNull,proto3" json:"enable_null,omitempty"`
 	PrimaryKey bool      `protobuf:"varint,4,opt,name=primary_key,json=primaryKey,proto3" json:"primary_key,omitempty"`
+	// stored with the vertex but not indexed for filtering, the type is not checked
+	StoredOnly bool `protobuf:"varint,5,opt,name=stored_only,json=storedOnly,proto3" json:"stored_only,omitempty"`
+	// BM25 keyword index of a String index, searched by SearchIndex.text_query
+	Fulltext bool   `protobuf:"varint,6,opt,name=fulltext,proto3" json:"fulltext,omitempty"`
+	Analyzer string `protobuf:"bytes,7,opt,name=analyzer,proto3" json:"analyzer,omitempty"` // fulltext analyzer: standard(default), simple or english
 }
 
 func (x *Index) Reset() {
@@ -666,6 +880,27 @@ func (x *Index) GetPrimaryKey() bool {
 	return false
 }
 
+func (x *Index) GetStoredOnly() bool {
+	if x != nil {
+		return x.StoredOnly
+	}
+	return false
+}
+
+func (x *Index) GetFulltext() bool {
+	if x != nil {
+		return x.Fulltext
+	}
+	return false
+}
+
+func (x *Index) GetAnalyzer() string {
+	if x != nil {
+		return x.Analyzer
+	}
+	return ""
+}
+
 type Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -920,6 +1155,7 @@ type IndexChange struct {
 	Metadata       *structpb.Struct `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	Vectors        []float32        `protobuf:"fixed32,4,rep,packed,name=vectors,proto3" json:"vectors,omitempty"`
 	Changed        IndexChagedType  `protobuf:"varint,5,opt,name=changed,proto3,enum=edgepb.IndexChagedType" json:"changed,omitempty"`
+	TtlSeconds     uint64           `protobuf:"varint,6,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"` // 0 => collection default_ttl_seconds
 }
 
 func (x *IndexChange) Reset() {
@@ -987,6 +1223,13 @@ func (x *IndexChange) GetChanged() IndexChagedType {
 	return IndexChagedType_CHANGED
 }
 
+func (x *IndexChange) GetTtlSeconds() uint64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
 type SearchIndex struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -998,6 +1241,15 @@ type SearchIndex struct {
 	Limit                 uint64            `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
 	FilterExpression      *FilterExpression `protobuf:"bytes,5,opt,name=filter_expression,json=filterExpression,proto3" json:"filter_expression,omitempty"`
 	HighResourceAvaliable bool              `protobuf:"varint,6,opt,name=high_resource_avaliable,json=highResourceAvaliable,proto3" json:"high_resource_avaliable,omitempty"`
+	Mode                  SearchMode        `protobuf:"varint,7,opt,name=mode,proto3,enum=edgepb.SearchMode" json:"mode,omitempty"`
+	TextQuery             string            `protobuf:"bytes,8,opt,name=text_query,json=textQuery,proto3" json:"text_query,omitempty"`             // keywords of TEXT and HYBRID modes
+	TextFields            []string          `protobuf:"bytes,9,rep,name=text_fields,json=textFields,proto3" json:"text_fields,omitempty"`          // fulltext indexes to match, empty => all
+	Fusion                FusionMethod      `protobuf:"varint,10,opt,name=fusion,proto3,enum=edgepb.FusionMethod" json:"fusion,omitempty"`         // HYBRID: how the vector and text rankings are combined
+	VectorWeight          float32           `protobuf:"fixed32,11,opt,name=vector_weight,json=vectorWeight,proto3" json:"vector_weight,omitempty"` // WEIGHTED: share of the vector score, the text score gets 1 - vector_weight
+	RrfK                  uint32            `protobuf:"varint,12,opt,name=rrf_k,json=rrfK,proto3" json:"rrf_k,omitempty"`                          // RRF: rank constant, 0 => 60
+	Facets                []*FacetRequest   `protobuf:"bytes,13,rep,name=facets,proto3" json:"facets,omitempty"`                                   // counted over every row matching filter_expression
+	// filter_expression as text, e.g. (type > 5 AND size < 4) OR tag IN ["a", "b"]
+	Filter string `protobuf:"bytes,14,opt,name=filter,proto3" json:"filter,omitempty"`
 }
 
 func (x *SearchIndex) Reset() {
@@ -1072,36 +1324,90 @@ func (x *SearchIndex) GetHighResourceAvaliable() bool {
 	return false
 }
 
-type SearchFilter struct {
+func (x *SearchIndex) GetMode() SearchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return SearchMode_VECTOR
+}
+
+func (x *SearchIndex) GetTextQuery() string {
+	if x != nil {
+		return x.TextQuery
+	}
+	return ""
+}
+
+func (x *SearchIndex) GetTextFields() []string {
+	if x != nil {
+		return x.TextFields
+	}
+	return nil
+}
+
+func (x *SearchIndex) GetFusion() FusionMethod {
+	if x != nil {
+		return x.Fusion
+	}
+	return FusionMethod_RRF
+}
+
+func (x *SearchIndex) GetVectorWeight() float32 {
+	if x != nil {
+		return x.VectorWeight
+	}
+	return 0
+}
+
+func (x *SearchIndex) GetRrfK() uint32 {
+	if x != nil {
+		return x.RrfK
+	}
+	return 0
+}
+
+func (x *SearchIndex) GetFacets() []*FacetRequest {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *SearchIndex) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+// ScalarQuery returns the rows matching the filter by id, without vector search
+type ScalarQuery struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IndexName string `protobuf:"bytes,1,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
-	Op        Op     `protobuf:"varint,2,opt,name=op,proto3,enum=edgepb.Op" json:"op,omitempty"`
-	// Types that are assignable to Value:
-	//
-	//	*SearchFilter_StringVal
-	//	*SearchFilter_IntVal
-	//	*SearchFilter_FloatVal
-	//	*SearchFilter_BoolVal
-	Value isSearchFilter_Value `protobuf_oneof:"value"`
+	CollectionName   string            `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	FilterExpression *FilterExpression `protobuf:"bytes,2,opt,name=filter_expression,json=filterExpression,proto3" json:"filter_expression,omitempty"` // empty => every row
+	Offset           uint64            `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit            uint64            `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"` // 0 => no rows, for facets only
+	Facets           []*FacetRequest   `protobuf:"bytes,5,rep,name=facets,proto3" json:"facets,omitempty"`
+	Filter           string            `protobuf:"bytes,6,opt,name=filter,proto3" json:"filter,omitempty"` // filter_expression as text, see SearchIndex.filter
 }
 
-func (x *SearchFilter) Reset() {
-	*x = SearchFilter{}
+func (x *ScalarQuery) Reset() {
+	*x = ScalarQuery{}
 	mi := &file_idl_proto_v4_edge_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchFilter) String() string {
+func (x *ScalarQuery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchFilter) ProtoMessage() {}
+func (*ScalarQuery) ProtoMessage() {}
 
-func (x *SearchFilter) ProtoReflect() protoreflect.Message {
+func (x *ScalarQuery) ProtoReflect() protoreflect.Message {
 	mi := &file_idl_proto_v4_edge_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1113,114 +1419,78 @@ func (x *SearchFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchFilter.ProtoReflect.Descriptor instead.
-func (*SearchFilter) Descriptor() ([]byte, []int) {
+// Deprecated: Use ScalarQuery.ProtoReflect.Descriptor instead.
+func (*ScalarQuery) Descriptor() ([]byte, []int) {
 	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *SearchFilter) GetIndexName() string {
+func (x *ScalarQuery) GetCollectionName() string {
 	if x != nil {
-		return x.IndexName
+		return x.CollectionName
 	}
 	return ""
 }
 
-func (x *SearchFilter) GetOp() Op {
+func (x *ScalarQuery) GetFilterExpression() *FilterExpression {
 	if x != nil {
-		return x.Op
-	}
-	return Op_EQ
-}
-
-func (m *SearchFilter) GetValue() isSearchFilter_Value {
-	if m != nil {
-		return m.Value
+		return x.FilterExpression
 	}
 	return nil
 }
 
-func (x *SearchFilter) GetStringVal() string {
-	if x, ok := x.GetValue().(*SearchFilter_StringVal); ok {
-		return x.StringVal
-	}
-	return ""
-}
-
-func (x *SearchFilter) GetIntVal() int64 {
-	if x, ok := x.GetValue().(*SearchFilter_IntVal); ok {
-		return x.IntVal
+func (x *ScalarQuery) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
 	}
 	return 0
 }
 
-func (x *SearchFilter) GetFloatVal() float64 {
-	if x, ok := x.GetValue().(*SearchFilter_FloatVal); ok {
-		return x.FloatVal
+func (x *ScalarQuery) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
 	}
 	return 0
 }
 
-func (x *SearchFilter) GetBoolVal() bool {
-	if x, ok := x.GetValue().(*SearchFilter_BoolVal); ok {
-		return x.BoolVal
+func (x *ScalarQuery) GetFacets() []*FacetRequest {
+	if x != nil {
+		return x.Facets
 	}
-	return false
-}
-
-type isSearchFilter_Value interface {
-	isSearchFilter_Value()
-}
-
-type SearchFilter_StringVal struct {
-	StringVal string `protobuf:"bytes,3,opt,name=string_val,json=stringVal,proto3,oneof"`
-}
-
-type SearchFilter_IntVal struct {
-	IntVal int64 `protobuf:"varint,4,opt,name=int_val,json=intVal,proto3,oneof"`
-}
-
-type SearchFilter_FloatVal struct {
-	FloatVal float64 `protobuf:"fixed64,5,opt,name=float_val,json=floatVal,proto3,oneof"`
+	return nil
 }
 
-type SearchFilter_BoolVal struct {
-	BoolVal bool `protobuf:"varint,6,opt,name=bool_val,json=boolVal,proto3,oneof"`
+func (x *ScalarQuery) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
 }
 
-func (*SearchFilter_StringVal) isSearchFilter_Value() {}
-
-func (*SearchFilter_IntVal) isSearchFilter_Value() {}
-
-func (*SearchFilter_FloatVal) isSearchFilter_Value() {}
-
-func (*SearchFilter_BoolVal) isSearchFilter_Value() {}
-
-type FilterExpression struct {
+type FacetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Expr:
-	//
-	//	*FilterExpression_Filter
-	//	*FilterExpression_Composite
-	Expr isFilterExpression_Expr `protobuf_oneof:"expr"`
+	IndexName         string  `protobuf:"bytes,1,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
+	TopN              uint32  `protobuf:"varint,2,opt,name=top_n,json=topN,proto3" json:"top_n,omitempty"`                                         // most frequent values kept, 0 => 10
+	Stats             bool    `protobuf:"varint,3,opt,name=stats,proto3" json:"stats,omitempty"`                                                   // numeric indexes: min, max, sum and avg
+	HistogramInterval float64 `protobuf:"fixed64,4,opt,name=histogram_interval,json=histogramInterval,proto3" json:"histogram_interval,omitempty"` // numeric indexes: bucket width, 0 => no histogram
 }
 
-func (x *FilterExpression) Reset() {
-	*x = FilterExpression{}
+func (x *FacetRequest) Reset() {
+	*x = FacetRequest{}
 	mi := &file_idl_proto_v4_edge_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FilterExpression) String() string {
+func (x *FacetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FilterExpression) ProtoMessage() {}
+func (*FacetRequest) ProtoMessage() {}
 
-func (x *FilterExpression) ProtoReflect() protoreflect.Message {
+func (x *FacetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_idl_proto_v4_edge_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1232,19 +1502,698 @@ func (x *FilterExpression) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FilterExpression.ProtoReflect.Descriptor instead.
-func (*FilterExpression) Descriptor() ([]byte, []int) {
+// Deprecated: Use FacetRequest.ProtoReflect.Descriptor instead.
+func (*FacetRequest) Descriptor() ([]byte, []int) {
 	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{11}
 }
 
-func (m *FilterExpression) GetExpr() isFilterExpression_Expr {
-	if m != nil {
-		return m.Expr
+func (x *FacetRequest) GetIndexName() string {
+	if x != nil {
+		return x.IndexName
 	}
-	return nil
+	return ""
 }
 
-func (x *FilterExpression) GetFilter() *SearchFilter {
+func (x *FacetRequest) GetTopN() uint32 {
+	if x != nil {
+		return x.TopN
+	}
+	return 0
+}
+
+func (x *FacetRequest) GetStats() bool {
+	if x != nil {
+		return x.Stats
+	}
+	return false
+}
+
+func (x *FacetRequest) GetHistogramInterval() float64 {
+	if x != nil {
+		return x.HistogramInterval
+	}
+	return 0
+}
+
+type FacetResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IndexName string             `protobuf:"bytes,1,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
+	Counts    []*FacetCount      `protobuf:"bytes,2,rep,name=counts,proto3" json:"counts,omitempty"`
+	Stats     *NumericStats      `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`
+	Histogram []*HistogramBucket `protobuf:"bytes,4,rep,name=histogram,proto3" json:"histogram,omitempty"`
+}
+
+func (x *FacetResult) Reset() {
+	*x = FacetResult{}
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetResult) ProtoMessage() {}
+
+func (x *FacetResult) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetResult.ProtoReflect.Descriptor instead.
+func (*FacetResult) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FacetResult) GetIndexName() string {
+	if x != nil {
+		return x.IndexName
+	}
+	return ""
+}
+
+func (x *FacetResult) GetCounts() []*FacetCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *FacetResult) GetStats() *NumericStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *FacetResult) GetHistogram() []*HistogramBucket {
+	if x != nil {
+		return x.Histogram
+	}
+	return nil
+}
+
+type FacetCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *FilterValue `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count uint64       `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *FacetCount) Reset() {
+	*x = FacetCount{}
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetCount) ProtoMessage() {}
+
+func (x *FacetCount) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetCount.ProtoReflect.Descriptor instead.
+func (*FacetCount) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FacetCount) GetValue() *FilterValue {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *FacetCount) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type NumericStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count uint64  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Min   float64 `protobuf:"fixed64,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max   float64 `protobuf:"fixed64,3,opt,name=max,proto3" json:"max,omitempty"`
+	Sum   float64 `protobuf:"fixed64,4,opt,name=sum,proto3" json:"sum,omitempty"`
+	Avg   float64 `protobuf:"fixed64,5,opt,name=avg,proto3" json:"avg,omitempty"`
+}
+
+func (x *NumericStats) Reset() {
+	*x = NumericStats{}
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NumericStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NumericStats) ProtoMessage() {}
+
+func (x *NumericStats) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NumericStats.ProtoReflect.Descriptor instead.
+func (*NumericStats) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NumericStats) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *NumericStats) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *NumericStats) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *NumericStats) GetSum() float64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+func (x *NumericStats) GetAvg() float64 {
+	if x != nil {
+		return x.Avg
+	}
+	return 0
+}
+
+// HistogramBucket counts the values in [from, from + histogram_interval)
+type HistogramBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From  float64 `protobuf:"fixed64,1,opt,name=from,proto3" json:"from,omitempty"`
+	Count uint64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *HistogramBucket) Reset() {
+	*x = HistogramBucket{}
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistogramBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistogramBucket) ProtoMessage() {}
+
+func (x *HistogramBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistogramBucket.ProtoReflect.Descriptor instead.
+func (*HistogramBucket) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *HistogramBucket) GetFrom() float64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *HistogramBucket) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type SearchFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IndexName string `protobuf:"bytes,1,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
+	Op        Op     `protobuf:"varint,2,opt,name=op,proto3,enum=edgepb.Op" json:"op,omitempty"`
+	// Types that are assignable to Value:
+	//	*SearchFilter_StringVal
+	//	*SearchFilter_IntVal
+	//	*SearchFilter_FloatVal
+	//	*SearchFilter_BoolVal
+	Value isSearchFilter_Value `protobuf_oneof:"value"`
+	// values of IN and NOT_IN
+	Values []*FilterValue `protobuf:"bytes,7,rep,name=values,proto3" json:"values,omitempty"`
+	// bounds of BETWEEN, inclusive unless marked exclusive
+	Lower          *FilterValue `protobuf:"bytes,8,opt,name=lower,proto3" json:"lower,omitempty"`
+	Upper          *FilterValue `protobuf:"bytes,9,opt,name=upper,proto3" json:"upper,omitempty"`
+	LowerExclusive bool         `protobuf:"varint,10,opt,name=lower_exclusive,json=lowerExclusive,proto3" json:"lower_exclusive,omitempty"`
+	UpperExclusive bool         `protobuf:"varint,11,opt,name=upper_exclusive,json=upperExclusive,proto3" json:"upper_exclusive,omitempty"`
+	// center and distance in meters of GEO_RADIUS
+	Center       *GeoLocation `protobuf:"bytes,12,opt,name=center,proto3" json:"center,omitempty"`
+	RadiusMeters float64      `protobuf:"fixed64,13,opt,name=radius_meters,json=radiusMeters,proto3" json:"radius_meters,omitempty"`
+	// corners of GEO_BBOX, crossing the antimeridian when south_west is east of north_east
+	SouthWest *GeoLocation `protobuf:"bytes,14,opt,name=south_west,json=southWest,proto3" json:"south_west,omitempty"`
+	NorthEast *GeoLocation `protobuf:"bytes,15,opt,name=north_east,json=northEast,proto3" json:"north_east,omitempty"`
+}
+
+func (x *SearchFilter) Reset() {
+	*x = SearchFilter{}
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFilter) ProtoMessage() {}
+
+func (x *SearchFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFilter.ProtoReflect.Descriptor instead.
+func (*SearchFilter) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchFilter) GetIndexName() string {
+	if x != nil {
+		return x.IndexName
+	}
+	return ""
+}
+
+func (x *SearchFilter) GetOp() Op {
+	if x != nil {
+		return x.Op
+	}
+	return Op_EQ
+}
+
+func (m *SearchFilter) GetValue() isSearchFilter_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *SearchFilter) GetStringVal() string {
+	if x, ok := x.GetValue().(*SearchFilter_StringVal); ok {
+		return x.StringVal
+	}
+	return ""
+}
+
+func (x *SearchFilter) GetIntVal() int64 {
+	if x, ok := x.GetValue().(*SearchFilter_IntVal); ok {
+		return x.IntVal
+	}
+	return 0
+}
+
+func (x *SearchFilter) GetFloatVal() float64 {
+	if x, ok := x.GetValue().(*SearchFilter_FloatVal); ok {
+		return x.FloatVal
+	}
+	return 0
+}
+
+func (x *SearchFilter) GetBoolVal() bool {
+	if x, ok := x.GetValue().(*SearchFilter_BoolVal); ok {
+		return x.BoolVal
+	}
+	return false
+}
+
+func (x *SearchFilter) GetValues() []*FilterValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *SearchFilter) GetLower() *FilterValue {
+	if x != nil {
+		return x.Lower
+	}
+	return nil
+}
+
+func (x *SearchFilter) GetUpper() *FilterValue {
+	if x != nil {
+		return x.Upper
+	}
+	return nil
+}
+
+func (x *SearchFilter) GetLowerExclusive() bool {
+	if x != nil {
+		return x.LowerExclusive
+	}
+	return false
+}
+
+func (x *SearchFilter) GetUpperExclusive() bool {
+	if x != nil {
+		return x.UpperExclusive
+	}
+	return false
+}
+
+func (x *SearchFilter) GetCenter() *GeoLocation {
+	if x != nil {
+		return x.Center
+	}
+	return nil
+}
+
+func (x *SearchFilter) GetRadiusMeters() float64 {
+	if x != nil {
+		return x.RadiusMeters
+	}
+	return 0
+}
+
+func (x *SearchFilter) GetSouthWest() *GeoLocation {
+	if x != nil {
+		return x.SouthWest
+	}
+	return nil
+}
+
+func (x *SearchFilter) GetNorthEast() *GeoLocation {
+	if x != nil {
+		return x.NorthEast
+	}
+	return nil
+}
+
+type isSearchFilter_Value interface {
+	isSearchFilter_Value()
+}
+
+type SearchFilter_StringVal struct {
+	StringVal string `protobuf:"bytes,3,opt,name=string_val,json=stringVal,proto3,oneof"`
+}
+
+type SearchFilter_IntVal struct {
+	IntVal int64 `protobuf:"varint,4,opt,name=int_val,json=intVal,proto3,oneof"`
+}
+
+type SearchFilter_FloatVal struct {
+	FloatVal float64 `protobuf:"fixed64,5,opt,name=float_val,json=floatVal,proto3,oneof"`
+}
+
+type SearchFilter_BoolVal struct {
+	BoolVal bool `protobuf:"varint,6,opt,name=bool_val,json=boolVal,proto3,oneof"`
+}
+
+func (*SearchFilter_StringVal) isSearchFilter_Value() {}
+
+func (*SearchFilter_IntVal) isSearchFilter_Value() {}
+
+func (*SearchFilter_FloatVal) isSearchFilter_Value() {}
+
+func (*SearchFilter_BoolVal) isSearchFilter_Value() {}
+
+type GeoLocation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lat float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon float64 `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+}
+
+func (x *GeoLocation) Reset() {
+	*x = GeoLocation{}
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoLocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoLocation) ProtoMessage() {}
+
+func (x *GeoLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoLocation.ProtoReflect.Descriptor instead.
+func (*GeoLocation) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GeoLocation) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *GeoLocation) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+type FilterValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Value:
+	//	*FilterValue_StringVal
+	//	*FilterValue_IntVal
+	//	*FilterValue_FloatVal
+	//	*FilterValue_BoolVal
+	Value isFilterValue_Value `protobuf_oneof:"value"`
+}
+
+func (x *FilterValue) Reset() {
+	*x = FilterValue{}
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterValue) ProtoMessage() {}
+
+func (x *FilterValue) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterValue.ProtoReflect.Descriptor instead.
+func (*FilterValue) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{18}
+}
+
+func (m *FilterValue) GetValue() isFilterValue_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *FilterValue) GetStringVal() string {
+	if x, ok := x.GetValue().(*FilterValue_StringVal); ok {
+		return x.StringVal
+	}
+	return ""
+}
+
+func (x *FilterValue) GetIntVal() int64 {
+	if x, ok := x.GetValue().(*FilterValue_IntVal); ok {
+		return x.IntVal
+	}
+	return 0
+}
+
+func (x *FilterValue) GetFloatVal() float64 {
+	if x, ok := x.GetValue().(*FilterValue_FloatVal); ok {
+		return x.FloatVal
+	}
+	return 0
+}
+
+func (x *FilterValue) GetBoolVal() bool {
+	if x, ok := x.GetValue().(*FilterValue_BoolVal); ok {
+		return x.BoolVal
+	}
+	return false
+}
+
+type isFilterValue_Value interface {
+	isFilterValue_Value()
+}
+
+type FilterValue_StringVal struct {
+	StringVal string `protobuf:"bytes,1,opt,name=string_val,json=stringVal,proto3,oneof"`
+}
+
+type FilterValue_IntVal struct {
+	IntVal int64 `protobuf:"varint,2,opt,name=int_val,json=intVal,proto3,oneof"`
+}
+
+type FilterValue_FloatVal struct {
+	FloatVal float64 `protobuf:"fixed64,3,opt,name=float_val,json=floatVal,proto3,oneof"`
+}
+
+type FilterValue_BoolVal struct {
+	BoolVal bool `protobuf:"varint,4,opt,name=bool_val,json=boolVal,proto3,oneof"`
+}
+
+func (*FilterValue_StringVal) isFilterValue_Value() {}
+
+func (*FilterValue_IntVal) isFilterValue_Value() {}
+
+func (*FilterValue_FloatVal) isFilterValue_Value() {}
+
+func (*FilterValue_BoolVal) isFilterValue_Value() {}
+
+type FilterExpression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Expr:
+	//	*FilterExpression_Filter
+	//	*FilterExpression_Composite
+	Expr isFilterExpression_Expr `protobuf_oneof:"expr"`
+}
+
+func (x *FilterExpression) Reset() {
+	*x = FilterExpression{}
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterExpression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterExpression) ProtoMessage() {}
+
+func (x *FilterExpression) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterExpression.ProtoReflect.Descriptor instead.
+func (*FilterExpression) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{19}
+}
+
+func (m *FilterExpression) GetExpr() isFilterExpression_Expr {
+	if m != nil {
+		return m.Expr
+	}
+	return nil
+}
+
+func (x *FilterExpression) GetFilter() *SearchFilter {
 	if x, ok := x.GetExpr().(*FilterExpression_Filter); ok {
 		return x.Filter
 	}
@@ -1285,7 +2234,7 @@ type CompositeFilter struct {
 
 func (x *CompositeFilter) Reset() {
 	*x = CompositeFilter{}
-	mi := &file_idl_proto_v4_edge_proto_msgTypes[12]
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1297,7 +2246,7 @@ func (x *CompositeFilter) String() string {
 func (*CompositeFilter) ProtoMessage() {}
 
 func (x *CompositeFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v4_edge_proto_msgTypes[12]
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1310,7 +2259,7 @@ func (x *CompositeFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompositeFilter.ProtoReflect.Descriptor instead.
 func (*CompositeFilter) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{12}
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *CompositeFilter) GetOp() LogicalOperator {
@@ -1332,14 +2281,16 @@ type SearchResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status     bool          `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Error      *Error        `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	Candidates []*Candidates `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	Status     bool           `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Error      *Error         `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Candidates []*Candidates  `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	Facets     []*FacetResult `protobuf:"bytes,4,rep,name=facets,proto3" json:"facets,omitempty"`
+	Explain    *QueryPlan     `protobuf:"bytes,5,opt,name=explain,proto3" json:"explain,omitempty"`
 }
 
 func (x *SearchResponse) Reset() {
 	*x = SearchResponse{}
-	mi := &file_idl_proto_v4_edge_proto_msgTypes[13]
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1351,7 +2302,7 @@ func (x *SearchResponse) String() string {
 func (*SearchResponse) ProtoMessage() {}
 
 func (x *SearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v4_edge_proto_msgTypes[13]
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1364,7 +2315,7 @@ func (x *SearchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
 func (*SearchResponse) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{13}
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *SearchResponse) GetStatus() bool {
@@ -1388,6 +2339,106 @@ func (x *SearchResponse) GetCandidates() []*Candidates {
 	return nil
 }
 
+func (x *SearchResponse) GetFacets() []*FacetResult {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetExplain() *QueryPlan {
+	if x != nil {
+		return x.Explain
+	}
+	return nil
+}
+
+// QueryPlan explains how a filtered search visited the rows
+type QueryPlan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Strategy    string  `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Filtered    uint64  `protobuf:"varint,2,opt,name=filtered,proto3" json:"filtered,omitempty"`
+	Total       uint64  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Selectivity float64 `protobuf:"fixed64,4,opt,name=selectivity,proto3" json:"selectivity,omitempty"`
+	Fetch       uint64  `protobuf:"varint,5,opt,name=fetch,proto3" json:"fetch,omitempty"` // candidates asked of the ann graph, 0 when the rows are scanned
+	Detail      string  `protobuf:"bytes,6,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *QueryPlan) Reset() {
+	*x = QueryPlan{}
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPlan) ProtoMessage() {}
+
+func (x *QueryPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPlan.ProtoReflect.Descriptor instead.
+func (*QueryPlan) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *QueryPlan) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *QueryPlan) GetFiltered() uint64 {
+	if x != nil {
+		return x.Filtered
+	}
+	return 0
+}
+
+func (x *QueryPlan) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *QueryPlan) GetSelectivity() float64 {
+	if x != nil {
+		return x.Selectivity
+	}
+	return 0
+}
+
+func (x *QueryPlan) GetFetch() uint64 {
+	if x != nil {
+		return x.Fetch
+	}
+	return 0
+}
+
+func (x *QueryPlan) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
 type Candidates struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1399,7 +2450,7 @@ type Candidates struct {
 
 func (x *Candidates) Reset() {
 	*x = Candidates{}
-	mi := &file_idl_proto_v4_edge_proto_msgTypes[14]
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1411,7 +2462,7 @@ func (x *Candidates) String() string {
 func (*Candidates) ProtoMessage() {}
 
 func (x *Candidates) ProtoReflect() protoreflect.Message {
-	mi := &file_idl_proto_v4_edge_proto_msgTypes[14]
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1424,7 +2475,7 @@ func (x *Candidates) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Candidates.ProtoReflect.Descriptor instead.
 func (*Candidates) Descriptor() ([]byte, []int) {
-	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{14}
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *Candidates) GetMetadata() *structpb.Struct {
@@ -1441,6 +2492,144 @@ func (x *Candidates) GetScore() float32 {
 	return 0
 }
 
+type SubscribeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CollectionName string `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	FromPosition   uint64 `protobuf:"varint,2,opt,name=from_position,json=fromPosition,proto3" json:"from_position,omitempty"` // 0 => only the changes from now on
+	WithVector     bool   `protobuf:"varint,3,opt,name=with_vector,json=withVector,proto3" json:"with_vector,omitempty"`
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SubscribeRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *SubscribeRequest) GetFromPosition() uint64 {
+	if x != nil {
+		return x.FromPosition
+	}
+	return 0
+}
+
+func (x *SubscribeRequest) GetWithVector() bool {
+	if x != nil {
+		return x.WithVector
+	}
+	return false
+}
+
+type ChangeEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Position   uint64           `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"` // resume with from_position=position
+	ChangeType ChangeEventType  `protobuf:"varint,2,opt,name=change_type,json=changeType,proto3,enum=edgepb.ChangeEventType" json:"change_type,omitempty"`
+	PrimaryKey string           `protobuf:"bytes,3,opt,name=primary_key,json=primaryKey,proto3" json:"primary_key,omitempty"`
+	Metadata   *structpb.Struct `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Vectors    []float32        `protobuf:"fixed32,5,rep,packed,name=vectors,proto3" json:"vectors,omitempty"`
+}
+
+func (x *ChangeEvent) Reset() {
+	*x = ChangeEvent{}
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEvent) ProtoMessage() {}
+
+func (x *ChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_idl_proto_v4_edge_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEvent.ProtoReflect.Descriptor instead.
+func (*ChangeEvent) Descriptor() ([]byte, []int) {
+	return file_idl_proto_v4_edge_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ChangeEvent) GetPosition() uint64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetChangeType() ChangeEventType {
+	if x != nil {
+		return x.ChangeType
+	}
+	return ChangeEventType_CHANGE_INSERT
+}
+
+func (x *ChangeEvent) GetPrimaryKey() string {
+	if x != nil {
+		return x.PrimaryKey
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetVectors() []float32 {
+	if x != nil {
+		return x.Vectors
+	}
+	return nil
+}
+
 var File_idl_proto_v4_edge_proto protoreflect.FileDescriptor
 
 var file_idl_proto_v4_edge_proto_rawDesc = []byte{
@@ -1455,7 +2644,7 @@ var file_idl_proto_v4_edge_proto_rawDesc = []byte{
 	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
 	0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x69, 0x74, 0x68, 0x5f,
 	0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x77, 0x69, 0x74, 0x68,
-	0x53, 0x69, 0x7a, 0x65, 0x22, 0xf4, 0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x53, 0x69, 0x7a, 0x65, 0x22, 0xa4, 0x02, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
 	0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
 	0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f,
 	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x05,
@@ -1470,7 +2659,10 @@ var file_idl_proto_v4_edge_proto_rawDesc = []byte{
 	0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x69, 0x6d,
 	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x64, 0x69, 0x6d, 0x12, 0x1e, 0x0a, 0x0a, 0x76,
 	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0a, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x85, 0x01, 0x0a, 0x12,
+	0x0a, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x2e, 0x0a, 0x13, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x74, 0x74, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x54, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x85, 0x01, 0x0a, 0x12,
 	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
 	0x73, 0x65, 0x12, 0x32, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e,
@@ -1479,7 +2671,7 @@ var file_idl_proto_v4_edge_proto_rawDesc = []byte{
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23,
 	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
 	0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x22, 0x9a, 0x01, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1d, 0x0a,
+	0x72, 0x6f, 0x72, 0x22, 0xf3, 0x01, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1d, 0x0a,
 	0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x0a,
 	0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
@@ -1489,175 +2681,361 @@ var file_idl_proto_v4_edge_proto_rawDesc = []byte{
 	0x01, 0x28, 0x08, 0x52, 0x0a, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x75, 0x6c, 0x6c, 0x12,
 	0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04,
 	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79,
-	0x22, 0x47, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x5e, 0x0a, 0x05, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x65, 0x64,
-	0x67, 0x65, 0x70, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x57, 0x0a, 0x18, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x4f, 0x6e, 0x6c,
+	0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x75, 0x6c, 0x6c, 0x74, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x75, 0x6c, 0x6c, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65, 0x72, 0x22, 0x47, 0x0a, 0x08, 0x52, 0x65, 0x73,
 	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a,
 	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x65,
 	0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x22, 0xed, 0x01, 0x0a, 0x10, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x32, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x64,
-	0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x63,
-	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x53, 0x69, 0x7a, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x6d, 0x6f, 0x72,
-	0x79, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x04, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a,
-	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x65,
-	0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x22, 0xd9, 0x01, 0x0a, 0x0b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x68, 0x61, 0x6e,
-	0x67, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70,
-	0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x08,
-	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03,
-	0x28, 0x02, 0x52, 0x07, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x31, 0x0a, 0x07, 0x63,
-	0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x65,
-	0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x68, 0x61, 0x67, 0x65,
-	0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x22, 0xfb,
-	0x01, 0x0a, 0x0b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x27,
-	0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f,
-	0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12,
-	0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x45, 0x0a,
-	0x11, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70,
-	0x62, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x52, 0x10, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x17, 0x68, 0x69, 0x67, 0x68, 0x5f, 0x72, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x61, 0x76, 0x61, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x68, 0x69, 0x67, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x41, 0x76, 0x61, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x22, 0xca, 0x01, 0x0a,
-	0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1d, 0x0a,
-	0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x02,
-	0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70,
-	0x62, 0x2e, 0x4f, 0x70, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x1f, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09,
-	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x12, 0x19, 0x0a, 0x07, 0x69, 0x6e, 0x74,
-	0x5f, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x06, 0x69, 0x6e,
-	0x74, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x09, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x76, 0x61,
-	0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74,
-	0x56, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x08, 0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c,
-	0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x10, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e,
-	0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
-	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x37,
-	0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f,
-	0x73, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6f,
-	0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x65, 0x78, 0x70, 0x72, 0x22,
-	0x76, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x12, 0x27, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17,
-	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x4f,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x3a, 0x0a, 0x0b, 0x65,
-	0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x6f, 0x72, 0x22, 0x5e, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x30, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f,
+	0x64, 0x65, 0x22, 0x57, 0x0a, 0x18, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xed, 0x01, 0x0a, 0x10,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x12, 0x32, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2b, 0x0a,
+	0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f,
+	0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xfa, 0x01, 0x0a, 0x0b,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x6d, 0x61,
+	0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x02, 0x52, 0x07, 0x76, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x73, 0x12, 0x31, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x43, 0x68, 0x61, 0x67, 0x65, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x74, 0x6c, 0x5f, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x74,
+	0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x91, 0x04, 0x0a, 0x0b, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x02, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x45, 0x0a, 0x11, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x36,
+	0x0a, 0x17, 0x68, 0x69, 0x67, 0x68, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x61, 0x76, 0x61, 0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x15, 0x68, 0x69, 0x67, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x41, 0x76, 0x61,
+	0x6c, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x74, 0x65, 0x78, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1f, 0x0a,
+	0x0b, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0a, 0x74, 0x65, 0x78, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x2c,
+	0x0a, 0x06, 0x66, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14,
+	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x46, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x52, 0x06, 0x66, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d,
+	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x0c, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x57, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x13, 0x0a, 0x05, 0x72, 0x72, 0x66, 0x5f, 0x6b, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x72, 0x72, 0x66, 0x4b, 0x12, 0x2c, 0x0a, 0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73,
+	0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e,
+	0x46, 0x61, 0x63, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x06, 0x66, 0x61,
+	0x63, 0x65, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0xf1, 0x01, 0x0a,
+	0x0b, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x0f,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x45, 0x0a, 0x11, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f,
+	0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x18, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x72,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x81, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0d, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72,
-	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x32, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69,
-	0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x64,
-	0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52,
-	0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0x57, 0x0a, 0x0a, 0x43,
-	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
-	0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14,
-	0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73,
-	0x63, 0x6f, 0x72, 0x65, 0x2a, 0x3c, 0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x10, 0x00, 0x12, 0x0b, 0x0a,
-	0x07, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x6c,
-	0x6f, 0x61, 0x74, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e,
-	0x10, 0x03, 0x2a, 0x25, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x0a,
-	0x0a, 0x06, 0x43, 0x6f, 0x73, 0x69, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x75,
-	0x63, 0x6c, 0x69, 0x64, 0x65, 0x61, 0x6e, 0x10, 0x01, 0x2a, 0x33, 0x0a, 0x0c, 0x51, 0x75, 0x61,
-	0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x6f, 0x6e,
-	0x65, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x46, 0x31, 0x36, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02,
-	0x46, 0x38, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x46, 0x31, 0x36, 0x10, 0x03, 0x2a, 0x97,
-	0x01, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0d, 0x0a, 0x09,
-	0x55, 0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x52,
-	0x50, 0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x43, 0x4f,
+	0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x66, 0x61,
+	0x63, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x65, 0x64, 0x67,
+	0x65, 0x70, 0x62, 0x2e, 0x46, 0x61, 0x63, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x22, 0x87, 0x01, 0x0a, 0x0c, 0x46, 0x61, 0x63, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x13, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x5f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x04, 0x74, 0x6f, 0x70, 0x4e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x68,
+	0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72,
+	0x61, 0x6d, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0xbb, 0x01, 0x0a, 0x0b, 0x46,
+	0x61, 0x63, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x64, 0x67, 0x65,
+	0x70, 0x62, 0x2e, 0x46, 0x61, 0x63, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x06, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x4e, 0x75,
+	0x6d, 0x65, 0x72, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x35, 0x0a, 0x09, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x09, 0x68,
+	0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x22, 0x4d, 0x0a, 0x0a, 0x46, 0x61, 0x63, 0x65,
+	0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x6c, 0x0a, 0x0c, 0x4e, 0x75, 0x6d, 0x65, 0x72,
+	0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12,
+	0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x61,
+	0x78, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03,
+	0x73, 0x75, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x76, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x03, 0x61, 0x76, 0x67, 0x22, 0x3b, 0x0a, 0x0f, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72,
+	0x61, 0x6d, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0xd9, 0x04, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a,
+	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x4f, 0x70, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x1f,
+	0x0a, 0x0a, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x12,
+	0x19, 0x0a, 0x07, 0x69, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x48, 0x00, 0x52, 0x06, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x09, 0x66, 0x6c,
+	0x6f, 0x61, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52,
+	0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x56, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x08, 0x62, 0x6f, 0x6f,
+	0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x62,
+	0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x12, 0x2b, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x29,
+	0x0a, 0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x6f, 0x77,
+	0x65, 0x72, 0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69,
+	0x76, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x75, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x63, 0x6c,
+	0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x75, 0x70, 0x70,
+	0x65, 0x72, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x63,
+	0x65, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x64,
+	0x67, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x6f, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x06, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x61, 0x64, 0x69,
+	0x75, 0x73, 0x5f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0c, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x32, 0x0a,
+	0x0a, 0x73, 0x6f, 0x75, 0x74, 0x68, 0x5f, 0x77, 0x65, 0x73, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x6f, 0x4c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x73, 0x6f, 0x75, 0x74, 0x68, 0x57, 0x65, 0x73,
+	0x74, 0x12, 0x32, 0x0a, 0x0a, 0x6e, 0x6f, 0x72, 0x74, 0x68, 0x5f, 0x65, 0x61, 0x73, 0x74, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x47,
+	0x65, 0x6f, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6e, 0x6f, 0x72, 0x74,
+	0x68, 0x45, 0x61, 0x73, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x31,
+	0x0a, 0x0b, 0x47, 0x65, 0x6f, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a,
+	0x03, 0x6c, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x61, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6f,
+	0x6e, 0x22, 0x8e, 0x01, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x1f, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56,
+	0x61, 0x6c, 0x12, 0x19, 0x0a, 0x07, 0x69, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x06, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a,
+	0x09, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x48, 0x00, 0x52, 0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x56, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x08,
+	0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00,
+	0x52, 0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x10, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62,
+	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52,
+	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x37, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65, 0x64, 0x67,
+	0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65,
+	0x42, 0x06, 0x0a, 0x04, 0x65, 0x78, 0x70, 0x72, 0x22, 0x76, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x02, 0x6f,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62,
+	0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x52, 0x02, 0x6f, 0x70, 0x12, 0x3a, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65, 0x64, 0x67, 0x65,
+	0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0xdb, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x65, 0x64, 0x67,
+	0x65, 0x70, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x32, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x46, 0x61,
+	0x63, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x66, 0x61, 0x63, 0x65, 0x74,
+	0x73, 0x12, 0x2b, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x22, 0xa9,
+	0x01, 0x0a, 0x09, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0b, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x66, 0x65, 0x74, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x66, 0x65, 0x74,
+	0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x57, 0x0a, 0x0a, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73, 0x63,
+	0x6f, 0x72, 0x65, 0x22, 0x81, 0x01, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x77, 0x69, 0x74,
+	0x68, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0xd3, 0x01, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70,
+	0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x33,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x02, 0x52, 0x07, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x2a, 0x8f, 0x01,
+	0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x6e, 0x74, 0x65, 0x67,
+	0x65, 0x72, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x10, 0x02, 0x12,
+	0x0b, 0x0a, 0x07, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x72, 0x72, 0x61, 0x79, 0x10, 0x04, 0x12, 0x10, 0x0a,
+	0x0c, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x41, 0x72, 0x72, 0x61, 0x79, 0x10, 0x05, 0x12,
+	0x0e, 0x0a, 0x0a, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x41, 0x72, 0x72, 0x61, 0x79, 0x10, 0x06, 0x12,
+	0x10, 0x0a, 0x0c, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x41, 0x72, 0x72, 0x61, 0x79, 0x10,
+	0x07, 0x12, 0x0c, 0x0a, 0x08, 0x47, 0x65, 0x6f, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x10, 0x08, 0x2a,
+	0x25, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x43,
+	0x6f, 0x73, 0x69, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x75, 0x63, 0x6c, 0x69,
+	0x64, 0x65, 0x61, 0x6e, 0x10, 0x01, 0x2a, 0x33, 0x0a, 0x0c, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00,
+	0x12, 0x07, 0x0a, 0x03, 0x46, 0x31, 0x36, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x46, 0x38, 0x10,
+	0x02, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x46, 0x31, 0x36, 0x10, 0x03, 0x2a, 0x97, 0x01, 0x0a, 0x09,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x4e, 0x44,
+	0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x50, 0x43, 0x5f,
+	0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x43, 0x4f, 0x4d, 0x4d, 0x55,
+	0x4e, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x44, 0x5f, 0x52,
+	0x50, 0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x43, 0x4f,
 	0x4d, 0x4d, 0x55, 0x4e, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x48, 0x41, 0x52,
-	0x44, 0x5f, 0x52, 0x50, 0x43, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x02, 0x12, 0x1d, 0x0a,
-	0x19, 0x43, 0x4f, 0x4d, 0x4d, 0x55, 0x4e, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53,
-	0x48, 0x41, 0x52, 0x44, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d,
-	0x4d, 0x41, 0x52, 0x53, 0x48, 0x41, 0x4c, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x04, 0x12,
-	0x17, 0x0a, 0x13, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x46, 0x55, 0x4e, 0x43,
-	0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x05, 0x2a, 0x2a, 0x0a, 0x0f, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x43, 0x68, 0x61, 0x67, 0x65, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x43,
-	0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45,
-	0x54, 0x45, 0x10, 0x01, 0x2a, 0x22, 0x0a, 0x0f, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x4f,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4e, 0x44, 0x10, 0x00,
-	0x12, 0x06, 0x0a, 0x02, 0x4f, 0x52, 0x10, 0x01, 0x2a, 0x37, 0x0a, 0x02, 0x4f, 0x70, 0x12, 0x06,
-	0x0a, 0x02, 0x45, 0x51, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x45, 0x51, 0x10, 0x01, 0x12,
-	0x06, 0x0a, 0x02, 0x47, 0x54, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x54, 0x45, 0x10, 0x03,
-	0x12, 0x06, 0x0a, 0x02, 0x4c, 0x54, 0x10, 0x04, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x54, 0x45, 0x10,
-	0x05, 0x32, 0xc5, 0x04, 0x0a, 0x07, 0x45, 0x64, 0x67, 0x65, 0x52, 0x70, 0x63, 0x12, 0x38, 0x0a,
-	0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x2e, 0x65, 0x64,
-	0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a,
-	0x1a, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a,
-	0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x16, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x20, 0x2e, 0x65, 0x64, 0x67, 0x65,
-	0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a,
-	0x0d, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16,
-	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x18, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e,
-	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
-	0x22, 0x00, 0x12, 0x44, 0x0a, 0x0e, 0x4c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x18, 0x2e, 0x65,
+	0x44, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x41, 0x52,
+	0x53, 0x48, 0x41, 0x4c, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13,
+	0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x46, 0x55, 0x4e, 0x43, 0x5f, 0x45, 0x52,
+	0x52, 0x4f, 0x52, 0x10, 0x05, 0x2a, 0x2a, 0x0a, 0x0f, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x68,
+	0x61, 0x67, 0x65, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x48, 0x41, 0x4e,
+	0x47, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10,
+	0x01, 0x2a, 0x2e, 0x0a, 0x0a, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x0a, 0x0a, 0x06, 0x56, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x54,
+	0x45, 0x58, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x48, 0x59, 0x42, 0x52, 0x49, 0x44, 0x10,
+	0x02, 0x2a, 0x25, 0x0a, 0x0c, 0x46, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x52, 0x46, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x57, 0x45,
+	0x49, 0x47, 0x48, 0x54, 0x45, 0x44, 0x10, 0x01, 0x2a, 0x2b, 0x0a, 0x0f, 0x4c, 0x6f, 0x67, 0x69,
+	0x63, 0x61, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x07, 0x0a, 0x03, 0x41,
+	0x4e, 0x44, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03,
+	0x4e, 0x4f, 0x54, 0x10, 0x02, 0x2a, 0xde, 0x01, 0x0a, 0x02, 0x4f, 0x70, 0x12, 0x06, 0x0a, 0x02,
+	0x45, 0x51, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x45, 0x51, 0x10, 0x01, 0x12, 0x06, 0x0a,
+	0x02, 0x47, 0x54, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x54, 0x45, 0x10, 0x03, 0x12, 0x06,
+	0x0a, 0x02, 0x4c, 0x54, 0x10, 0x04, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x54, 0x45, 0x10, 0x05, 0x12,
+	0x06, 0x0a, 0x02, 0x49, 0x4e, 0x10, 0x06, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x54, 0x5f, 0x49,
+	0x4e, 0x10, 0x07, 0x12, 0x0b, 0x0a, 0x07, 0x42, 0x45, 0x54, 0x57, 0x45, 0x45, 0x4e, 0x10, 0x08,
+	0x12, 0x0b, 0x0a, 0x07, 0x49, 0x53, 0x5f, 0x4e, 0x55, 0x4c, 0x4c, 0x10, 0x09, 0x12, 0x0f, 0x0a,
+	0x0b, 0x49, 0x53, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x4e, 0x55, 0x4c, 0x4c, 0x10, 0x0a, 0x12, 0x0a,
+	0x0a, 0x06, 0x50, 0x52, 0x45, 0x46, 0x49, 0x58, 0x10, 0x0b, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f,
+	0x4e, 0x54, 0x41, 0x49, 0x4e, 0x53, 0x10, 0x0c, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x45, 0x47, 0x45,
+	0x58, 0x10, 0x0d, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x4e, 0x59, 0x5f, 0x4f, 0x46, 0x10, 0x0e, 0x12,
+	0x0a, 0x0a, 0x06, 0x41, 0x4c, 0x4c, 0x5f, 0x4f, 0x46, 0x10, 0x0f, 0x12, 0x0b, 0x0a, 0x07, 0x4e,
+	0x4f, 0x4e, 0x45, 0x5f, 0x4f, 0x46, 0x10, 0x10, 0x12, 0x0e, 0x0a, 0x0a, 0x47, 0x45, 0x4f, 0x5f,
+	0x52, 0x41, 0x44, 0x49, 0x55, 0x53, 0x10, 0x11, 0x12, 0x0c, 0x0a, 0x08, 0x47, 0x45, 0x4f, 0x5f,
+	0x42, 0x42, 0x4f, 0x58, 0x10, 0x12, 0x2a, 0x4a, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x48, 0x41,
+	0x4e, 0x47, 0x45, 0x5f, 0x49, 0x4e, 0x53, 0x45, 0x52, 0x54, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d,
+	0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12,
+	0x11, 0x0a, 0x0d, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45,
+	0x10, 0x02, 0x32, 0xbd, 0x05, 0x0a, 0x07, 0x45, 0x64, 0x67, 0x65, 0x52, 0x70, 0x63, 0x12, 0x38,
+	0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x2e, 0x65,
 	0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x11, 0x52, 0x65, 0x6c, 0x65,
-	0x61, 0x73, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e,
+	0x1a, 0x1a, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e,
+	0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x20, 0x2e, 0x65, 0x64, 0x67,
+	0x65, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43,
+	0x0a, 0x0d, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x16, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x18, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62,
+	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0e, 0x4c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x18, 0x2e,
 	0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x10, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x05, 0x46, 0x6c, 0x75,
-	0x73, 0x68, 0x12, 0x16, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x10, 0x2e, 0x65, 0x64, 0x67,
-	0x65, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x30,
-	0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62,
-	0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x10, 0x2e, 0x65,
-	0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x37, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x13, 0x2e, 0x65, 0x64, 0x67,
-	0x65, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x1a,
-	0x16, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x65,
-	0x64, 0x67, 0x65, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x11, 0x52, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x10, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x05, 0x46, 0x6c,
+	0x75, 0x73, 0x68, 0x12, 0x16, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x1a, 0x10, 0x2e, 0x65, 0x64,
+	0x67, 0x65, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x30, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70,
+	0x62, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x10, 0x2e,
+	0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x37, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x13, 0x2e, 0x65, 0x64,
+	0x67, 0x65, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x1a, 0x16, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x05, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x12, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x53, 0x63, 0x61,
+	0x6c, 0x61, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x16, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70,
+	0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x3e, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12,
+	0x18, 0x2e, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x65, 0x64, 0x67, 0x65,
+	0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00,
+	0x30, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x65, 0x64, 0x67, 0x65, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1672,80 +3050,117 @@ func file_idl_proto_v4_edge_proto_rawDescGZIP() []byte {
 	return file_idl_proto_v4_edge_proto_rawDescData
 }
 
-var file_idl_proto_v4_edge_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_idl_proto_v4_edge_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_idl_proto_v4_edge_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
+var file_idl_proto_v4_edge_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_idl_proto_v4_edge_proto_goTypes = []any{
 	(IndexType)(0),                   // 0: edgepb.IndexType
 	(Distance)(0),                    // 1: edgepb.Distance
 	(Quantization)(0),                // 2: edgepb.Quantization
 	(ErrorCode)(0),                   // 3: edgepb.ErrorCode
 	(IndexChagedType)(0),             // 4: edgepb.IndexChagedType
-	(LogicalOperator)(0),             // 5: edgepb.LogicalOperator
-	(Op)(0),                          // 6: edgepb.Op
-	(*CollectionName)(nil),           // 7: edgepb.CollectionName
-	(*Collection)(nil),               // 8: edgepb.Collection
-	(*CollectionResponse)(nil),       // 9: edgepb.CollectionResponse
-	(*Index)(nil),                    // 10: edgepb.Index
-	(*Response)(nil),                 // 11: edgepb.Response
-	(*Error)(nil),                    // 12: edgepb.Error
-	(*DeleteCollectionResponse)(nil), // 13: edgepb.DeleteCollectionResponse
-	(*CollectionDetail)(nil),         // 14: edgepb.CollectionDetail
-	(*IndexChange)(nil),              // 15: edgepb.IndexChange
-	(*SearchIndex)(nil),              // 16: edgepb.SearchIndex
-	(*SearchFilter)(nil),             // 17: edgepb.SearchFilter
-	(*FilterExpression)(nil),         // 18: edgepb.FilterExpression
-	(*CompositeFilter)(nil),          // 19: edgepb.CompositeFilter
-	(*SearchResponse)(nil),           // 20: edgepb.SearchResponse
-	(*Candidates)(nil),               // 21: edgepb.Candidates
-	(*structpb.Struct)(nil),          // 22: google.protobuf.Struct
-	(*emptypb.Empty)(nil),            // 23: google.protobuf.Empty
+	(SearchMode)(0),                  // 5: edgepb.SearchMode
+	(FusionMethod)(0),                // 6: edgepb.FusionMethod
+	(LogicalOperator)(0),             // 7: edgepb.LogicalOperator
+	(Op)(0),                          // 8: edgepb.Op
+	(ChangeEventType)(0),             // 9: edgepb.ChangeEventType
+	(*CollectionName)(nil),           // 10: edgepb.CollectionName
+	(*Collection)(nil),               // 11: edgepb.Collection
+	(*CollectionResponse)(nil),       // 12: edgepb.CollectionResponse
+	(*Index)(nil),                    // 13: edgepb.Index
+	(*Response)(nil),                 // 14: edgepb.Response
+	(*Error)(nil),                    // 15: edgepb.Error
+	(*DeleteCollectionResponse)(nil), // 16: edgepb.DeleteCollectionResponse
+	(*CollectionDetail)(nil),         // 17: edgepb.CollectionDetail
+	(*IndexChange)(nil),              // 18: edgepb.IndexChange
+	(*SearchIndex)(nil),              // 19: edgepb.SearchIndex
+	(*ScalarQuery)(nil),              // 20: edgepb.ScalarQuery
+	(*FacetRequest)(nil),             // 21: edgepb.FacetRequest
+	(*FacetResult)(nil),              // 22: edgepb.FacetResult
+	(*FacetCount)(nil),               // 23: edgepb.FacetCount
+	(*NumericStats)(nil),             // 24: edgepb.NumericStats
+	(*HistogramBucket)(nil),          // 25: edgepb.HistogramBucket
+	(*SearchFilter)(nil),             // 26: edgepb.SearchFilter
+	(*GeoLocation)(nil),              // 27: edgepb.GeoLocation
+	(*FilterValue)(nil),              // 28: edgepb.FilterValue
+	(*FilterExpression)(nil),         // 29: edgepb.FilterExpression
+	(*CompositeFilter)(nil),          // 30: edgepb.CompositeFilter
+	(*SearchResponse)(nil),           // 31: edgepb.SearchResponse
+	(*QueryPlan)(nil),                // 32: edgepb.QueryPlan
+	(*Candidates)(nil),               // 33: edgepb.Candidates
+	(*SubscribeRequest)(nil),         // 34: edgepb.SubscribeRequest
+	(*ChangeEvent)(nil),              // 35: edgepb.ChangeEvent
+	(*structpb.Struct)(nil),          // 36: google.protobuf.Struct
+	(*emptypb.Empty)(nil),            // 37: google.protobuf.Empty
 }
 var file_idl_proto_v4_edge_proto_depIdxs = []int32{
-	10, // 0: edgepb.Collection.index:type_name -> edgepb.Index
+	13, // 0: edgepb.Collection.index:type_name -> edgepb.Index
 	1,  // 1: edgepb.Collection.distance:type_name -> edgepb.Distance
 	2,  // 2: edgepb.Collection.quantization:type_name -> edgepb.Quantization
-	8,  // 3: edgepb.CollectionResponse.collection:type_name -> edgepb.Collection
-	12, // 4: edgepb.CollectionResponse.error:type_name -> edgepb.Error
+	11, // 3: edgepb.CollectionResponse.collection:type_name -> edgepb.Collection
+	15, // 4: edgepb.CollectionResponse.error:type_name -> edgepb.Error
 	0,  // 5: edgepb.Index.index_type:type_name -> edgepb.IndexType
-	12, // 6: edgepb.Response.error:type_name -> edgepb.Error
+	15, // 6: edgepb.Response.error:type_name -> edgepb.Error
 	3,  // 7: edgepb.Error.error_code:type_name -> edgepb.ErrorCode
-	12, // 8: edgepb.DeleteCollectionResponse.error:type_name -> edgepb.Error
-	8,  // 9: edgepb.CollectionDetail.collection:type_name -> edgepb.Collection
-	12, // 10: edgepb.CollectionDetail.error:type_name -> edgepb.Error
-	22, // 11: edgepb.IndexChange.metadata:type_name -> google.protobuf.Struct
+	15, // 8: edgepb.DeleteCollectionResponse.error:type_name -> edgepb.Error
+	11, // 9: edgepb.CollectionDetail.collection:type_name -> edgepb.Collection
+	15, // 10: edgepb.CollectionDetail.error:type_name -> edgepb.Error
+	36, // 11: edgepb.IndexChange.metadata:type_name -> google.protobuf.Struct
 	4,  // 12: edgepb.IndexChange.changed:type_name -> edgepb.IndexChagedType
-	18, // 13: edgepb.SearchIndex.filter_expression:type_name -> edgepb.FilterExpression
-	6,  // 14: edgepb.SearchFilter.op:type_name -> edgepb.Op
-	17, // 15: edgepb.FilterExpression.filter:type_name -> edgepb.SearchFilter
-	19, // 16: edgepb.FilterExpression.composite:type_name -> edgepb.CompositeFilter
-	5,  // 17: edgepb.CompositeFilter.op:type_name -> edgepb.LogicalOperator
-	18, // 18: edgepb.CompositeFilter.expressions:type_name -> edgepb.FilterExpression
-	12, // 19: edgepb.SearchResponse.error:type_name -> edgepb.Error
-	21, // 20: edgepb.SearchResponse.candidates:type_name -> edgepb.Candidates
-	22, // 21: edgepb.Candidates.metadata:type_name -> google.protobuf.Struct
-	23, // 22: edgepb.EdgeRpc.Ping:input_type -> google.protobuf.Empty
-	8,  // 23: edgepb.EdgeRpc.CreateCollection:input_type -> edgepb.Collection
-	7,  // 24: edgepb.EdgeRpc.DeleteCollection:input_type -> edgepb.CollectionName
-	7,  // 25: edgepb.EdgeRpc.GetCollection:input_type -> edgepb.CollectionName
-	7,  // 26: edgepb.EdgeRpc.LoadCollection:input_type -> edgepb.CollectionName
-	7,  // 27: edgepb.EdgeRpc.ReleaseCollection:input_type -> edgepb.CollectionName
-	7,  // 28: edgepb.EdgeRpc.Flush:input_type -> edgepb.CollectionName
-	15, // 29: edgepb.EdgeRpc.Index:input_type -> edgepb.IndexChange
-	16, // 30: edgepb.EdgeRpc.Search:input_type -> edgepb.SearchIndex
-	23, // 31: edgepb.EdgeRpc.Ping:output_type -> google.protobuf.Empty
-	9,  // 32: edgepb.EdgeRpc.CreateCollection:output_type -> edgepb.CollectionResponse
-	13, // 33: edgepb.EdgeRpc.DeleteCollection:output_type -> edgepb.DeleteCollectionResponse
-	14, // 34: edgepb.EdgeRpc.GetCollection:output_type -> edgepb.CollectionDetail
-	14, // 35: edgepb.EdgeRpc.LoadCollection:output_type -> edgepb.CollectionDetail
-	11, // 36: edgepb.EdgeRpc.ReleaseCollection:output_type -> edgepb.Response
-	11, // 37: edgepb.EdgeRpc.Flush:output_type -> edgepb.Response
-	11, // 38: edgepb.EdgeRpc.Index:output_type -> edgepb.Response
-	20, // 39: edgepb.EdgeRpc.Search:output_type -> edgepb.SearchResponse
-	31, // [31:40] is the sub-list for method output_type
-	22, // [22:31] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	29, // 13: edgepb.SearchIndex.filter_expression:type_name -> edgepb.FilterExpression
+	5,  // 14: edgepb.SearchIndex.mode:type_name -> edgepb.SearchMode
+	6,  // 15: edgepb.SearchIndex.fusion:type_name -> edgepb.FusionMethod
+	21, // 16: edgepb.SearchIndex.facets:type_name -> edgepb.FacetRequest
+	29, // 17: edgepb.ScalarQuery.filter_expression:type_name -> edgepb.FilterExpression
+	21, // 18: edgepb.ScalarQuery.facets:type_name -> edgepb.FacetRequest
+	23, // 19: edgepb.FacetResult.counts:type_name -> edgepb.FacetCount
+	24, // 20: edgepb.FacetResult.stats:type_name -> edgepb.NumericStats
+	25, // 21: edgepb.FacetResult.histogram:type_name -> edgepb.HistogramBucket
+	28, // 22: edgepb.FacetCount.value:type_name -> edgepb.FilterValue
+	8,  // 23: edgepb.SearchFilter.op:type_name -> edgepb.Op
+	28, // 24: edgepb.SearchFilter.values:type_name -> edgepb.FilterValue
+	28, // 25: edgepb.SearchFilter.lower:type_name -> edgepb.FilterValue
+	28, // 26: edgepb.SearchFilter.upper:type_name -> edgepb.FilterValue
+	27, // 27: edgepb.SearchFilter.center:type_name -> edgepb.GeoLocation
+	27, // 28: edgepb.SearchFilter.south_west:type_name -> edgepb.GeoLocation
+	27, // 29: edgepb.SearchFilter.north_east:type_name -> edgepb.GeoLocation
+	26, // 30: edgepb.FilterExpression.filter:type_name -> edgepb.SearchFilter
+	30, // 31: edgepb.FilterExpression.composite:type_name -> edgepb.CompositeFilter
+	7,  // 32: edgepb.CompositeFilter.op:type_name -> edgepb.LogicalOperator
+	29, // 33: edgepb.CompositeFilter.expressions:type_name -> edgepb.FilterExpression
+	15, // 34: edgepb.SearchResponse.error:type_name -> edgepb.Error
+	33, // 35: edgepb.SearchResponse.candidates:type_name -> edgepb.Candidates
+	22, // 36: edgepb.SearchResponse.facets:type_name -> edgepb.FacetResult
+	32, // 37: edgepb.SearchResponse.explain:type_name -> edgepb.QueryPlan
+	36, // 38: edgepb.Candidates.metadata:type_name -> google.protobuf.Struct
+	9,  // 39: edgepb.ChangeEvent.change_type:type_name -> edgepb.ChangeEventType
+	36, // 40: edgepb.ChangeEvent.metadata:type_name -> google.protobuf.Struct
+	37, // 41: edgepb.EdgeRpc.Ping:input_type -> google.protobuf.Empty
+	11, // 42: edgepb.EdgeRpc.CreateCollection:input_type -> edgepb.Collection
+	10, // 43: edgepb.EdgeRpc.DeleteCollection:input_type -> edgepb.CollectionName
+	10, // 44: edgepb.EdgeRpc.GetCollection:input_type -> edgepb.CollectionName
+	10, // 45: edgepb.EdgeRpc.LoadCollection:input_type -> edgepb.CollectionName
+	10, // 46: edgepb.EdgeRpc.ReleaseCollection:input_type -> edgepb.CollectionName
+	10, // 47: edgepb.EdgeRpc.Flush:input_type -> edgepb.CollectionName
+	18, // 48: edgepb.EdgeRpc.Index:input_type -> edgepb.IndexChange
+	19, // 49: edgepb.EdgeRpc.Search:input_type -> edgepb.SearchIndex
+	20, // 50: edgepb.EdgeRpc.Query:input_type -> edgepb.ScalarQuery
+	34, // 51: edgepb.EdgeRpc.Subscribe:input_type -> edgepb.SubscribeRequest
+	37, // 52: edgepb.EdgeRpc.Ping:output_type -> google.protobuf.Empty
+	12, // 53: edgepb.EdgeRpc.CreateCollection:output_type -> edgepb.CollectionResponse
+	16, // 54: edgepb.EdgeRpc.DeleteCollection:output_type -> edgepb.DeleteCollectionResponse
+	17, // 55: edgepb.EdgeRpc.GetCollection:output_type -> edgepb.CollectionDetail
+	17, // 56: edgepb.EdgeRpc.LoadCollection:output_type -> edgepb.CollectionDetail
+	14, // 57: edgepb.EdgeRpc.ReleaseCollection:output_type -> edgepb.Response
+	14, // 58: edgepb.EdgeRpc.Flush:output_type -> edgepb.Response
+	14, // 59: edgepb.EdgeRpc.Index:output_type -> edgepb.Response
+	31, // 60: edgepb.EdgeRpc.Search:output_type -> edgepb.SearchResponse
+	31, // 61: edgepb.EdgeRpc.Query:output_type -> edgepb.SearchResponse
+	35, // 62: edgepb.EdgeRpc.Subscribe:output_type -> edgepb.ChangeEvent
+	52, // [52:63] is the sub-list for method output_type
+	41, // [41:52] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
 }
 
 func init() { file_idl_proto_v4_edge_proto_init() }
@@ -1753,13 +3168,19 @@ func file_idl_proto_v4_edge_proto_init() {
 	if File_idl_proto_v4_edge_proto != nil {
 		return
 	}
-	file_idl_proto_v4_edge_proto_msgTypes[10].OneofWrappers = []any{
+	file_idl_proto_v4_edge_proto_msgTypes[16].OneofWrappers = []any{
 		(*SearchFilter_StringVal)(nil),
 		(*SearchFilter_IntVal)(nil),
 		(*SearchFilter_FloatVal)(nil),
 		(*SearchFilter_BoolVal)(nil),
 	}
-	file_idl_proto_v4_edge_proto_msgTypes[11].OneofWrappers = []any{
+	file_idl_proto_v4_edge_proto_msgTypes[18].OneofWrappers = []any{
+		(*FilterValue_StringVal)(nil),
+		(*FilterValue_IntVal)(nil),
+		(*FilterValue_FloatVal)(nil),
+		(*FilterValue_BoolVal)(nil),
+	}
+	file_idl_proto_v4_edge_proto_msgTypes[19].OneofWrappers = []any{
 		(*FilterExpression_Filter)(nil),
 		(*FilterExpression_Composite)(nil),
 	}
@@ -1768,8 +3189,8 @@ func file_idl_proto_v4_edge_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_idl_proto_v4_edge_proto_rawDesc,
-			NumEnums:      7,
-			NumMessages:   15,
+			NumEnums:      10,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -46,6 +46,8 @@ const (
 	EdgeRpc_Flush_FullMethodName             = "/edgepb.EdgeRpc/Flush"
 	EdgeRpc_Index_FullMethodName             = "/edgepb.EdgeRpc/Index"
 	EdgeRpc_Search_FullMethodName            = "/edgepb.EdgeRpc/Search"
+	EdgeRpc_Query_FullMethodName             = "/edgepb.EdgeRpc/Query"
+	EdgeRpc_Subscribe_FullMethodName         = "/edgepb.EdgeRpc/Subscribe"
 )
 
 // EdgeRpcClient is the client API for EdgeRpc service.
@@ -61,6 +63,8 @@ type EdgeRpcClient interface {
 	Flush(ctx context.Context, in *CollectionName, opts ...grpc.CallOption) (*Response, error)
 	Index(ctx context.Context, in *IndexChange, opts ...grpc.CallOption) (*Response, error)
 	Search(ctx context.Context, in *SearchIndex, opts ...grpc.CallOption) (*SearchResponse, error)
+	Query(ctx context.Context, in *ScalarQuery, opts ...grpc.CallOption) (*SearchResponse, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangeEvent], error)
 }
 
 type edgeRpcClient struct {
@@ -161,6 +165,35 @@ func (c *edgeRpcClient) Search(ctx context.Context, in *SearchIndex, opts ...grp
 	return out, nil
 }
 
+func (c *edgeRpcClient) Query(ctx context.Context, in *ScalarQuery, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, EdgeRpc_Query_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *edgeRpcClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangeEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EdgeRpc_ServiceDesc.Streams[0], EdgeRpc_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, ChangeEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EdgeRpc_SubscribeClient = grpc.ServerStreamingClient[ChangeEvent]
+
 // EdgeRpcServer is the server API for EdgeRpc service.
 // All implementations should embed UnimplementedEdgeRpcServer
 // for forward compatibility.
@@ -174,6 +207,8 @@ type EdgeRpcServer interface {
 	Flush(context.Context, *CollectionName) (*Response, error)
 	Index(context.Context, *IndexChange) (*Response, error)
 	Search(context.Context, *SearchIndex) (*SearchResponse, error)
+	Query(context.Context, *ScalarQuery) (*SearchResponse, error)
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[ChangeEvent]) error
 }
 
 // UnimplementedEdgeRpcServer should be embedded to have
@@ -210,6 +245,12 @@ func (UnimplementedEdgeRpcServer) Index(context.Context, *IndexChange) (*Respons
 func (UnimplementedEdgeRpcServer) Search(context.Context, *SearchIndex) (*SearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
+func (UnimplementedEdgeRpcServer) Query(context.Context, *ScalarQuery) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Query not implemented")
+}
+func (UnimplementedEdgeRpcServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[ChangeEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
 func (UnimplementedEdgeRpcServer) testEmbeddedByValue() {}
 
 // UnsafeEdgeRpcServer may be embedded to opt out of forward compatibility for this service.
@@ -392,6 +433,35 @@ func _EdgeRpc_Search_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EdgeRpc_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScalarQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EdgeRpcServer).Query(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EdgeRpc_Query_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EdgeRpcServer).Query(ctx, req.(*ScalarQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EdgeRpc_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EdgeRpcServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, ChangeEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EdgeRpc_SubscribeServer = grpc.ServerStreamingServer[ChangeEvent]
+
 // EdgeRpc_ServiceDesc is the grpc.ServiceDesc for EdgeRpc service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -435,7 +505,17 @@ var EdgeRpc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Search",
 			Handler:    _EdgeRpc_Search_Handler,
 		},
+		{
+			MethodName: "Query",
+			Handler:    _EdgeRpc_Query_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _EdgeRpc_Subscribe_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "idl/proto/v4/edge.proto",
 }
@@ -0,0 +1,247 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package changefeed keeps a bounded, per collection log of the changes made
+// to a collection so subscribers can follow it and resume after a reconnect.
+//
+// Sequence numbers grow across restarts (they start from the wall clock), so
+// a sequence from a previous process is never mistaken for a current one.
+// A subscriber resuming from a sequence the log no longer holds gets
+// ErrTruncated and has to resync from a full read of the collection.
+//
+// A collection retains its events only while it has subscribers, and for an
+// idle period after the last one leaves so that a reconnect can resume.
+// Collections nobody follows keep no events.
+//
+// The log lives in memory and starts over on restart. It is not built on the
+// diskv Watch queue, which is a single channel for the whole db without
+// positions to resume from and holds no edge collection. A restart loses
+// the subscriptions anyway, and the clients resync after ErrTruncated.
+package changefeed
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrTruncated = errors.New("changefeed: the requested position is no longer retained")
+	ErrDropped   = errors.New("changefeed: the collection is dropped")
+)
+
+type Op uint8
+
+const (
+	OpInsert Op = iota
+	OpUpdate
+	OpDelete
+)
+
+type Event struct {
+	Seq      uint64
+	Op       Op
+	Id       string
+	Metadata map[string]interface{}
+	Vector   []float32
+	Vectors  map[string][]float32
+}
+
+type Feed struct {
+	capacity int
+	idle     time.Duration
+	mu       sync.Mutex
+	lastSeq  uint64
+	logs     map[string]*collectionLog
+}
+
+// collectionLog holds the events after floor in sequence order, in a ring
+// growing up to the feed capacity. notify is closed and replaced on every
+// publish to wake the waiting subscribers.
+type collectionLog struct {
+	ring        []Event
+	head        int // the oldest event once the ring is full
+	floor       uint64
+	subscribers int
+	idleSince   time.Time // when the last subscriber left
+	notify      chan struct{}
+	dropped     bool
+}
+
+// New returns a feed that retains the last capacity events of each followed
+// collection, until idle has passed since its last subscriber left.
+func New(capacity int, idle time.Duration) *Feed {
+	return &Feed{
+		capacity: capacity,
+		idle:     idle,
+		logs:     make(map[string]*collectionLog),
+	}
+}
+
+func (f *Feed) nextSeq() uint64 {
+	seq := uint64(time.Now().UnixNano())
+	if seq <= f.lastSeq {
+		seq = f.lastSeq + 1
+	}
+	f.lastSeq = seq
+	return seq
+}
+
+func (f *Feed) log(collection string) *collectionLog {
+	l, ok := f.logs[collection]
+	if !ok {
+		l = &collectionLog{
+			floor:  f.nextSeq(),
+			notify: make(chan struct{}),
+		}
+		f.logs[collection] = l
+	}
+	return l
+}
+
+func (l *collectionLog) at(i int) Event {
+	return l.ring[(l.head+i)%len(l.ring)]
+}
+
+func (l *collectionLog) push(event Event, capacity int) {
+	if len(l.ring) < capacity {
+		l.ring = append(l.ring, event)
+		return
+	}
+	l.floor = l.ring[l.head].Seq
+	l.ring[l.head] = event
+	l.head = (l.head + 1) % capacity
+}
+
+// last returns the sequence of the newest event, the floor when there is none.
+func (l *collectionLog) last() uint64 {
+	if len(l.ring) == 0 {
+		return l.floor
+	}
+	return l.at(len(l.ring) - 1).Seq
+}
+
+func (l *collectionLog) retains(idle time.Duration) bool {
+	return l.subscribers > 0 || time.Since(l.idleSince) < idle
+}
+
+// Publish appends the event to the collection log and returns its sequence.
+func (f *Feed) Publish(collection string, event Event) uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	l := f.log(collection)
+	event.Seq = f.nextSeq()
+	if l.retains(f.idle) {
+		l.push(event, f.capacity)
+	} else {
+		// nobody follows the collection, the events so far can't be resumed from
+		l.ring, l.head = nil, 0
+		l.floor = event.Seq
+	}
+	close(l.notify)
+	l.notify = make(chan struct{})
+	return event.Seq
+}
+
+// Drop ends the subscriptions of a dropped collection and forgets its log.
+func (f *Feed) Drop(collection string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if l, ok := f.logs[collection]; ok {
+		l.dropped = true
+		close(l.notify)
+		delete(f.logs, collection)
+	}
+}
+
+// Subscribe follows the collection from the events after the sequence from,
+// from 0 follows only the events published from now on. The subscription
+// is closed when the subscriber leaves.
+func (f *Feed) Subscribe(collection string, from uint64) (*Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	l := f.log(collection)
+	after := from
+	if from == 0 {
+		after = l.last()
+	}
+	if after < l.floor {
+		return nil, ErrTruncated
+	}
+	l.subscribers++
+	return &Subscription{feed: f, log: l, after: after}, nil
+}
+
+type Subscription struct {
+	feed   *Feed
+	log    *collectionLog
+	after  uint64
+	closed bool
+}
+
+// Close ends the subscription, the log is kept for the idle period of the
+// feed after the last subscriber leaves.
+func (s *Subscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	if s.log.subscribers--; s.log.subscribers == 0 {
+		s.log.idleSince = time.Now()
+	}
+}
+
+// Next blocks until the next event is published, or ctx is done.
+func (s *Subscription) Next(ctx context.Context) (Event, error) {
+	for {
+		s.feed.mu.Lock()
+		l := s.log
+		if l.dropped {
+			s.feed.mu.Unlock()
+			return Event{}, ErrDropped
+		}
+		// the subscriber fell behind the retained events
+		if s.after < l.floor {
+			s.feed.mu.Unlock()
+			return Event{}, ErrTruncated
+		}
+		i := sort.Search(len(l.ring), func(i int) bool {
+			return l.at(i).Seq > s.after
+		})
+		if i < len(l.ring) {
+			event := l.at(i)
+			s.after = event.Seq
+			s.feed.mu.Unlock()
+			return event, nil
+		}
+		notify := l.notify
+		s.feed.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return Event{}, ctx.Err()
+		case <-notify:
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package changefeed
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFeedResume(t *testing.T) {
+	feed := New(2, time.Minute)
+	sub, err := feed.Subscribe("col", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := feed.Publish("col", Event{Op: OpInsert, Id: "a"})
+	feed.Publish("col", Event{Op: OpUpdate, Id: "a"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	event, err := sub.Next(ctx)
+	if err != nil || event.Seq != first || event.Id != "a" {
+		t.Fatalf("unexpected first event %+v %v", event, err)
+	}
+
+	// resume after the first event
+	resumed, err := feed.Subscribe("col", first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err = resumed.Next(ctx)
+	if err != nil || event.Op != OpUpdate {
+		t.Fatalf("unexpected resumed event %+v %v", event, err)
+	}
+
+	// the third event pushes the first one out of the log
+	feed.Publish("col", Event{Op: OpDelete, Id: "a"})
+	if _, err := feed.Subscribe("col", first-1); err != ErrTruncated {
+		t.Fatalf("expected ErrTruncated, got %v", err)
+	}
+	// everything after the first event is still retained
+	event, err = sub.Next(ctx)
+	if err != nil || event.Op != OpUpdate {
+		t.Fatalf("unexpected event %+v %v", event, err)
+	}
+}
+
+func TestFeedDrop(t *testing.T) {
+	feed := New(8, time.Minute)
+	sub, err := feed.Subscribe("col", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		feed.Drop("col")
+	}()
+	if _, err := sub.Next(context.Background()); err != ErrDropped {
+		t.Fatalf("expected ErrDropped, got %v", err)
+	}
+}
+
+func TestFeedRetention(t *testing.T) {
+	feed := New(3, 20*time.Millisecond)
+	// nobody follows the collection yet, nothing is retained
+	unfollowed := feed.Publish("col", Event{Op: OpInsert, Id: "a"})
+	if _, err := feed.Subscribe("col", unfollowed-1); err != ErrTruncated {
+		t.Fatalf("expected ErrTruncated, got %v", err)
+	}
+
+	sub, err := feed.Subscribe("col", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var seqs []uint64
+	for _, id := range []string{"b", "c", "d", "e", "f"} {
+		seqs = append(seqs, feed.Publish("col", Event{Op: OpInsert, Id: id}))
+	}
+	// the ring wrapped, the last three events are retained in order
+	if _, err := feed.Subscribe("col", seqs[0]); err != ErrTruncated {
+		t.Fatalf("expected ErrTruncated, got %v", err)
+	}
+	resumed, err := feed.Subscribe("col", seqs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for _, id := range []string{"d", "e", "f"} {
+		event, err := resumed.Next(ctx)
+		if err != nil || event.Id != id {
+			t.Fatalf("expected %s, got %+v %v", id, event, err)
+		}
+	}
+	sub.Close()
+	resumed.Close()
+	resumed.Close()
+
+	// within the idle period a reconnect still resumes
+	g := feed.Publish("col", Event{Op: OpInsert, Id: "g"})
+	reconnected, err := feed.Subscribe("col", seqs[4])
+	if err != nil {
+		t.Fatalf("expected to resume, got %v", err)
+	}
+	reconnected.Close()
+	time.Sleep(30 * time.Millisecond)
+
+	// after it the log is released
+	feed.Publish("col", Event{Op: OpInsert, Id: "h"})
+	if _, err := feed.Subscribe("col", g); err != ErrTruncated {
+		t.Fatalf("expected ErrTruncated, got %v", err)
+	}
+	if n := len(feed.logs["col"].ring); n != 0 {
+		t.Fatalf("expected no retained events, got %d", n)
+	}
+}
@@ -144,10 +144,18 @@ func (t *Table) readMeta() error {
 	return nil
 }
 
-// write the metadata info to the meta file in json format.
+// write the metadata info to the meta file in json format,
+// replacing the metadata written by the previous close.
 func (t *Table) writeMeta() error {
-	encoder := json.NewEncoder(t.metaFile)
-	return encoder.Encode(t.meta)
+	data, err := json.Marshal(t.meta)
+	if err != nil {
+		return err
+	}
+	if err := t.metaFile.Truncate(0); err != nil {
+		return err
+	}
+	_, err = t.metaFile.WriteAt(data, 0)
+	return err
 }
 
 // Close closes the files of the hash table.
@@ -332,6 +340,35 @@ func (t *Table) Delete(key []byte, matchKey MatchKeyFunc) error {
 	}
 }
 
+// Range calls fn for every slot of the hash table, in bucket order.
+// Iteration stops when fn returns false or an error.
+func (t *Table) Range(fn func(Slot) (bool, error)) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for bucketIndex := uint32(0); bucketIndex < t.meta.NumBuckets; bucketIndex++ {
+		bi := t.newBucketIterator(bucketIndex)
+		for {
+			b, err := bi.next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			for _, slot := range b.slots {
+				if slot.Hash == 0 {
+					break
+				}
+				if next, err := fn(slot); !next || err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // Size returns the number of keys in the hash table.
 func (t *Table) Size() uint32 {
 	t.mu.RLock()
@@ -0,0 +1,150 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package expiry tracks the expiry time of documents, so that vector
+// collections can hide and reap rows whose ttl has passed.
+package expiry
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// Table maps a document id to its expiry time in unix nanoseconds.
+// Documents without a ttl are not stored.
+type Table struct {
+	defaultTTL time.Duration
+	mu         sync.RWMutex
+	expireAt   map[uint64]int64
+}
+
+// NewTable creates a table, defaultTTL applies to writes without a ttl, 0 disables it.
+func NewTable(defaultTTL time.Duration) *Table {
+	return &Table{
+		defaultTTL: defaultTTL,
+		expireAt:   make(map[uint64]int64),
+	}
+}
+
+func (t *Table) DefaultTTL() time.Duration {
+	return t.defaultTTL
+}
+
+// ExpireAt returns the expiry time of a document written now with ttl,
+// a zero ttl falls back to the default ttl. 0 means it never expires.
+func (t *Table) ExpireAt(ttl time.Duration) int64 {
+	if ttl <= 0 {
+		ttl = t.defaultTTL
+	}
+	if ttl <= 0 {
+		return 0
+	}
+	return time.Now().Add(ttl).UnixNano()
+}
+
+// Set records the expiry time of a document, 0 removes it from the table.
+func (t *Table) Set(id uint64, expireAt int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if expireAt <= 0 {
+		delete(t.expireAt, id)
+		return
+	}
+	t.expireAt[id] = expireAt
+}
+
+func (t *Table) Del(ids ...uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, id := range ids {
+		delete(t.expireAt, id)
+	}
+}
+
+// IsExpired reports whether the document is expired at now, it is the
+// lookup for searches that test their candidates one by one.
+func (t *Table) IsExpired(id uint64, now int64) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	expireAt, ok := t.expireAt[id]
+	return ok && expireAt <= now
+}
+
+// Expired returns the documents expired at now, it scans the whole table.
+func (t *Table) Expired(now int64) map[uint64]struct{} {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	expired := make(map[uint64]struct{})
+	for id, expireAt := range t.expireAt {
+		if expireAt <= now {
+			expired[id] = struct{}{}
+		}
+	}
+	return expired
+}
+
+func (t *Table) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.expireAt)
+}
+
+// MarshalBinary encodes the table as a count followed by (id, expireAt) pairs.
+// The default ttl is part of the collection config and is not encoded.
+func (t *Table) MarshalBinary() ([]byte, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, binary.BigEndian, uint64(len(t.expireAt))); err != nil {
+		return nil, err
+	}
+	for id, expireAt := range t.expireAt {
+		if err := binary.Write(&buf, binary.BigEndian, id); err != nil {
+			return nil, err
+		}
+		if err := binary.Write(&buf, binary.BigEndian, expireAt); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (t *Table) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	var count uint64
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return err
+	}
+	expireAt := make(map[uint64]int64, count)
+	for i := uint64(0); i < count; i++ {
+		var id uint64
+		var at int64
+		if err := binary.Read(r, binary.BigEndian, &id); err != nil {
+			return err
+		}
+		if err := binary.Read(r, binary.BigEndian, &at); err != nil {
+			return err
+		}
+		expireAt[id] = at
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expireAt = expireAt
+	return nil
+}
@@ -0,0 +1,60 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package expiry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableExpired(t *testing.T) {
+	table := NewTable(time.Hour)
+	now := time.Now().UnixNano()
+	table.Set(1, now-1)
+	table.Set(2, table.ExpireAt(0))
+	table.Set(3, table.ExpireAt(time.Millisecond))
+	table.Set(4, 0)
+	assert.Equal(t, 3, table.Len())
+
+	expired := table.Expired(now)
+	assert.Len(t, expired, 1)
+	assert.Contains(t, expired, uint64(1))
+	assert.True(t, table.IsExpired(1, now))
+	assert.False(t, table.IsExpired(2, now))
+	assert.False(t, table.IsExpired(4, now))
+
+	expired = table.Expired(time.Now().Add(time.Minute).UnixNano())
+	assert.Len(t, expired, 2)
+	assert.NotContains(t, expired, uint64(2))
+}
+
+func TestTableBinary(t *testing.T) {
+	table := NewTable(0)
+	assert.Equal(t, int64(0), table.ExpireAt(0))
+	table.Set(7, 100)
+	table.Set(9, 200)
+	data, err := table.MarshalBinary()
+	assert.NoError(t, err)
+
+	loaded := NewTable(0)
+	assert.NoError(t, loaded.UnmarshalBinary(data))
+	assert.Equal(t, 2, loaded.Len())
+	assert.Len(t, loaded.Expired(150), 1)
+}
@@ -659,6 +659,10 @@ type Writer struct {
 }
 
 func (w *Writer) Write(data []byte) (n int, err error) {
+	// a writer without a key writes plain deflate
+	if len(w.key) == 0 {
+		return w.d.write(data)
+	}
 	byteReader := bytes.NewReader(data)
 	read := 0
 
@@ -370,6 +370,13 @@ func (f *decompressor) Read(b []byte) (int, error) {
 }
 
 func (f *decompressor) Close() error {
+	// a reader without a key reads plain deflate
+	if len(f.key) == 0 {
+		if f.err == io.EOF {
+			return nil
+		}
+		return f.err
+	}
 
 	byteReader := bytes.NewReader(f.dict.hist)
 	read := 0
@@ -0,0 +1,112 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package fulltext is a BM25 scored inverted index over the text fields of
+// documents, for keyword and hybrid keyword plus vector retrieval.
+package fulltext
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	porterstemmer "github.com/blevesearch/go-porterstemmer"
+)
+
+// TokenFilter transforms the token stream of an Analyzer.
+type TokenFilter func(tokens []string) []string
+
+// Analyzer turns a text into the terms it is indexed and searched by,
+// the text is split into runs of letters and digits which pass the filters in order.
+type Analyzer struct {
+	Name    string
+	Filters []TokenFilter
+}
+
+const DefaultAnalyzer = "standard"
+
+var analyzers = map[string]*Analyzer{
+	// simple lowercases the words
+	"simple": {Name: "simple", Filters: []TokenFilter{LowercaseFilter}},
+	// standard also drops the english stop words
+	"standard": {Name: "standard", Filters: []TokenFilter{LowercaseFilter, StopWordFilter(englishStopWords)}},
+	// english also reduces the words to their porter stem
+	"english": {Name: "english", Filters: []TokenFilter{LowercaseFilter, StopWordFilter(englishStopWords), StemFilter}},
+}
+
+// LookupAnalyzer returns the analyzer registered by name, "" is the DefaultAnalyzer.
+func LookupAnalyzer(name string) (*Analyzer, error) {
+	if name == "" {
+		name = DefaultAnalyzer
+	}
+	a, ok := analyzers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown fulltext analyzer: %s", name)
+	}
+	return a, nil
+}
+
+func (a *Analyzer) Analyze(text string) []string {
+	tokens := Tokenize(text)
+	for _, filter := range a.Filters {
+		tokens = filter(tokens)
+	}
+	return tokens
+}
+
+// Tokenize splits text into runs of letters and digits.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func LowercaseFilter(tokens []string) []string {
+	for i, token := range tokens {
+		tokens[i] = strings.ToLower(token)
+	}
+	return tokens
+}
+
+// StopWordFilter drops the tokens in words, it runs after LowercaseFilter.
+func StopWordFilter(words map[string]struct{}) TokenFilter {
+	return func(tokens []string) []string {
+		kept := tokens[:0]
+		for _, token := range tokens {
+			if _, stop := words[token]; !stop {
+				kept = append(kept, token)
+			}
+		}
+		return kept
+	}
+}
+
+func StemFilter(tokens []string) []string {
+	for i, token := range tokens {
+		tokens[i] = porterstemmer.StemString(token)
+	}
+	return tokens
+}
+
+var englishStopWords = func() map[string]struct{} {
+	words := make(map[string]struct{})
+	for _, word := range strings.Fields(`a an and are as at be but by for if in into is it
+		no not of on or such that the their then there these they this to was will with`) {
+		words[word] = struct{}{}
+	}
+	return words
+}()
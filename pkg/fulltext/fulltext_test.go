@@ -0,0 +1,88 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fulltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzer(t *testing.T) {
+	standard, err := LookupAnalyzer("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"quick", "brown", "foxes", "42"}, standard.Analyze("The quick-brown FOXES, 42!"))
+	english, err := LookupAnalyzer("english")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"run", "fox"}, english.Analyze("running foxes"))
+	_, err = LookupAnalyzer("klingon")
+	assert.Error(t, err)
+}
+
+func TestIndexBM25(t *testing.T) {
+	idx, err := New(map[string]string{"title": "standard", "body": "english"})
+	assert.NoError(t, err)
+	idx.Add(1, map[string]string{"title": "vector database", "body": "storing embeddings"})
+	idx.Add(2, map[string]string{"title": "cooking", "body": "a database of recipes and more recipes and stories"})
+	idx.Add(3, map[string]string{"title": "garden", "body": "planting trees"})
+
+	hits, err := idx.Search("database", nil, 10, nil)
+	assert.NoError(t, err)
+	assert.Len(t, hits, 2)
+	// the title match and shorter field outweigh the long body
+	assert.Equal(t, uint64(1), hits[0].Id)
+
+	hits, err = idx.Search("recipe", []string{"body"}, 10, nil)
+	assert.NoError(t, err)
+	assert.Len(t, hits, 1)
+	assert.Equal(t, uint64(2), hits[0].Id)
+
+	hits, err = idx.Search("database", nil, 10, func(id uint64) bool { return id != 1 })
+	assert.NoError(t, err)
+	assert.Len(t, hits, 1)
+
+	_, err = idx.Search("database", []string{"missing"}, 10, nil)
+	assert.Error(t, err)
+
+	// an update replaces the terms of the document
+	idx.Add(1, map[string]string{"title": "garden tools"})
+	hits, _ = idx.Search("database", nil, 10, nil)
+	assert.Len(t, hits, 1)
+	idx.Remove(3)
+	hits, _ = idx.Search("garden", nil, 10, nil)
+	assert.Equal(t, []Hit{{Id: 1, Score: hits[0].Score}}, hits)
+}
+
+func TestIndexBinary(t *testing.T) {
+	idx, _ := New(map[string]string{"body": "english"})
+	idx.Add(1, map[string]string{"body": "running foxes jump"})
+	idx.Add(2, map[string]string{"body": "a fox runs and runs"})
+	want, _ := idx.Search("run fox", nil, 10, nil)
+
+	data, err := idx.MarshalBinary()
+	assert.NoError(t, err)
+	loaded, _ := New(nil)
+	assert.NoError(t, loaded.UnmarshalBinary(data))
+	got, err := loaded.Search("run fox", nil, 10, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	loaded.Remove(2)
+	got, _ = loaded.Search("run fox", nil, 10, nil)
+	assert.Len(t, got, 1)
+}
@@ -0,0 +1,254 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fulltext
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// BM25 parameters, K1 saturates the term frequency and B weighs the
+// document length normalization.
+const (
+	K1 = 1.2
+	B  = 0.75
+)
+
+// Hit is a document matching a query with its BM25 score.
+type Hit struct {
+	Id    uint64
+	Score float64
+}
+
+// Index keeps a posting list per term of each text field.
+type Index struct {
+	mu     sync.RWMutex
+	fields map[string]*field
+}
+
+type field struct {
+	analyzer    *Analyzer
+	postings    map[string]map[uint64]uint32 // term => document => term frequency
+	lengths     map[uint64]uint32            // document => number of terms
+	terms       map[uint64][]string          // document => distinct terms, to remove it
+	totalLength uint64
+}
+
+// New creates an index of the fields, mapped to the name of their analyzer.
+func New(fields map[string]string) (*Index, error) {
+	idx := &Index{fields: make(map[string]*field, len(fields))}
+	for name, analyzerName := range fields {
+		analyzer, err := LookupAnalyzer(analyzerName)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		idx.fields[name] = newField(analyzer)
+	}
+	return idx, nil
+}
+
+func newField(analyzer *Analyzer) *field {
+	return &field{
+		analyzer: analyzer,
+		postings: make(map[string]map[uint64]uint32),
+		lengths:  make(map[uint64]uint32),
+		terms:    make(map[uint64][]string),
+	}
+}
+
+// Fields returns the number of indexed fields.
+func (idx *Index) Fields() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.fields)
+}
+
+// Add indexes the texts of a document by field name, replacing the previous
+// version of the document. Texts of fields not in the index are ignored.
+func (idx *Index) Add(id uint64, texts map[string]string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for name, f := range idx.fields {
+		f.remove(id)
+		if text, ok := texts[name]; ok {
+			f.add(id, text)
+		}
+	}
+}
+
+// Remove drops the documents, unknown ids are skipped.
+func (idx *Index) Remove(ids ...uint64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, f := range idx.fields {
+		for _, id := range ids {
+			f.remove(id)
+		}
+	}
+}
+
+func (f *field) add(id uint64, text string) {
+	tokens := f.analyzer.Analyze(text)
+	if len(tokens) == 0 {
+		return
+	}
+	terms := make([]string, 0, len(tokens))
+	for _, term := range tokens {
+		docs, ok := f.postings[term]
+		if !ok {
+			docs = make(map[uint64]uint32)
+			f.postings[term] = docs
+		}
+		if docs[id] == 0 {
+			terms = append(terms, term)
+		}
+		docs[id]++
+	}
+	f.lengths[id] = uint32(len(tokens))
+	f.terms[id] = terms
+	f.totalLength += uint64(len(tokens))
+}
+
+func (f *field) remove(id uint64) {
+	length, ok := f.lengths[id]
+	if !ok {
+		return
+	}
+	for _, term := range f.terms[id] {
+		docs := f.postings[term]
+		delete(docs, id)
+		if len(docs) == 0 {
+			delete(f.postings, term)
+		}
+	}
+	delete(f.lengths, id)
+	delete(f.terms, id)
+	f.totalLength -= uint64(length)
+}
+
+// Search scores the documents matching any term of query by BM25 summed over
+// fields, every field of the index when fields is empty. allow, if not nil,
+// restricts the documents. The best topK hits are returned by descending score.
+func (idx *Index) Search(query string, fields []string, topK int, allow func(id uint64) bool) ([]Hit, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	if len(fields) == 0 {
+		for name := range idx.fields {
+			fields = append(fields, name)
+		}
+	}
+	scores := make(map[uint64]float64)
+	for _, name := range fields {
+		f, ok := idx.fields[name]
+		if !ok {
+			return nil, fmt.Errorf("field %s has no fulltext index", name)
+		}
+		f.score(query, scores, allow)
+	}
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{Id: id, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Id < hits[j].Id
+	})
+	if topK >= 0 && len(hits) > topK {
+		hits = hits[:topK]
+	}
+	return hits, nil
+}
+
+func (f *field) score(query string, scores map[uint64]float64, allow func(id uint64) bool) {
+	n := float64(len(f.lengths))
+	if n == 0 {
+		return
+	}
+	avgLength := float64(f.totalLength) / n
+	seen := make(map[string]struct{})
+	for _, term := range f.analyzer.Analyze(query) {
+		if _, ok := seen[term]; ok {
+			continue
+		}
+		seen[term] = struct{}{}
+		docs := f.postings[term]
+		if len(docs) == 0 {
+			continue
+		}
+		df := float64(len(docs))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, tf := range docs {
+			if allow != nil && !allow(id) {
+				continue
+			}
+			norm := K1 * (1 - B + B*float64(f.lengths[id])/avgLength)
+			scores[id] += idf * float64(tf) * (K1 + 1) / (float64(tf) + norm)
+		}
+	}
+}
+
+type savedField struct {
+	Analyzer string                       `msgpack:"analyzer"`
+	Postings map[string]map[uint64]uint32 `msgpack:"postings"`
+}
+
+func (idx *Index) MarshalBinary() ([]byte, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	saved := make(map[string]savedField, len(idx.fields))
+	for name, f := range idx.fields {
+		saved[name] = savedField{Analyzer: f.analyzer.Name, Postings: f.postings}
+	}
+	return msgpack.Marshal(saved)
+}
+
+// UnmarshalBinary replaces the index, the document lengths and terms are
+// rebuilt from the postings.
+func (idx *Index) UnmarshalBinary(data []byte) error {
+	var saved map[string]savedField
+	if err := msgpack.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	fields := make(map[string]*field, len(saved))
+	for name, sf := range saved {
+		analyzer, err := LookupAnalyzer(sf.Analyzer)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+		f := newField(analyzer)
+		for term, docs := range sf.Postings {
+			f.postings[term] = docs
+			for id, tf := range docs {
+				f.lengths[id] += tf
+				f.terms[id] = append(f.terms[id], term)
+				f.totalLength += uint64(tf)
+			}
+		}
+		fields[name] = f
+	}
+	idx.mu.Lock()
+	idx.fields = fields
+	idx.mu.Unlock()
+	return nil
+}
@@ -46,7 +46,13 @@ func (idx *BitmapIndex) SearchWitCandidates(
 
 // using pure search
 func (idx *BitmapIndex) PureSearch(filter map[string]string) []uint64 {
-	var result *roaring.Bitmap
+	return idx.FilterBitmap(filter).ToArray()
+}
+
+// FilterBitmap returns the nodes matching every key of the filter,
+// an empty bitmap for an empty filter
+func (idx *BitmapIndex) FilterBitmap(filter map[string]string) *roaring.Bitmap {
+	result := roaring.New()
 	first := true
 
 	for key, value := range filter {
@@ -55,7 +61,7 @@ func (idx *BitmapIndex) PureSearch(filter map[string]string) []uint64 {
 		bm, exists := shard.ShardIndex[value]
 		if !exists {
 			shard.rmu.RUnlock()
-			return []uint64{}
+			return roaring.New()
 		}
 		if first {
 			result = bm.Clone()
@@ -65,8 +71,5 @@ func (idx *BitmapIndex) PureSearch(filter map[string]string) []uint64 {
 		}
 		shard.rmu.RUnlock()
 	}
-	if result != nil {
-		return result.ToArray()
-	}
-	return []uint64{}
+	return result
 }
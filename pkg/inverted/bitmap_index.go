@@ -28,19 +28,78 @@ import (
 type BitmapIndex struct {
 	Shards    map[string]*IndexShard
 	shardLock sync.RWMutex
+	// nodes holds every added node, the universe of IS_NULL and NOT.
+	nodes     *roaring.Bitmap
+	nodesLock sync.RWMutex
 }
 
 type IndexShard struct {
 	ShardIndex map[interface{}]*roaring.Bitmap
+	ranges     *rangeIndex
+	grams      gramIndex
+	geo        *geoIndex
 	rmu        sync.RWMutex
 }
 
 func NewBitmapIndex() *BitmapIndex {
 	return &BitmapIndex{
 		Shards: make(map[string]*IndexShard),
+		nodes:  roaring.New(),
 	}
 }
 
+// allNodes returns a copy of the bitmap of every added node.
+func (idx *BitmapIndex) allNodes() *roaring.Bitmap {
+	idx.nodesLock.RLock()
+	defer idx.nodesLock.RUnlock()
+	return idx.nodes.Clone()
+}
+
+// Nodes returns every added node in ascending order.
+func (idx *BitmapIndex) Nodes() []uint64 {
+	idx.nodesLock.RLock()
+	defer idx.nodesLock.RUnlock()
+	return idx.nodes.ToArray()
+}
+
+// Len returns the number of indexed nodes
+func (idx *BitmapIndex) Len() uint64 {
+	idx.nodesLock.RLock()
+	defer idx.nodesLock.RUnlock()
+	return idx.nodes.GetCardinality()
+}
+
+// indexValue adds a new value of the shard to its auxiliary indexes, the caller holds rmu.
+func (shard *IndexShard) indexValue(val interface{}, bm *roaring.Bitmap) {
+	shard.ranges.insert(val, bm)
+	switch v := val.(type) {
+	case string:
+		shard.grams.insert(v)
+	case GeoPoint:
+		shard.geo.insert(v, bm)
+	}
+}
+
+// unindexValue removes a deleted value of the shard from its auxiliary indexes.
+func (shard *IndexShard) unindexValue(val interface{}) {
+	shard.ranges.delete(val)
+	switch v := val.(type) {
+	case string:
+		shard.grams.delete(v)
+	case GeoPoint:
+		shard.geo.delete(v)
+	}
+}
+
+// union returns the nodes having any value of the shard, the caller holds rmu.
+func (shard *IndexShard) union() *roaring.Bitmap {
+	bms := make([]*roaring.Bitmap, 0, len(shard.ShardIndex))
+	for _, bm := range shard.ShardIndex {
+		bms = append(bms, bm)
+	}
+	return roaring.FastOr(bms...)
+}
+
 func (idx *BitmapIndex) getShard(key string) *IndexShard {
 	idx.shardLock.RLock()
 	shard, exists := idx.Shards[key]
@@ -54,34 +113,76 @@ func (idx *BitmapIndex) getShard(key string) *IndexShard {
 	if !exists {
 		shard = &IndexShard{
 			ShardIndex: make(map[interface{}]*roaring.Bitmap),
+			ranges:     newRangeIndex(),
+			grams:      make(gramIndex),
+			geo:        newGeoIndex(),
 		}
 		idx.Shards[key] = shard
 	}
 	return shard
 }
 
-func (idx *BitmapIndex) Add(nodeId uint64, metadata map[string]interface{}) error {
+// indexEntries returns the values of the metadata by the index they are filed under.
+// Nested objects are filed under dotted paths and the elements of an array
+// under the path of the array, values which can't be a map key are skipped.
+func indexEntries(metadata map[string]interface{}) map[string][]interface{} {
+	entries := make(map[string][]interface{}, len(metadata))
+	var walk func(path string, val interface{})
+	walk = func(path string, val interface{}) {
+		switch v := val.(type) {
+		case map[string]interface{}:
+			for key, child := range v {
+				walk(path+"."+key, child)
+			}
+		case []interface{}:
+			for _, elem := range v {
+				walk(path, elem)
+			}
+		case string, bool, int, int8, int16, int32, int64,
+			uint, uint8, uint16, uint32, uint64, float32, float64, GeoPoint:
+			entries[path] = append(entries[path], v)
+		}
+	}
 	for key, val := range metadata {
+		walk(key, val)
+	}
+	return entries
+}
+
+func (idx *BitmapIndex) Add(nodeId uint64, metadata map[string]interface{}) error {
+	idx.nodesLock.Lock()
+	idx.nodes.Add(nodeId)
+	idx.nodesLock.Unlock()
+	for key, values := range indexEntries(metadata) {
 		shard := idx.getShard(key)
 		shard.rmu.Lock()
-		if _, exists := shard.ShardIndex[val]; !exists {
-			shard.ShardIndex[val] = roaring.New()
+		for _, elem := range values {
+			if _, exists := shard.ShardIndex[elem]; !exists {
+				shard.ShardIndex[elem] = roaring.New()
+				shard.indexValue(elem, shard.ShardIndex[elem])
+			}
+			shard.ShardIndex[elem].Add(nodeId)
 		}
-		shard.ShardIndex[val].Add(nodeId)
 		shard.rmu.Unlock()
 	}
 	return nil
 }
 
 func (idx *BitmapIndex) Remove(nodeId uint64, metadata map[string]interface{}) error {
+	idx.nodesLock.Lock()
+	idx.nodes.Remove(nodeId)
+	idx.nodesLock.Unlock()
 
-	for key, val := range metadata {
+	for key, values := range indexEntries(metadata) {
 		shard := idx.getShard(key)
 		shard.rmu.Lock()
-		if bm, exists := shard.ShardIndex[val]; exists {
-			bm.Remove(nodeId)
-			if bm.IsEmpty() {
-				delete(shard.ShardIndex, val)
+		for _, elem := range values {
+			if bm, exists := shard.ShardIndex[elem]; exists {
+				bm.Remove(nodeId)
+				if bm.IsEmpty() {
+					delete(shard.ShardIndex, elem)
+					shard.unindexValue(elem)
+				}
 			}
 		}
 		if len(shard.ShardIndex) == 0 {
@@ -356,6 +457,9 @@ func compareValues(a, b interface{}) (int, error) {
 }
 
 func satisfiesOp(f *Filter, key interface{}) (bool, error) {
+	if f.Op == OpBetween {
+		return satisfiesBetween(f, key)
+	}
 	cmp, err := compareValues(key, f.Value)
 	if err != nil {
 		return false, err
@@ -377,3 +481,18 @@ func satisfiesOp(f *Filter, key interface{}) (bool, error) {
 		return false, fmt.Errorf("unsupported op")
 	}
 }
+
+func satisfiesBetween(f *Filter, key interface{}) (bool, error) {
+	lower, err := compareValues(key, f.Value)
+	if err != nil {
+		return false, err
+	}
+	if lower < 0 || (lower == 0 && f.LowerExclusive) {
+		return false, nil
+	}
+	upper, err := compareValues(key, f.Upper)
+	if err != nil {
+		return false, err
+	}
+	return upper < 0 || (upper == 0 && !f.UpperExclusive), nil
+}
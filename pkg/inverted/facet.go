@@ -0,0 +1,160 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package inverted
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	roaring "github.com/RoaringBitmap/roaring/v2/roaring64"
+)
+
+// FacetRequest asks for the value counts of an index over a candidate set.
+type FacetRequest struct {
+	IndexName string
+	// TopN keeps the most frequent values, every value when 0.
+	TopN int
+	// Stats adds the min, max, sum and avg of the numeric values.
+	Stats bool
+	// HistogramInterval adds buckets of that width over the numeric values, none when 0.
+	HistogramInterval float64
+}
+
+type FacetCount struct {
+	Value interface{}
+	Count uint64
+}
+
+// NumericStats aggregates the numeric values, a node with several values
+// of an array index counts each of them.
+type NumericStats struct {
+	Count uint64
+	Min   float64
+	Max   float64
+	Sum   float64
+	Avg   float64
+}
+
+// HistogramBucket counts the values in [From, From+interval).
+type HistogramBucket struct {
+	From  float64
+	Count uint64
+}
+
+type FacetResult struct {
+	IndexName string
+	Counts    []FacetCount
+	Stats     *NumericStats
+	Histogram []HistogramBucket
+}
+
+// Facets counts the values of the requested indexes among the nodes matching
+// expr, every node when expr is nil, leaving out exclude.
+func (idx *BitmapIndex) Facets(expr *FilterExpression, exclude []uint64, requests ...FacetRequest) ([]FacetResult, error) {
+	var candidates *roaring.Bitmap
+	if expr == nil {
+		candidates = idx.allNodes()
+	} else {
+		bm, err := idx.evaluateFilterExpression(expr)
+		if err != nil {
+			return nil, err
+		}
+		candidates = bm.Clone()
+	}
+	candidates.AndNot(roaring.BitmapOf(exclude...))
+
+	results := make([]FacetResult, 0, len(requests))
+	for _, req := range requests {
+		if req.HistogramInterval < 0 || math.IsNaN(req.HistogramInterval) || math.IsInf(req.HistogramInterval, 0) {
+			return nil, fmt.Errorf("index: [%s] invalid histogram interval %v", req.IndexName, req.HistogramInterval)
+		}
+		idx.shardLock.RLock()
+		shard, ok := idx.Shards[req.IndexName]
+		idx.shardLock.RUnlock()
+		result := FacetResult{IndexName: req.IndexName}
+		if ok {
+			shard.rmu.RLock()
+			shard.facet(candidates, req, &result)
+			shard.rmu.RUnlock()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// facet fills the result of req from the shard values, the caller holds rmu.
+func (shard *IndexShard) facet(candidates *roaring.Bitmap, req FacetRequest, result *FacetResult) {
+	var stats NumericStats
+	buckets := make(map[float64]uint64)
+	for value, bm := range shard.ShardIndex {
+		count := bm.AndCardinality(candidates)
+		if count == 0 {
+			continue
+		}
+		result.Counts = append(result.Counts, FacetCount{Value: value, Count: count})
+		item, ok := numberOf(value)
+		if !ok || (!req.Stats && req.HistogramInterval == 0) {
+			continue
+		}
+		number := item.f
+		if item.isInt {
+			number = float64(item.i)
+		}
+		if stats.Count == 0 || number < stats.Min {
+			stats.Min = number
+		}
+		if stats.Count == 0 || number > stats.Max {
+			stats.Max = number
+		}
+		stats.Count += count
+		stats.Sum += number * float64(count)
+		if req.HistogramInterval > 0 {
+			buckets[math.Floor(number/req.HistogramInterval)*req.HistogramInterval] += count
+		}
+	}
+
+	sort.Slice(result.Counts, func(i, j int) bool {
+		a, b := result.Counts[i], result.Counts[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if c, err := compareValues(a.Value, b.Value); err == nil {
+			return c < 0
+		}
+		return fmt.Sprint(a.Value) < fmt.Sprint(b.Value)
+	})
+	if req.TopN > 0 && len(result.Counts) > req.TopN {
+		result.Counts = result.Counts[:req.TopN]
+	}
+	if req.Stats {
+		if stats.Count > 0 {
+			stats.Avg = stats.Sum / float64(stats.Count)
+		}
+		result.Stats = &stats
+	}
+	if req.HistogramInterval > 0 {
+		result.Histogram = make([]HistogramBucket, 0, len(buckets))
+		for from, count := range buckets {
+			result.Histogram = append(result.Histogram, HistogramBucket{From: from, Count: count})
+		}
+		sort.Slice(result.Histogram, func(i, j int) bool {
+			return result.Histogram[i].From < result.Histogram[j].From
+		})
+	}
+}
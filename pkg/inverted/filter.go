@@ -28,6 +28,30 @@ const (
 	OpGreaterThanEqual
 	OpLessThan
 	OpLessThanEqual
+	// OpIn matches any of Filter.Values, OpNotIn matches the indexed
+	// values that are none of them.
+	OpIn
+	OpNotIn
+	// OpBetween matches the values between Filter.Value and Filter.Upper.
+	OpBetween
+	// OpIsNull matches the nodes without the index, OpIsNotNull the ones with it.
+	OpIsNull
+	OpIsNotNull
+	// OpPrefix, OpContains and OpRegex match the string values starting with,
+	// containing or fully matching the Value pattern.
+	OpPrefix
+	OpContains
+	OpRegex
+	// OpAnyOf, OpAllOf and OpNoneOf match the array values having any,
+	// all or none of Filter.Values. OpNoneOf includes the nodes without the index.
+	OpAnyOf
+	OpAllOf
+	OpNoneOf
+	// OpGeoRadius matches the GeoPoint values within Filter.Radius meters of
+	// Value, OpGeoBBox the ones in the box from the south-west Value to the
+	// north-east Upper, crossing the antimeridian when Value is east of Upper.
+	OpGeoRadius
+	OpGeoBBox
 )
 
 type LogicalOp int
@@ -35,12 +59,22 @@ type LogicalOp int
 const (
 	LogicalAnd LogicalOp = iota
 	LogicalOr
+	// LogicalNot is the complement of its single expression.
+	LogicalNot
 )
 
 type Filter struct {
 	IndexName string
 	Op        FilterOp
 	Value     interface{}
+	// Values is the value list of OpIn, OpNotIn, OpAnyOf, OpAllOf and OpNoneOf.
+	Values []interface{}
+	// Upper is the upper bound of OpBetween and OpGeoBBox, Value is the lower one.
+	Upper          interface{}
+	LowerExclusive bool
+	UpperExclusive bool
+	// Radius is the distance of OpGeoRadius in meters.
+	Radius float64
 }
 
 func NewFilter(indexName string, op FilterOp, value interface{}) *Filter {
@@ -51,7 +85,58 @@ func NewFilter(indexName string, op FilterOp, value interface{}) *Filter {
 	}
 }
 
+// NewInFilter returns a filter of the value list operators,
+// OpIn, OpNotIn, OpAnyOf, OpAllOf or OpNoneOf.
+func NewInFilter(indexName string, op FilterOp, values ...interface{}) *Filter {
+	return &Filter{
+		IndexName: indexName,
+		Op:        op,
+		Values:    values,
+	}
+}
+
+// NewBetweenFilter returns an OpBetween filter, the bounds are inclusive
+// unless marked exclusive.
+func NewBetweenFilter(indexName string, lower, upper interface{}, lowerExclusive, upperExclusive bool) *Filter {
+	return &Filter{
+		IndexName:      indexName,
+		Op:             OpBetween,
+		Value:          lower,
+		Upper:          upper,
+		LowerExclusive: lowerExclusive,
+		UpperExclusive: upperExclusive,
+	}
+}
+
+// NewGeoRadiusFilter returns an OpGeoRadius filter of the points within meters of center.
+func NewGeoRadiusFilter(indexName string, center GeoPoint, meters float64) *Filter {
+	return &Filter{
+		IndexName: indexName,
+		Op:        OpGeoRadius,
+		Value:     center,
+		Radius:    meters,
+	}
+}
+
+// NewGeoBBoxFilter returns an OpGeoBBox filter of the points in the box.
+func NewGeoBBoxFilter(indexName string, southWest, northEast GeoPoint) *Filter {
+	return &Filter{
+		IndexName: indexName,
+		Op:        OpGeoBBox,
+		Value:     southWest,
+		Upper:     northEast,
+	}
+}
+
 func (f *Filter) String() string {
+	switch f.Op {
+	case OpIn, OpNotIn, OpAnyOf, OpAllOf, OpNoneOf:
+		return fmt.Sprintf("Filter[IndexName=%s, Op=%d, Values=%v]", f.IndexName, f.Op, f.Values)
+	case OpBetween, OpGeoBBox:
+		return fmt.Sprintf("Filter[IndexName=%s, Op=%d, Lower=%v, Upper=%v]", f.IndexName, f.Op, f.Value, f.Upper)
+	case OpGeoRadius:
+		return fmt.Sprintf("Filter[IndexName=%s, Op=%d, Center=%v, Radius=%v]", f.IndexName, f.Op, f.Value, f.Radius)
+	}
 	return fmt.Sprintf("Filter[IndexName=%s, Op=%d, Value=%v]", f.IndexName, f.Op, f.Value)
 }
 
@@ -73,6 +158,11 @@ func NewCompositeExpression(op LogicalOp, exprs ...*FilterExpression) *FilterExp
 	}
 }
 
+// NewNotExpression returns the complement of expr.
+func NewNotExpression(expr *FilterExpression) *FilterExpression {
+	return NewCompositeExpression(LogicalNot, expr)
+}
+
 func (fe *FilterExpression) String() string {
 	if fe.Single != nil {
 		return fmt.Sprintf("Single[%v %v]", fe.Single.IndexName, fe.Single.Value)
@@ -89,8 +179,11 @@ type CompositeFilter struct {
 
 func (cf *CompositeFilter) String() string {
 	opStr := "AND"
-	if cf.Op == LogicalOr {
+	switch cf.Op {
+	case LogicalOr:
 		opStr = "OR"
+	case LogicalNot:
+		opStr = "NOT"
 	}
 	return fmt.Sprintf("%s(%v)", opStr, cf.Expressions)
 }
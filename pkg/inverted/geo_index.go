@@ -0,0 +1,253 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package inverted
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	roaring "github.com/RoaringBitmap/roaring/v2/roaring64"
+	"github.com/google/btree"
+)
+
+// GeoPoint is a latitude and longitude in degrees.
+type GeoPoint struct {
+	Lat float64
+	Lon float64
+}
+
+// Valid reports whether the point is on the globe.
+func (p GeoPoint) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lon >= -180 && p.Lon <= 180
+}
+
+const (
+	// Earth radius in meters
+	earthRadius = 6371000
+	degToRad    = math.Pi / 180
+	// geohashPrecision is the length of the geohash points are ordered by, ~4cm cells.
+	geohashPrecision = 12
+	// maxGeoCells bounds the geohash cells covering a query box.
+	maxGeoCells = 64
+)
+
+const geohashBase32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// HaversineDistance returns the distance in meters between two points on the
+// Earth's surface, the formula of pkg/distancepq.
+func HaversineDistance(a, b GeoPoint) float64 {
+	lata, lona, latb, lonb := a.Lat*degToRad, a.Lon*degToRad, b.Lat*degToRad, b.Lon*degToRad
+	sinDlat, sinDlon := math.Sin((lata-latb)/2), math.Sin((lona-lonb)/2)
+	h := sinDlat*sinDlat + math.Cos(lata)*math.Cos(latb)*sinDlon*sinDlon
+	return earthRadius * 2 * math.Asin(math.Sqrt(math.Min(1, h)))
+}
+
+// geohash encodes the point to a geohash of the given length.
+func geohash(p GeoPoint, precision int) string {
+	latLo, latHi, lonLo, lonHi := -90.0, 90.0, -180.0, 180.0
+	hash := make([]byte, 0, precision)
+	var ch, bit int
+	even := true
+	for len(hash) < precision {
+		if even {
+			mid := (lonLo + lonHi) / 2
+			if p.Lon >= mid {
+				ch |= 1 << (4 - bit)
+				lonLo = mid
+			} else {
+				lonHi = mid
+			}
+		} else {
+			mid := (latLo + latHi) / 2
+			if p.Lat >= mid {
+				ch |= 1 << (4 - bit)
+				latLo = mid
+			} else {
+				latHi = mid
+			}
+		}
+		even = !even
+		if bit++; bit == 5 {
+			hash = append(hash, geohashBase32[ch])
+			ch, bit = 0, 0
+		}
+	}
+	return string(hash)
+}
+
+// geohashCell returns the latitude and longitude extent of the geohash cells of a length.
+func geohashCell(precision int) (lat, lon float64) {
+	bits := 5 * precision
+	return 180 / math.Pow(2, float64(bits/2)), 360 / math.Pow(2, float64(bits-bits/2))
+}
+
+// geoIndex keeps the points of a shard in geohash order, so a geo filter visits
+// the points of the cells covering it. Like rangeIndex it shares the bitmaps
+// of IndexShard.ShardIndex and is rebuilt from it on load.
+type geoIndex struct {
+	points *btree.BTreeG[geoItem]
+}
+
+type geoItem struct {
+	hash  string
+	point GeoPoint
+	bm    *roaring.Bitmap
+}
+
+func geoItemLess(a, b geoItem) bool {
+	if a.hash != b.hash {
+		return a.hash < b.hash
+	}
+	if a.point.Lat != b.point.Lat {
+		return a.point.Lat < b.point.Lat
+	}
+	return a.point.Lon < b.point.Lon
+}
+
+func newGeoIndex() *geoIndex {
+	return &geoIndex{points: btree.NewG(32, geoItemLess)}
+}
+
+func (gi *geoIndex) insert(p GeoPoint, bm *roaring.Bitmap) {
+	gi.points.ReplaceOrInsert(geoItem{hash: geohash(p, geohashPrecision), point: p, bm: bm})
+}
+
+func (gi *geoIndex) delete(p GeoPoint) {
+	gi.points.Delete(geoItem{hash: geohash(p, geohashPrecision), point: p})
+}
+
+// geoBox is a latitude and longitude range not crossing the antimeridian.
+type geoBox struct {
+	minLat, maxLat, minLon, maxLon float64
+}
+
+func (b geoBox) contains(p GeoPoint) bool {
+	return p.Lat >= b.minLat && p.Lat <= b.maxLat && p.Lon >= b.minLon && p.Lon <= b.maxLon
+}
+
+// geoFilterBitmaps returns the bitmaps of the points matching a GEO_RADIUS
+// or GEO_BBOX filter. The caller holds rmu.
+func (shard *IndexShard) geoFilterBitmaps(f *Filter) ([]*roaring.Bitmap, error) {
+	lower, ok := f.Value.(GeoPoint)
+	if !ok || !lower.Valid() {
+		return nil, fmt.Errorf("index: [%s] geo filter expects a valid GeoPoint, got %v", f.IndexName, f.Value)
+	}
+	if f.Op == OpGeoRadius {
+		if f.Radius < 0 || math.IsNaN(f.Radius) {
+			return nil, fmt.Errorf("index: [%s] invalid radius %v", f.IndexName, f.Radius)
+		}
+		return shard.geoBitmaps(radiusBoxes(lower, f.Radius), func(p GeoPoint) bool {
+			return HaversineDistance(lower, p) <= f.Radius
+		}), nil
+	}
+	upper, ok := f.Upper.(GeoPoint)
+	if !ok || !upper.Valid() || upper.Lat < lower.Lat {
+		return nil, fmt.Errorf("index: [%s] GEO_BBOX expects a south-west and a north-east GeoPoint", f.IndexName)
+	}
+	boxes := bboxBoxes(lower, upper)
+	return shard.geoBitmaps(boxes, func(p GeoPoint) bool {
+		for _, box := range boxes {
+			if box.contains(p) {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+// bboxBoxes splits a south-west to north-east box crossing the antimeridian in two.
+func bboxBoxes(sw, ne GeoPoint) []geoBox {
+	if sw.Lon <= ne.Lon {
+		return []geoBox{{sw.Lat, ne.Lat, sw.Lon, ne.Lon}}
+	}
+	return []geoBox{{sw.Lat, ne.Lat, sw.Lon, 180}, {sw.Lat, ne.Lat, -180, ne.Lon}}
+}
+
+// radiusBoxes returns the boxes bounding the circle around center.
+func radiusBoxes(center GeoPoint, meters float64) []geoBox {
+	dLat := meters / earthRadius / degToRad
+	minLat, maxLat := center.Lat-dLat, center.Lat+dLat
+	if minLat <= -90 || maxLat >= 90 || dLat >= 90 {
+		// the circle holds a pole, every longitude
+		return []geoBox{{math.Max(minLat, -90), math.Min(maxLat, 90), -180, 180}}
+	}
+	dLon := math.Asin(math.Min(1, math.Sin(meters/earthRadius)/math.Cos(center.Lat*degToRad))) / degToRad
+	minLon, maxLon := center.Lon-dLon, center.Lon+dLon
+	switch {
+	case dLon >= 180 || maxLon-minLon >= 360:
+		return []geoBox{{minLat, maxLat, -180, 180}}
+	case minLon < -180:
+		return []geoBox{{minLat, maxLat, minLon + 360, 180}, {minLat, maxLat, -180, maxLon}}
+	case maxLon > 180:
+		return []geoBox{{minLat, maxLat, minLon, 180}, {minLat, maxLat, -180, maxLon - 360}}
+	}
+	return []geoBox{{minLat, maxLat, minLon, maxLon}}
+}
+
+// cells returns the geohashes of the longest length whose cells covering the
+// box are at most maxGeoCells, an empty prefix covering everything.
+func (b geoBox) cells() []string {
+	for precision := geohashPrecision; precision > 0; precision-- {
+		latStep, lonStep := geohashCell(precision)
+		latFrom, latTo := math.Floor((b.minLat+90)/latStep), math.Floor((b.maxLat+90)/latStep)
+		lonFrom, lonTo := math.Floor((b.minLon+180)/lonStep), math.Floor((b.maxLon+180)/lonStep)
+		if (latTo-latFrom+1)*(lonTo-lonFrom+1) > maxGeoCells {
+			continue
+		}
+		cells := make([]string, 0, maxGeoCells)
+		for i := latFrom; i <= latTo; i++ {
+			for j := lonFrom; j <= lonTo; j++ {
+				// the center of the cell, clamped on the last cell of a pole or the antimeridian
+				center := GeoPoint{
+					Lat: math.Min(-90+(i+0.5)*latStep, 90),
+					Lon: math.Min(-180+(j+0.5)*lonStep, 180),
+				}
+				cells = append(cells, geohash(center, precision))
+			}
+		}
+		return cells
+	}
+	return []string{""}
+}
+
+// geoBitmaps returns the bitmaps of the points matching a GEO_RADIUS or GEO_BBOX filter,
+// candidates come from the geohash cells covering boxes and are checked by match.
+// The caller holds rmu.
+func (shard *IndexShard) geoBitmaps(boxes []geoBox, match func(GeoPoint) bool) []*roaring.Bitmap {
+	var bms []*roaring.Bitmap
+	seen := make(map[string]struct{})
+	for _, box := range boxes {
+		for _, cell := range box.cells() {
+			if _, ok := seen[cell]; ok {
+				continue
+			}
+			seen[cell] = struct{}{}
+			shard.geo.points.AscendGreaterOrEqual(geoItem{hash: cell}, func(item geoItem) bool {
+				if !strings.HasPrefix(item.hash, cell) {
+					return false
+				}
+				if match(item.point) {
+					bms = append(bms, item.bm)
+				}
+				return true
+			})
+		}
+	}
+	return bms
+}
@@ -0,0 +1,136 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package inverted
+
+import (
+	"regexp"
+	"strings"
+
+	roaring "github.com/RoaringBitmap/roaring/v2/roaring64"
+)
+
+// gramSize is the n of the n-gram index, needles shorter than it are
+// matched against every string value of the shard.
+const gramSize = 3
+
+// gramIndex maps each n-gram to the distinct string values containing it,
+// a substring filter verifies only the values sharing all n-grams of the needle.
+type gramIndex map[string]map[string]struct{}
+
+func grams(s string) []string {
+	if len(s) < gramSize {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(s)-gramSize+1)
+	out := make([]string, 0, len(s)-gramSize+1)
+	for i := 0; i+gramSize <= len(s); i++ {
+		g := s[i : i+gramSize]
+		if _, ok := seen[g]; ok {
+			continue
+		}
+		seen[g] = struct{}{}
+		out = append(out, g)
+	}
+	return out
+}
+
+func (gi gramIndex) insert(value string) {
+	for _, g := range grams(value) {
+		values, ok := gi[g]
+		if !ok {
+			values = make(map[string]struct{})
+			gi[g] = values
+		}
+		values[value] = struct{}{}
+	}
+}
+
+func (gi gramIndex) delete(value string) {
+	for _, g := range grams(value) {
+		if values, ok := gi[g]; ok {
+			delete(values, value)
+			if len(values) == 0 {
+				delete(gi, g)
+			}
+		}
+	}
+}
+
+// containsBitmaps returns the bitmaps of the string values containing needle,
+// the caller holds rmu.
+func (shard *IndexShard) containsBitmaps(needle string) []*roaring.Bitmap {
+	var bms []*roaring.Bitmap
+	needleGrams := grams(needle)
+	if len(needleGrams) == 0 {
+		shard.ranges.strings.Ascend(func(item stringItem) bool {
+			if strings.Contains(item.s, needle) {
+				bms = append(bms, item.bm)
+			}
+			return true
+		})
+		return bms
+	}
+	// start from the rarest n-gram
+	candidates := shard.grams[needleGrams[0]]
+	for _, g := range needleGrams[1:] {
+		if values := shard.grams[g]; len(values) < len(candidates) {
+			candidates = values
+		}
+	}
+	for value := range candidates {
+		if strings.Contains(value, needle) {
+			bms = append(bms, shard.ShardIndex[value])
+		}
+	}
+	return bms
+}
+
+// prefixBitmaps returns the bitmaps of the string values starting with prefix,
+// the caller holds rmu.
+func (shard *IndexShard) prefixBitmaps(prefix string) []*roaring.Bitmap {
+	var bms []*roaring.Bitmap
+	shard.ranges.strings.AscendGreaterOrEqual(stringItem{s: prefix}, func(item stringItem) bool {
+		if !strings.HasPrefix(item.s, prefix) {
+			return false
+		}
+		bms = append(bms, item.bm)
+		return true
+	})
+	return bms
+}
+
+// regexBitmaps returns the bitmaps of the string values fully matching pattern,
+// only the values sharing its literal prefix are tried. The caller holds rmu.
+func (shard *IndexShard) regexBitmaps(pattern string) ([]*roaring.Bitmap, error) {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, err
+	}
+	prefix, _ := re.LiteralPrefix()
+	var bms []*roaring.Bitmap
+	shard.ranges.strings.AscendGreaterOrEqual(stringItem{s: prefix}, func(item stringItem) bool {
+		if !strings.HasPrefix(item.s, prefix) {
+			return false
+		}
+		if re.MatchString(item.s) {
+			bms = append(bms, item.bm)
+		}
+		return true
+	})
+	return bms, nil
+}
@@ -0,0 +1,209 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package inverted
+
+import (
+	"math"
+
+	roaring "github.com/RoaringBitmap/roaring/v2/roaring64"
+	"github.com/google/btree"
+)
+
+// rangeIndex keeps the values of a shard in order, so a range filter visits
+// the values in range instead of every distinct value of the shard.
+// It shares the bitmaps of IndexShard.ShardIndex and is rebuilt from it on load,
+// SerializeBinary leaves it out on purpose: it only holds the order of the
+// ShardIndex values, storing it would write every value twice and tie the file
+// format to the tree layout, while rebuilding it is a sort of the distinct values.
+type rangeIndex struct {
+	numbers *btree.BTreeG[numberItem]
+	strings *btree.BTreeG[stringItem]
+}
+
+type numberItem struct {
+	i     int64
+	f     float64
+	isInt bool
+	rank  int // orders the types of an equal number, -1 for a search pivot
+	bm    *roaring.Bitmap
+}
+
+type stringItem struct {
+	s  string
+	bm *roaring.Bitmap
+}
+
+func newRangeIndex() *rangeIndex {
+	return &rangeIndex{
+		numbers: btree.NewG(32, numberLess),
+		strings: btree.NewG(32, func(a, b stringItem) bool { return a.s < b.s }),
+	}
+}
+
+// numberOf converts the value types compareValues orders numerically.
+// NaN has no order and is left to the scan.
+func numberOf(v interface{}) (numberItem, bool) {
+	switch n := v.(type) {
+	case int:
+		return numberItem{i: int64(n), f: float64(n), isInt: true, rank: 0}, true
+	case int64:
+		return numberItem{i: n, f: float64(n), isInt: true, rank: 1}, true
+	case float32:
+		if math.IsNaN(float64(n)) {
+			return numberItem{}, false
+		}
+		return numberItem{f: float64(n), rank: 2}, true
+	case float64:
+		if math.IsNaN(n) {
+			return numberItem{}, false
+		}
+		return numberItem{f: n, rank: 3}, true
+	}
+	return numberItem{}, false
+}
+
+func compareNumbers(a, b numberItem) int {
+	if a.isInt && b.isInt {
+		switch {
+		case a.i < b.i:
+			return -1
+		case a.i > b.i:
+			return 1
+		}
+		return 0
+	}
+	switch {
+	case a.f < b.f:
+		return -1
+	case a.f > b.f:
+		return 1
+	}
+	return 0
+}
+
+func numberLess(a, b numberItem) bool {
+	if c := compareNumbers(a, b); c != 0 {
+		return c < 0
+	}
+	return a.rank < b.rank
+}
+
+func (ri *rangeIndex) insert(value interface{}, bm *roaring.Bitmap) {
+	if item, ok := numberOf(value); ok {
+		item.bm = bm
+		ri.numbers.ReplaceOrInsert(item)
+	} else if s, ok := value.(string); ok {
+		ri.strings.ReplaceOrInsert(stringItem{s: s, bm: bm})
+	}
+}
+
+func (ri *rangeIndex) delete(value interface{}) {
+	if item, ok := numberOf(value); ok {
+		ri.numbers.Delete(item)
+	} else if s, ok := value.(string); ok {
+		ri.strings.Delete(stringItem{s: s})
+	}
+}
+
+// rangeBitmaps returns the bitmaps of the values matching a GT, GTE, LT, LTE
+// or BETWEEN filter. ok is false when the filter is answered by the scan,
+// the shard mixes value types or the bounds are of another type than the values,
+// which compareValues converts.
+func (shard *IndexShard) rangeBitmaps(f *Filter) (bms []*roaring.Bitmap, ok bool) {
+	var lower, upper interface{}
+	var lowerExclusive, upperExclusive bool
+	switch f.Op {
+	case OpGreaterThan:
+		lower, lowerExclusive = f.Value, true
+	case OpGreaterThanEqual:
+		lower = f.Value
+	case OpLessThan:
+		upper, upperExclusive = f.Value, true
+	case OpLessThanEqual:
+		upper = f.Value
+	case OpBetween:
+		lower, upper = f.Value, f.Upper
+		lowerExclusive, upperExclusive = f.LowerExclusive, f.UpperExclusive
+	default:
+		return nil, false
+	}
+
+	ri := shard.ranges
+	switch {
+	case ri.numbers.Len() == len(shard.ShardIndex):
+		var lo, up numberItem
+		if lower != nil {
+			if lo, ok = numberOf(lower); !ok {
+				return nil, false
+			}
+		}
+		if upper != nil {
+			if up, ok = numberOf(upper); !ok {
+				return nil, false
+			}
+		}
+		visit := func(item numberItem) bool {
+			if upper != nil {
+				if c := compareNumbers(item, up); c > 0 || (c == 0 && upperExclusive) {
+					return false
+				}
+			}
+			if lower != nil && lowerExclusive && compareNumbers(item, lo) == 0 {
+				return true
+			}
+			bms = append(bms, item.bm)
+			return true
+		}
+		if lower == nil {
+			ri.numbers.Ascend(visit)
+		} else {
+			lo.rank = -1
+			ri.numbers.AscendGreaterOrEqual(lo, visit)
+		}
+		return bms, true
+	case ri.strings.Len() == len(shard.ShardIndex):
+		var lo, up string
+		if lower != nil {
+			if lo, ok = lower.(string); !ok {
+				return nil, false
+			}
+		}
+		if upper != nil {
+			if up, ok = upper.(string); !ok {
+				return nil, false
+			}
+		}
+		visit := func(item stringItem) bool {
+			if upper != nil && (item.s > up || (item.s == up && upperExclusive)) {
+				return false
+			}
+			if lower != nil && lowerExclusive && item.s == lo {
+				return true
+			}
+			bms = append(bms, item.bm)
+			return true
+		}
+		if lower == nil {
+			ri.strings.Ascend(visit)
+		} else {
+			ri.strings.AscendGreaterOrEqual(stringItem{s: lo}, visit)
+		}
+		return bms, true
+	}
+	return nil, false
+}
@@ -84,6 +84,29 @@ func writeValue(buf *bytes.Buffer, val interface{}) error {
 			b = 1
 		}
 		return buf.WriteByte(b)
+	case []interface{}:
+		// Type tag 4: array
+		if err := buf.WriteByte(4); err != nil {
+			return err
+		}
+		if err := binary.Write(buf, binary.BigEndian, uint32(len(v))); err != nil {
+			return err
+		}
+		for _, elem := range v {
+			if _, nested := elem.([]interface{}); nested {
+				return fmt.Errorf("unsupported metadata type: nested array")
+			}
+			if err := writeValue(buf, elem); err != nil {
+				return err
+			}
+		}
+		return nil
+	case GeoPoint:
+		// Type tag 5: geo point
+		if err := buf.WriteByte(5); err != nil {
+			return err
+		}
+		return binary.Write(buf, binary.BigEndian, [2]float64{v.Lat, v.Lon})
 	default:
 		return fmt.Errorf("unsupported metadata type: %T", v)
 	}
@@ -123,6 +146,29 @@ func readValue(buf *bytes.Reader) (interface{}, error) {
 			return nil, err
 		}
 		return b != 0, nil
+	case 4: // array
+		var count uint32
+		if err := binary.Read(buf, binary.BigEndian, &count); err != nil {
+			return nil, err
+		}
+		if int64(count) > int64(buf.Len()) {
+			return nil, fmt.Errorf("array length %d exceeds the data", count)
+		}
+		arr := make([]interface{}, count)
+		for i := range arr {
+			val, err := readValue(buf)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = val
+		}
+		return arr, nil
+	case 5: // geo point
+		var latLon [2]float64
+		if err := binary.Read(buf, binary.BigEndian, &latLon); err != nil {
+			return nil, err
+		}
+		return GeoPoint{Lat: latLon[0], Lon: latLon[1]}, nil
 	default:
 		return nil, fmt.Errorf("unsupported metadata type tag: %d", tag)
 	}
@@ -183,6 +229,17 @@ func (idx *BitmapIndex) SerializeBinary() ([]byte, error) {
 		shard.rmu.RUnlock()
 	}
 	idx.shardLock.RUnlock()
+	// the node bitmap trails the shards, older files end after them
+	nodesBytes, err := idx.allNodes().ToBytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize node bitmap: %v", err)
+	}
+	if err := binary.Write(buf, binary.LittleEndian, uint32(len(nodesBytes))); err != nil {
+		return nil, fmt.Errorf("failed to write node bitmap length: %v", err)
+	}
+	if _, err := buf.Write(nodesBytes); err != nil {
+		return nil, fmt.Errorf("failed to write node bitmap data: %v", err)
+	}
 	return buf.Bytes(), nil
 }
 
@@ -227,8 +284,35 @@ func (idx *BitmapIndex) DeserializeBinary(data []byte) error {
 			}
 			shard.rmu.Lock()
 			shard.ShardIndex[val] = bitmap
+			shard.indexValue(val, bitmap)
 			shard.rmu.Unlock()
 		}
 	}
+	nodes := roaring.New()
+	if buf.Len() == 0 {
+		// written before the node bitmap was kept, every indexed node is a node
+		idx.shardLock.RLock()
+		for _, shard := range idx.Shards {
+			shard.rmu.RLock()
+			nodes.Or(shard.union())
+			shard.rmu.RUnlock()
+		}
+		idx.shardLock.RUnlock()
+	} else {
+		var nodesLength uint32
+		if err := binary.Read(buf, binary.LittleEndian, &nodesLength); err != nil {
+			return fmt.Errorf("failed to read node bitmap length: %v", err)
+		}
+		nodesBytes := make([]byte, nodesLength)
+		if _, err := io.ReadFull(buf, nodesBytes); err != nil {
+			return fmt.Errorf("failed to read node bitmap data: %v", err)
+		}
+		if err := nodes.UnmarshalBinary(nodesBytes); err != nil {
+			return fmt.Errorf("failed to unmarshal node bitmap: %v", err)
+		}
+	}
+	idx.nodesLock.Lock()
+	idx.nodes = nodes
+	idx.nodesLock.Unlock()
 	return nil
 }
@@ -24,16 +24,93 @@ import (
 )
 
 func (idx *BitmapIndex) evaluateSingleFilter(f *Filter) (*roaring.Bitmap, error) {
+	var nodes *roaring.Bitmap
+	if f.Op == OpIsNull || f.Op == OpNoneOf {
+		nodes = idx.allNodes()
+	}
 	shard := idx.getShard(f.IndexName)
 	result := roaring.New()
 	shard.rmu.RLock()
 	defer shard.rmu.RUnlock()
 
-	if f.Op == OpEqual {
+	switch f.Op {
+	case OpEqual:
 		if bm, exists := shard.ShardIndex[f.Value]; exists {
 			result.Or(bm)
 		}
-	} else {
+	case OpIn, OpNotIn:
+		bms := make([]*roaring.Bitmap, 0, len(f.Values))
+		for _, value := range f.Values {
+			if bm, exists := shard.ShardIndex[value]; exists {
+				bms = append(bms, bm)
+			}
+		}
+		result = roaring.FastOr(bms...)
+		if f.Op == OpNotIn {
+			indexed := shard.union()
+			indexed.AndNot(result)
+			result = indexed
+		}
+	case OpAnyOf, OpNoneOf:
+		bms := make([]*roaring.Bitmap, 0, len(f.Values))
+		for _, value := range f.Values {
+			if bm, exists := shard.ShardIndex[value]; exists {
+				bms = append(bms, bm)
+			}
+		}
+		result = roaring.FastOr(bms...)
+		if f.Op == OpNoneOf {
+			nodes.AndNot(result)
+			result = nodes
+		}
+	case OpAllOf:
+		bms := make([]*roaring.Bitmap, 0, len(f.Values))
+		for _, value := range f.Values {
+			bm, exists := shard.ShardIndex[value]
+			if !exists {
+				// a value no node has
+				bms = nil
+				break
+			}
+			bms = append(bms, bm)
+		}
+		if len(bms) > 0 {
+			result = roaring.FastAnd(bms...)
+		}
+	case OpPrefix, OpContains, OpRegex:
+		pattern, ok := f.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("index: [%s] string operator expects a string value, got %T", f.IndexName, f.Value)
+		}
+		var bms []*roaring.Bitmap
+		switch f.Op {
+		case OpPrefix:
+			bms = shard.prefixBitmaps(pattern)
+		case OpContains:
+			bms = shard.containsBitmaps(pattern)
+		default:
+			var err error
+			if bms, err = shard.regexBitmaps(pattern); err != nil {
+				return nil, fmt.Errorf("index: [%s] invalid regex: %v", f.IndexName, err)
+			}
+		}
+		result = roaring.FastOr(bms...)
+	case OpGeoRadius, OpGeoBBox:
+		bms, err := shard.geoFilterBitmaps(f)
+		if err != nil {
+			return nil, err
+		}
+		result = roaring.FastOr(bms...)
+	case OpIsNotNull:
+		result = shard.union()
+	case OpIsNull:
+		nodes.AndNot(shard.union())
+		result = nodes
+	default:
+		if bms, ok := shard.rangeBitmaps(f); ok {
+			result = roaring.FastOr(bms...)
+			break
+		}
 		for key, bm := range shard.ShardIndex {
 			match, err := satisfiesOp(f, key)
 			if err != nil {
@@ -70,6 +147,16 @@ func (idx *BitmapIndex) evaluateCompositeFilter(cf *CompositeFilter) (*roaring.B
 			}
 			result.Or(bm)
 		}
+	} else if cf.Op == LogicalNot {
+		if len(cf.Expressions) != 1 {
+			return nil, fmt.Errorf("NOT expects a single expression, got %d", len(cf.Expressions))
+		}
+		bm, err := idx.evaluateFilterExpression(cf.Expressions[0])
+		if err != nil {
+			return nil, err
+		}
+		result = idx.allNodes()
+		result.AndNot(bm)
 	} else {
 		return nil, fmt.Errorf("unsupported composite op")
 	}
@@ -117,3 +204,8 @@ func (idx *BitmapIndex) SearchWithExpression(expr *FilterExpression) ([]uint64,
 	}
 	return bm.ToArray(), nil
 }
+
+// SearchBitmap evaluates the expression into a bitmap owned by the caller
+func (idx *BitmapIndex) SearchBitmap(expr *FilterExpression) (*roaring.Bitmap, error) {
+	return idx.evaluateFilterExpression(expr)
+}
@@ -0,0 +1,268 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package inverted
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testIndex(t *testing.T) *BitmapIndex {
+	idx := NewBitmapIndex()
+	docs := map[uint64]map[string]interface{}{
+		1: {"name": "apple pie", "price": 10, "tags": []interface{}{"fruit", "sweet"},
+			"shop": map[string]interface{}{"city": "seoul"}, "loc": GeoPoint{Lat: 37.5665, Lon: 126.9780}},
+		2: {"name": "banana bread", "price": 20.5, "tags": []interface{}{"fruit", "bread"},
+			"shop": map[string]interface{}{"city": "busan"}, "loc": GeoPoint{Lat: 35.1796, Lon: 129.0756}},
+		3: {"name": "apricot jam", "price": 30, "tags": []interface{}{"fruit"},
+			"shop": map[string]interface{}{"city": "seoul"}, "loc": GeoPoint{Lat: 37.4563, Lon: 126.7052}},
+		4: {"name": "cherry cola"},
+	}
+	for id, metadata := range docs {
+		assert.Nil(t, idx.Add(id, metadata))
+	}
+	return idx
+}
+
+func search(t *testing.T, idx *BitmapIndex, expr *FilterExpression) []uint64 {
+	ids, err := idx.SearchWithExpression(expr)
+	assert.Nil(t, err)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func TestSetAndNullOperators(t *testing.T) {
+	idx := testIndex(t)
+	cases := []struct {
+		filter *Filter
+		ids    []uint64
+	}{
+		{NewInFilter("name", OpIn, "apple pie", "cherry cola", "missing"), []uint64{1, 4}},
+		{NewInFilter("name", OpNotIn, "apple pie", "cherry cola"), []uint64{2, 3}},
+		// nodes without the index are not in NOT_IN
+		{NewInFilter("price", OpNotIn, 10), []uint64{2, 3}},
+		{NewBetweenFilter("price", 10, 30, false, false), []uint64{1, 2, 3}},
+		{NewBetweenFilter("price", 10, 30, true, true), []uint64{2}},
+		{NewBetweenFilter("price", 20, 25.0, false, false), []uint64{2}},
+		{NewFilter("price", OpIsNull, nil), []uint64{4}},
+		{NewFilter("price", OpIsNotNull, nil), []uint64{1, 2, 3}},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.ids, search(t, idx, NewSingleExpression(c.filter)), c.filter.String())
+	}
+
+	// NOT is the complement over every node, including the ones without the index
+	not := NewNotExpression(NewSingleExpression(NewFilter("price", OpGreaterThan, 15)))
+	assert.Equal(t, []uint64{1, 4}, search(t, idx, not))
+	_, err := idx.SearchWithExpression(NewCompositeExpression(LogicalNot))
+	assert.NotNil(t, err)
+}
+
+func TestRangeFiltersAfterReload(t *testing.T) {
+	idx := testIndex(t)
+	// a shard mixing value types is answered by the scan, which converts "1" to a number
+	assert.Nil(t, idx.Add(5, map[string]interface{}{"mixed": 1}))
+	assert.Nil(t, idx.Add(6, map[string]interface{}{"mixed": "1"}))
+
+	data, err := idx.SerializeBinary()
+	assert.Nil(t, err)
+	reloaded := NewBitmapIndex()
+	assert.Nil(t, reloaded.DeserializeBinary(data))
+
+	cases := []struct {
+		filter *Filter
+		ids    []uint64
+	}{
+		{NewFilter("price", OpGreaterThan, 10), []uint64{2, 3}},
+		{NewFilter("price", OpGreaterThanEqual, 10.0), []uint64{1, 2, 3}},
+		{NewFilter("price", OpLessThan, 30), []uint64{1, 2}},
+		{NewFilter("price", OpLessThanEqual, 20.5), []uint64{1, 2}},
+		{NewFilter("name", OpGreaterThan, "apricot jam"), []uint64{2, 4}},
+		{NewFilter("name", OpLessThanEqual, "apricot jam"), []uint64{1, 3}},
+		{NewBetweenFilter("name", "b", "c", false, true), []uint64{2}},
+		{NewFilter("mixed", OpGreaterThanEqual, 1), []uint64{5, 6}},
+	}
+	for _, c := range cases {
+		for _, idx := range []*BitmapIndex{idx, reloaded} {
+			assert.Equal(t, c.ids, search(t, idx, NewSingleExpression(c.filter)), c.filter.String())
+		}
+	}
+}
+
+func TestStringMatchOperators(t *testing.T) {
+	idx := testIndex(t)
+	cases := []struct {
+		filter *Filter
+		ids    []uint64
+	}{
+		{NewFilter("name", OpPrefix, "ap"), []uint64{1, 3}},
+		{NewFilter("name", OpPrefix, ""), []uint64{1, 2, 3, 4}},
+		{NewFilter("name", OpContains, "pie"), []uint64{1}},
+		{NewFilter("name", OpContains, "r"), []uint64{2, 3, 4}},
+		{NewFilter("name", OpContains, "na b"), []uint64{2}},
+		{NewFilter("name", OpRegex, "^a.*(pie|jam)$"), []uint64{1, 3}},
+		// the pattern has to match the whole value
+		{NewFilter("name", OpRegex, "cherry"), nil},
+	}
+	for _, c := range cases {
+		ids := search(t, idx, NewSingleExpression(c.filter))
+		if c.ids == nil {
+			assert.Empty(t, ids, c.filter.String())
+			continue
+		}
+		assert.Equal(t, c.ids, ids, c.filter.String())
+	}
+
+	// the n-gram index follows the removals
+	assert.Nil(t, idx.Remove(1, map[string]interface{}{"name": "apple pie"}))
+	assert.Empty(t, search(t, idx, NewSingleExpression(NewFilter("name", OpContains, "pie"))))
+
+	_, err := idx.SearchWithExpression(NewSingleExpression(NewFilter("name", OpRegex, "(")))
+	assert.NotNil(t, err)
+	_, err = idx.SearchWithExpression(NewSingleExpression(NewFilter("name", OpPrefix, 1)))
+	assert.NotNil(t, err)
+}
+
+func TestArrayOperators(t *testing.T) {
+	idx := testIndex(t)
+	cases := []struct {
+		filter *Filter
+		ids    []uint64
+	}{
+		{NewInFilter("tags", OpAnyOf, "sweet", "bread"), []uint64{1, 2}},
+		{NewInFilter("tags", OpAllOf, "fruit", "sweet"), []uint64{1}},
+		{NewInFilter("tags", OpAllOf, "fruit", "missing"), nil},
+		// nodes without the index have none of the values
+		{NewInFilter("tags", OpNoneOf, "sweet"), []uint64{2, 3, 4}},
+		{NewFilter("tags", OpEqual, "fruit"), []uint64{1, 2, 3}},
+	}
+	for _, c := range cases {
+		ids := search(t, idx, NewSingleExpression(c.filter))
+		if c.ids == nil {
+			assert.Empty(t, ids, c.filter.String())
+			continue
+		}
+		assert.Equal(t, c.ids, ids, c.filter.String())
+	}
+
+	// removing a node drops it from every element of its array
+	assert.Nil(t, idx.Remove(2, map[string]interface{}{"tags": []interface{}{"fruit", "bread"}}))
+	assert.Equal(t, []uint64{1, 3}, search(t, idx, NewSingleExpression(NewFilter("tags", OpEqual, "fruit"))))
+	assert.Empty(t, search(t, idx, NewSingleExpression(NewFilter("tags", OpEqual, "bread"))))
+}
+
+func TestNestedPaths(t *testing.T) {
+	idx := testIndex(t)
+	assert.Nil(t, idx.Add(7, map[string]interface{}{
+		"shop": map[string]interface{}{
+			"city":  "seoul",
+			"owner": map[string]interface{}{"name": "kim"},
+			// the objects of an array are filed under the path of the array
+			"staff": []interface{}{
+				map[string]interface{}{"name": "lee"},
+				map[string]interface{}{"name": "park"},
+			},
+		},
+	}))
+
+	cases := []struct {
+		filter *Filter
+		ids    []uint64
+	}{
+		{NewFilter("shop.city", OpEqual, "seoul"), []uint64{1, 3, 7}},
+		{NewFilter("shop.owner.name", OpEqual, "kim"), []uint64{7}},
+		{NewInFilter("shop.staff.name", OpAllOf, "lee", "park"), []uint64{7}},
+		{NewFilter("shop", OpIsNotNull, nil), nil},
+		{NewFilter("shop.city", OpIsNull, nil), []uint64{4}},
+	}
+	for _, c := range cases {
+		ids := search(t, idx, NewSingleExpression(c.filter))
+		if c.ids == nil {
+			assert.Empty(t, ids, c.filter.String())
+			continue
+		}
+		assert.Equal(t, c.ids, ids, c.filter.String())
+	}
+}
+
+func TestGeoOperators(t *testing.T) {
+	idx := testIndex(t)
+	fiji, samoa := GeoPoint{Lat: -17.7134, Lon: 178.0650}, GeoPoint{Lat: -13.7590, Lon: -172.1046}
+	assert.Nil(t, idx.Add(8, map[string]interface{}{"loc": fiji}))
+	assert.Nil(t, idx.Add(9, map[string]interface{}{"loc": samoa}))
+	seoul := GeoPoint{Lat: 37.5665, Lon: 126.9780}
+	antimeridian := GeoPoint{Lat: -15, Lon: 180}
+
+	cases := []struct {
+		filter *Filter
+		ids    []uint64
+	}{
+		{NewGeoRadiusFilter("loc", seoul, 10_000), []uint64{1}},
+		{NewGeoRadiusFilter("loc", seoul, 30_000), []uint64{1, 3}},
+		{NewGeoRadiusFilter("loc", seoul, 400_000), []uint64{1, 2, 3}},
+		{NewGeoRadiusFilter("loc", antimeridian, 1_000_000), []uint64{8, 9}},
+		{NewGeoBBoxFilter("loc", GeoPoint{Lat: 33, Lon: 124}, GeoPoint{Lat: 39, Lon: 131}), []uint64{1, 2, 3}},
+		{NewGeoBBoxFilter("loc", GeoPoint{Lat: 37, Lon: 126.9}, GeoPoint{Lat: 38, Lon: 127.1}), []uint64{1}},
+		// the box crosses the antimeridian when the south-west corner is east of the north-east one
+		{NewGeoBBoxFilter("loc", GeoPoint{Lat: -20, Lon: 170}, GeoPoint{Lat: -10, Lon: -170}), []uint64{8, 9}},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.ids, search(t, idx, NewSingleExpression(c.filter)), c.filter.String())
+	}
+	assert.True(t, HaversineDistance(antimeridian, samoa) <= 1_000_000)
+
+	invalid := []*Filter{
+		NewGeoRadiusFilter("loc", seoul, -1),
+		NewGeoRadiusFilter("loc", GeoPoint{Lat: 91}, 10),
+		NewGeoBBoxFilter("loc", GeoPoint{Lat: 10}, GeoPoint{Lat: 0}),
+	}
+	for _, f := range invalid {
+		_, err := idx.SearchWithExpression(NewSingleExpression(f))
+		assert.NotNil(t, err, f.String())
+	}
+}
+
+func TestFacets(t *testing.T) {
+	idx := testIndex(t)
+	fruit := NewSingleExpression(NewFilter("tags", OpEqual, "fruit"))
+	results, err := idx.Facets(fruit, []uint64{3},
+		FacetRequest{IndexName: "tags"},
+		FacetRequest{IndexName: "shop.city", TopN: 1},
+		FacetRequest{IndexName: "price", Stats: true, HistogramInterval: 10},
+		FacetRequest{IndexName: "missing"},
+	)
+	assert.Nil(t, err)
+	assert.Len(t, results, 4)
+
+	// most frequent first, ties in value order
+	assert.Equal(t, []FacetCount{{"fruit", 2}, {"bread", 1}, {"sweet", 1}}, results[0].Counts)
+	assert.Len(t, results[1].Counts, 1)
+	assert.Equal(t, &NumericStats{Count: 2, Min: 10, Max: 20.5, Sum: 30.5, Avg: 15.25}, results[2].Stats)
+	assert.Equal(t, []HistogramBucket{{From: 10, Count: 1}, {From: 20, Count: 1}}, results[2].Histogram)
+	assert.Empty(t, results[3].Counts)
+
+	// without an expression every node is counted
+	results, err = idx.Facets(nil, nil, FacetRequest{IndexName: "shop.city"})
+	assert.Nil(t, err)
+	assert.Equal(t, []FacetCount{{"seoul", 2}, {"busan", 1}}, results[0].Counts)
+
+	_, err = idx.Facets(nil, nil, FacetRequest{IndexName: "price", HistogramInterval: -1})
+	assert.NotNil(t, err)
+}
@@ -49,6 +49,18 @@ func (api *MinioAPI) GetObject(bucketName, objectName string) ([]byte, error) {
 	return byteData, nil
 }
 
+// ObjectExists reports whether the object is stored in the bucket
+func (api *MinioAPI) ObjectExists(bucketName, objectName string) (bool, error) {
+	_, err := api.session.StatObject(context.Background(), bucketName, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 func (api *MinioAPI) removeObjectOldVersion(bucketName, objectName, oldVersion string) error {
 	return api.session.RemoveObject(context.Background(), bucketName, objectName, minio.RemoveObjectOptions{
 		VersionID: oldVersion,
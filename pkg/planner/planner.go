@@ -0,0 +1,103 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package planner picks how a filtered vector search visits the rows, from
+// the cardinality of the filter bitmap against the size of the collection.
+package planner
+
+import (
+	"fmt"
+	"math"
+)
+
+type Strategy int
+
+const (
+	// Exact scores every row matching the filter.
+	Exact Strategy = iota
+	// FilteredANN traverses the ANN graph, keeping only the matching rows in the result.
+	FilteredANN
+	// PostFilter searches without the filter, fetching more candidates than asked,
+	// and drops the rows not matching it. Without an ANN graph it is a full scan
+	// testing each row against the filter bitmap.
+	PostFilter
+)
+
+func (s Strategy) String() string {
+	switch s {
+	case Exact:
+		return "exact"
+	case FilteredANN:
+		return "filtered_ann"
+	case PostFilter:
+		return "post_filter"
+	}
+	return fmt.Sprintf("Strategy(%d)", int(s))
+}
+
+const (
+	// ExactMaxRows is the largest filtered set scored exactly, about the
+	// distance computations of a graph search.
+	ExactMaxRows = 4096
+	// PostFilterSelectivity is the smallest share of matching rows left to a post-filter.
+	PostFilterSelectivity = 0.5
+	// overFetch covers the uneven spread of the matching rows among the nearest ones.
+	overFetch = 1.5
+)
+
+// Plan is the strategy chosen for a filter and the figures it was chosen by.
+type Plan struct {
+	Strategy    Strategy
+	Filtered    uint64 // rows matching the filter
+	Total       uint64 // rows of the collection
+	Selectivity float64
+}
+
+// Choose plans a search whose filter matches filtered of total rows.
+// Without an ANN graph the choice is between Exact and the full scan of PostFilter.
+func Choose(filtered, total uint64, ann bool) Plan {
+	plan := Plan{Filtered: filtered, Total: total}
+	if total > 0 {
+		plan.Selectivity = math.Min(1, float64(filtered)/float64(total))
+	}
+	switch {
+	case plan.Selectivity >= PostFilterSelectivity:
+		plan.Strategy = PostFilter
+	case !ann || filtered <= ExactMaxRows:
+		plan.Strategy = Exact
+	default:
+		plan.Strategy = FilteredANN
+	}
+	return plan
+}
+
+// Fetch returns the candidates a PostFilter search asks the ANN graph for
+// to keep k rows after filtering, k for the other strategies.
+func (p Plan) Fetch(k int) int {
+	if p.Strategy != PostFilter || p.Selectivity == 0 {
+		return k
+	}
+	fetch := int(math.Ceil(float64(k) / p.Selectivity * overFetch))
+	if p.Total > 0 && uint64(fetch) > p.Total {
+		fetch = int(p.Total)
+	}
+	return max(fetch, k)
+}
+
+func (p Plan) String() string {
+	return fmt.Sprintf("%s: %d of %d rows match the filter (selectivity %.4f)", p.Strategy, p.Filtered, p.Total, p.Selectivity)
+}
@@ -0,0 +1,43 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package planner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChoose(t *testing.T) {
+	assert.Equal(t, Exact, Choose(100, 1000000, true).Strategy)
+	assert.Equal(t, FilteredANN, Choose(50000, 1000000, true).Strategy)
+	assert.Equal(t, PostFilter, Choose(800000, 1000000, true).Strategy)
+
+	// without a graph the mid range is scored exactly
+	assert.Equal(t, Exact, Choose(50000, 1000000, false).Strategy)
+	assert.Equal(t, PostFilter, Choose(800000, 1000000, false).Strategy)
+
+	assert.Equal(t, Exact, Choose(0, 0, true).Strategy)
+}
+
+func TestFetch(t *testing.T) {
+	plan := Choose(500, 1000, true)
+	assert.Equal(t, 30, plan.Fetch(10))
+	assert.Equal(t, 1000, plan.Fetch(900))
+	assert.Equal(t, 10, Choose(10, 1000, true).Fetch(10))
+}
@@ -0,0 +1,106 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package wal
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/sjy-dv/coltt/pkg/flate"
+)
+
+// Compression is the codec of the chunk data, it is kept in the upper bits
+// of the chunk type so segments written with different options stay readable.
+type Compression = byte
+
+const (
+	NoCompression Compression = iota
+	FlateCompression
+)
+
+const (
+	chunkTypeMask         = 0x0f
+	chunkCompressionShift = 4
+)
+
+var flateWriterPool = sync.Pool{
+	New: func() interface{} {
+		w, err := flate.NewWriter(nil, flate.BestSpeed, nil)
+		if err != nil {
+			panic(err)
+		}
+		return w
+	},
+}
+
+var flateReaderPool = sync.Pool{
+	New: func() interface{} {
+		return flate.NewReader(bytes.NewReader(nil), nil)
+	},
+}
+
+func validCompression(compression Compression) error {
+	switch compression {
+	case NoCompression, FlateCompression:
+		return nil
+	}
+	return fmt.Errorf("unsupported compression %d", compression)
+}
+
+// compress returns the data to write and the codec it was written with,
+// data that does not shrink is written uncompressed.
+func compress(compression Compression, data []byte) ([]byte, Compression, error) {
+	if compression == NoCompression || len(data) == 0 {
+		return data, NoCompression, nil
+	}
+	var buf bytes.Buffer
+	w := flateWriterPool.Get().(*flate.Writer)
+	defer flateWriterPool.Put(w)
+	w.Reset(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, 0, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, 0, err
+	}
+	if buf.Len() >= len(data) {
+		return data, NoCompression, nil
+	}
+	return buf.Bytes(), compression, nil
+}
+
+func decompress(compression Compression, data []byte) ([]byte, error) {
+	switch compression {
+	case NoCompression:
+		return data, nil
+	case FlateCompression:
+		r := flateReaderPool.Get().(io.ReadCloser)
+		defer flateReaderPool.Put(r)
+		if err := r.(flate.Resetter).Reset(bytes.NewReader(data), nil); err != nil {
+			return nil, err
+		}
+		out, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return out, r.Close()
+	}
+	return nil, fmt.Errorf("unsupported compression %d", compression)
+}
@@ -0,0 +1,184 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package wal
+
+import (
+	"sync"
+	"time"
+)
+
+// Durability is how far a write is persisted before it returns.
+type Durability = byte
+
+const (
+	// DurabilityDefault follows Options.Sync and Options.BytesPerSync.
+	DurabilityDefault Durability = iota
+	// DurabilityNone returns once the data is written to the os buffer,
+	// and the write never triggers a sync.
+	DurabilityNone
+	// DurabilityOSBuffer returns once the data is written to the os buffer,
+	// the segment is synced every Options.BytesPerSync bytes.
+	DurabilityOSBuffer
+	// DurabilityFsync returns once the data is synced to disk, concurrent
+	// writes waiting for a sync share one fsync.
+	DurabilityFsync
+)
+
+// SyncStats reports the fsyncs of the WAL, for tuning Sync and BytesPerSync.
+type SyncStats struct {
+	// Syncs is the number of fsyncs.
+	Syncs uint64
+	// SyncedWrites is the number of writes made durable by the fsyncs.
+	SyncedWrites uint64
+	// MaxBatch is the most writes made durable by one fsync.
+	MaxBatch uint64
+	// TotalLatency, MaxLatency and LastLatency are the fsync latencies.
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+	LastLatency  time.Duration
+}
+
+// AvgBatch is the average number of writes made durable by one fsync.
+func (s SyncStats) AvgBatch() float64 {
+	if s.Syncs == 0 {
+		return 0
+	}
+	return float64(s.SyncedWrites) / float64(s.Syncs)
+}
+
+// AvgLatency is the average fsync latency.
+func (s SyncStats) AvgLatency() time.Duration {
+	if s.Syncs == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Syncs)
+}
+
+// groupCommit lets one waiting writer fsync for every write appended so far,
+// the others wait for it instead of issuing their own fsync.
+type groupCommit struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	syncing bool
+	synced  uint64 // writes up to this sequence are on disk
+	stats   SyncStats
+}
+
+func newGroupCommit() *groupCommit {
+	gc := &groupCommit{}
+	gc.cond = sync.NewCond(&gc.mu)
+	return gc
+}
+
+// done records an fsync that made the writes up to seq durable, gc.mu must be held.
+func (gc *groupCommit) done(seq uint64, latency time.Duration) {
+	if seq <= gc.synced {
+		return
+	}
+	batch := seq - gc.synced
+	gc.synced = seq
+	gc.stats.Syncs++
+	gc.stats.SyncedWrites += batch
+	if batch > gc.stats.MaxBatch {
+		gc.stats.MaxBatch = batch
+	}
+	gc.stats.TotalLatency += latency
+	gc.stats.LastLatency = latency
+	if latency > gc.stats.MaxLatency {
+		gc.stats.MaxLatency = latency
+	}
+}
+
+// syncTo returns once the writes up to seq are synced, wal.mu must not be held.
+func (wal *WAL) syncTo(seq uint64) error {
+	gc := wal.group
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	for gc.synced < seq {
+		if gc.syncing {
+			gc.cond.Wait()
+			continue
+		}
+		gc.syncing = true
+		gc.mu.Unlock()
+
+		// the older segments were synced when they were rotated,
+		// so syncing the active one covers every write so far.
+		// Writes go on during the fsync, and wait for the next one.
+		wal.syncLock.RLock()
+		wal.mu.RLock()
+		seg, target := wal.activeSegment, wal.writeSeq
+		wal.mu.RUnlock()
+		start := time.Now()
+		err := seg.Sync()
+		latency := time.Since(start)
+		wal.syncLock.RUnlock()
+
+		gc.mu.Lock()
+		gc.syncing = false
+		if err == nil {
+			gc.done(target, latency)
+		}
+		gc.cond.Broadcast()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncActive syncs the active segment for every write so far, wal.mu must be held.
+func (wal *WAL) syncActive() error {
+	start := time.Now()
+	if err := wal.activeSegment.Sync(); err != nil {
+		return err
+	}
+	latency := time.Since(start)
+	wal.group.mu.Lock()
+	wal.group.done(wal.writeSeq, latency)
+	wal.group.mu.Unlock()
+	return nil
+}
+
+// needSync reports whether a write of size bytes has to wait for a sync, wal.mu must be held.
+func (wal *WAL) needSync(durability Durability, size uint32) bool {
+	if durability == DurabilityDefault {
+		durability = DurabilityOSBuffer
+		if wal.options.Sync {
+			durability = DurabilityFsync
+		}
+	}
+	switch durability {
+	case DurabilityFsync:
+		return true
+	case DurabilityOSBuffer:
+		wal.bytesWrite += size
+		if wal.options.BytesPerSync > 0 && wal.bytesWrite >= wal.options.BytesPerSync {
+			wal.bytesWrite = 0
+			return true
+		}
+	}
+	return false
+}
+
+// SyncStats returns the fsync statistics since the WAL was opened.
+func (wal *WAL) SyncStats() SyncStats {
+	wal.group.mu.Lock()
+	defer wal.group.mu.Unlock()
+	return wal.group.stats
+}
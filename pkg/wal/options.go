@@ -29,6 +29,10 @@ type Options struct {
 	Sync bool
 
 	BytesPerSync uint32
+
+	// Compression compresses the data of every write, segments written
+	// with another compression can still be read.
+	Compression Compression
 }
 
 const (
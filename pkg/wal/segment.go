@@ -40,8 +40,10 @@ const (
 )
 
 var (
-	ErrClosed     = errors.New("the segment file is closed")
-	ErrInvalidCRC = errors.New("invalid crc, the data may be corrupted")
+	ErrClosed       = errors.New("the segment file is closed")
+	ErrInvalidCRC   = errors.New("invalid crc, the data may be corrupted")
+	ErrTornChunk    = errors.New("the chunk is incomplete, the segment may end with a partial write")
+	ErrInvalidChunk = errors.New("invalid chunk type, the data may be corrupted")
 )
 
 const (
@@ -70,6 +72,7 @@ type segment struct {
 	currentBlockSize   uint32
 	closed             bool
 	header             []byte
+	compression        Compression
 	startupBlock       *startupBlock
 	isStartupTraversal bool
 }
@@ -117,8 +120,8 @@ func putBuffer(buf []byte) {
 	blockPool.Put(buf)
 }
 
-// openSegmentFile a new segment file.
-func openSegmentFile(dirPath, extName string, id uint32) (*segment, error) {
+// openSegmentFile a new segment file, the writes to it are compressed with compression.
+func openSegmentFile(dirPath, extName string, id uint32, compression Compression) (*segment, error) {
 	fd, err := os.OpenFile(
 		SegmentFileName(dirPath, extName, id),
 		os.O_CREATE|os.O_RDWR|os.O_APPEND,
@@ -139,6 +142,7 @@ func openSegmentFile(dirPath, extName string, id uint32) (*segment, error) {
 		id:                 id,
 		fd:                 fd,
 		header:             make([]byte, chunkHeaderSize),
+		compression:        compression,
 		currentBlockNumber: uint32(offset / blockSize),
 		currentBlockSize:   uint32(offset % blockSize),
 		startupBlock: &startupBlock{
@@ -210,6 +214,11 @@ func (seg *segment) writeToBuffer(data []byte, chunkBuffer *bytebufferpool.ByteB
 		return nil, ErrClosed
 	}
 
+	data, compression, err := compress(seg.compression, data)
+	if err != nil {
+		return nil, err
+	}
+
 	// if the left block size can not hold the chunk header, padding the block
 	if seg.currentBlockSize+chunkHeaderSize >= blockSize {
 		// padding if necessary
@@ -234,7 +243,7 @@ func (seg *segment) writeToBuffer(data []byte, chunkBuffer *bytebufferpool.ByteB
 	dataSize := uint32(len(data))
 	// The entire chunk can fit into the block.
 	if seg.currentBlockSize+dataSize+chunkHeaderSize <= blockSize {
-		seg.appendChunkBuffer(chunkBuffer, data, ChunkTypeFull)
+		seg.appendChunkBuffer(chunkBuffer, data, ChunkTypeFull, compression)
 		position.ChunkSize = dataSize + chunkHeaderSize
 	} else {
 		// If the size of the data exceeds the size of the block,
@@ -266,7 +275,7 @@ func (seg *segment) writeToBuffer(data []byte, chunkBuffer *bytebufferpool.ByteB
 			default: // Middle chunk
 				chunkType = ChunkTypeMiddle
 			}
-			seg.appendChunkBuffer(chunkBuffer, data[dataSize-leftSize:end], chunkType)
+			seg.appendChunkBuffer(chunkBuffer, data[dataSize-leftSize:end], chunkType, compression)
 
 			leftSize -= chunkSize
 			blockCount += 1
@@ -363,11 +372,11 @@ func (seg *segment) Write(data []byte) (pos *ChunkPosition, err error) {
 	return
 }
 
-func (seg *segment) appendChunkBuffer(buf *bytebufferpool.ByteBuffer, data []byte, chunkType ChunkType) {
+func (seg *segment) appendChunkBuffer(buf *bytebufferpool.ByteBuffer, data []byte, chunkType ChunkType, compression Compression) {
 	// Length	2 Bytes	index:4-5
 	binary.LittleEndian.PutUint16(seg.header[4:6], uint16(len(data)))
-	// Type	1 Byte	index:6
-	seg.header[6] = chunkType
+	// Type	1 Byte	index:6, the upper bits hold the compression
+	seg.header[6] = chunkType | compression<<chunkCompressionShift
 	// Checksum	4 Bytes index:0-3
 	sum := crc32.ChecksumIEEE(seg.header[4:])
 	sum = crc32.Update(sum, crc32.IEEETable, data)
@@ -406,10 +415,12 @@ func (seg *segment) readInternal(blockNumber uint32, chunkOffset int64) ([]byte,
 	}
 
 	var (
-		result    []byte
-		block     []byte
-		segSize   = seg.Size()
-		nextChunk = &ChunkPosition{SegmentId: seg.id}
+		result      []byte
+		block       []byte
+		compression Compression
+		continued   bool // the first chunk must be Full or First, the others Middle or Last
+		segSize     = seg.Size()
+		nextChunk   = &ChunkPosition{SegmentId: seg.id}
 	)
 
 	if seg.isStartupTraversal {
@@ -430,8 +441,14 @@ func (seg *segment) readInternal(blockNumber uint32, chunkOffset int64) ([]byte,
 		}
 
 		if chunkOffset >= size {
+			if continued {
+				return nil, nil, ErrTornChunk
+			}
 			return nil, nil, io.EOF
 		}
+		if chunkOffset+chunkHeaderSize > size {
+			return nil, nil, ErrTornChunk
+		}
 
 		if seg.isStartupTraversal {
 			// There are two cases that we should read block from file:
@@ -461,6 +478,9 @@ func (seg *segment) readInternal(blockNumber uint32, chunkOffset int64) ([]byte,
 
 		// copy data
 		start := chunkOffset + chunkHeaderSize
+		if start+int64(length) > size {
+			return nil, nil, ErrTornChunk
+		}
 		result = append(result, block[start:start+int64(length)]...)
 
 		// check sum
@@ -472,7 +492,12 @@ func (seg *segment) readInternal(blockNumber uint32, chunkOffset int64) ([]byte,
 		}
 
 		// type
-		chunkType := header[6]
+		chunkType := header[6] & chunkTypeMask
+		compression = header[6] >> chunkCompressionShift
+		if continued != (chunkType == ChunkTypeMiddle || chunkType == ChunkTypeLast) ||
+			chunkType > ChunkTypeLast {
+			return nil, nil, ErrInvalidChunk
+		}
 
 		if chunkType == ChunkTypeFull || chunkType == ChunkTypeLast {
 			nextChunk.BlockNumber = blockNumber
@@ -487,6 +512,11 @@ func (seg *segment) readInternal(blockNumber uint32, chunkOffset int64) ([]byte,
 		}
 		blockNumber += 1
 		chunkOffset = 0
+		continued = true
+	}
+	result, err := decompress(compression, result)
+	if err != nil {
+		return nil, nil, err
 	}
 	return result, nextChunk, nil
 }
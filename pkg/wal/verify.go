@@ -0,0 +1,175 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package wal
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// quarantined segment files are renamed with this prefix, Open no longer
+// takes them for segment files.
+const quarantinePrefix = "CORRUPT-"
+
+// Corruption is a damaged region of a segment file, Position is the first
+// chunk that can not be read.
+type Corruption struct {
+	Position ChunkPosition
+	Err      error
+}
+
+// SegmentReport is the result of scanning one segment file.
+type SegmentReport struct {
+	Id   SegmentID
+	Path string
+	Size int64
+	// Records is the number of readable records, including the ones after a damaged region.
+	Records int
+	// ValidSize is the size up to the end of the last readable chunk before the first corruption.
+	ValidSize   int64
+	Corruptions []Corruption
+	Truncated   bool
+	Quarantined bool
+}
+
+// VerifyReport is the result of Verify or Repair, the segments are sorted by id.
+type VerifyReport struct {
+	Segments []*SegmentReport
+}
+
+// Corrupted reports whether any segment file has a damaged region.
+func (r *VerifyReport) Corrupted() bool {
+	for _, seg := range r.Segments {
+		if len(seg.Corruptions) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify reads every chunk of the segment files and checks the checksums.
+// After a damaged region the scan goes on from the next block that starts
+// with a readable chunk, so every damaged region is reported.
+// The WAL must not be opened while it runs.
+func Verify(options Options) (*VerifyReport, error) {
+	segmentIDs, err := segmentFileIDs(options.DirPath, options.SegmentFileExt)
+	if err != nil {
+		return nil, err
+	}
+	report := &VerifyReport{}
+	for _, id := range segmentIDs {
+		seg, err := openSegmentFile(options.DirPath, options.SegmentFileExt, uint32(id), NoCompression)
+		if err != nil {
+			return nil, err
+		}
+		report.Segments = append(report.Segments, scanSegment(seg))
+		if err := seg.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// Repair verifies the segment files and repairs the damaged ones.
+// The active segment is truncated at the end of its last readable chunk
+// before the first corruption, so a torn tail from a crash is dropped.
+// An older segment is quarantined, renamed with the CORRUPT- prefix, and
+// the records in it are no longer read.
+// The WAL must not be opened while it runs.
+func Repair(options Options) (*VerifyReport, error) {
+	report, err := Verify(options)
+	if err != nil {
+		return nil, err
+	}
+	for i, seg := range report.Segments {
+		if len(seg.Corruptions) == 0 {
+			continue
+		}
+		if i == len(report.Segments)-1 {
+			if err := os.Truncate(seg.Path, seg.ValidSize); err != nil {
+				return report, err
+			}
+			seg.Truncated = true
+			continue
+		}
+		if err := Quarantine(seg.Path); err != nil {
+			return report, err
+		}
+		seg.Quarantined = true
+	}
+	return report, nil
+}
+
+// Quarantine renames the file with the CORRUPT- prefix, Open skips it.
+func Quarantine(path string) error {
+	return os.Rename(path, filepath.Join(filepath.Dir(path), quarantinePrefix+filepath.Base(path)))
+}
+
+func scanSegment(seg *segment) *SegmentReport {
+	report := &SegmentReport{
+		Id:   seg.id,
+		Path: seg.fd.Name(),
+		Size: seg.Size(),
+	}
+	var (
+		blockNumber uint32
+		chunkOffset int64
+	)
+	for {
+		_, next, err := seg.readInternal(blockNumber, chunkOffset)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			report.Corruptions = append(report.Corruptions, Corruption{
+				Position: ChunkPosition{
+					SegmentId:   seg.id,
+					BlockNumber: blockNumber,
+					ChunkOffset: chunkOffset,
+				},
+				Err: err,
+			})
+			var ok bool
+			if blockNumber, ok = resyncSegment(seg, blockNumber+1); !ok {
+				break
+			}
+			chunkOffset = 0
+			continue
+		}
+		report.Records++
+		if len(report.Corruptions) == 0 {
+			report.ValidSize = int64(next.BlockNumber)*blockSize + next.ChunkOffset
+			if report.ValidSize > report.Size {
+				report.ValidSize = report.Size
+			}
+		}
+		blockNumber, chunkOffset = next.BlockNumber, next.ChunkOffset
+	}
+	return report
+}
+
+// resyncSegment finds the first block from blockNumber on whose first chunk is readable.
+func resyncSegment(seg *segment, blockNumber uint32) (uint32, bool) {
+	for ; int64(blockNumber)*blockSize < seg.Size(); blockNumber++ {
+		if _, _, err := seg.readInternal(blockNumber, 0); err == nil {
+			return blockNumber, true
+		}
+	}
+	return 0, false
+}
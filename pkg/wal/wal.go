@@ -57,6 +57,9 @@ type WAL struct {
 	pendingWrites     [][]byte
 	pendingSize       int64
 	pendingWritesLock sync.Mutex
+	writeSeq          uint64 // number of chunks written, the sequence of the last write
+	group             *groupCommit
+	syncLock          sync.RWMutex // held by a group sync, Close and Delete wait for it
 }
 
 // Reader represents a reader for the WAL.
@@ -77,10 +80,14 @@ func Open(options Options) (*WAL, error) {
 	if !strings.HasPrefix(options.SegmentFileExt, ".") {
 		return nil, fmt.Errorf("segment file extension must start with '.'")
 	}
+	if err := validCompression(options.Compression); err != nil {
+		return nil, err
+	}
 	wal := &WAL{
 		options:       options,
 		olderSegments: make(map[SegmentID]*segment),
 		pendingWrites: make([][]byte, 0),
+		group:         newGroupCommit(),
 	}
 
 	// create the directory if not exists.
@@ -88,41 +95,25 @@ func Open(options Options) (*WAL, error) {
 		return nil, err
 	}
 
-	// iterate the dir and open all segment files.
-	entries, err := os.ReadDir(options.DirPath)
+	// iterate the dir and get all segment file ids.
+	segmentIDs, err := segmentFileIDs(options.DirPath, options.SegmentFileExt)
 	if err != nil {
 		return nil, err
 	}
 
-	// get all segment file ids.
-	var segmentIDs []int
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-		var id int
-		_, err := fmt.Sscanf(entry.Name(), "%d"+options.SegmentFileExt, &id)
-		if err != nil {
-			continue
-		}
-		segmentIDs = append(segmentIDs, id)
-	}
-
 	// empty directory, just initialize a new segment file.
 	if len(segmentIDs) == 0 {
 		segment, err := openSegmentFile(options.DirPath, options.SegmentFileExt,
-			initialSegmentFileID)
+			initialSegmentFileID, options.Compression)
 		if err != nil {
 			return nil, err
 		}
 		wal.activeSegment = segment
 	} else {
 		// open the segment files in order, get the max one as the active segment file.
-		sort.Ints(segmentIDs)
-
 		for i, segId := range segmentIDs {
 			segment, err := openSegmentFile(options.DirPath, options.SegmentFileExt,
-				uint32(segId))
+				uint32(segId), options.Compression)
 			if err != nil {
 				return nil, err
 			}
@@ -137,6 +128,28 @@ func Open(options Options) (*WAL, error) {
 	return wal, nil
 }
 
+// segmentFileIDs returns the sorted ids of the segment files in the directory.
+func segmentFileIDs(dirPath, extName string) ([]int, error) {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	var segmentIDs []int
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		var id int
+		_, err := fmt.Sscanf(entry.Name(), "%d"+extName, &id)
+		if err != nil {
+			continue
+		}
+		segmentIDs = append(segmentIDs, id)
+	}
+	sort.Ints(segmentIDs)
+	return segmentIDs, nil
+}
+
 // SegmentFileName returns the file name of a segment file.
 func SegmentFileName(dirPath string, extName string, id SegmentID) string {
 	return filepath.Join(dirPath, fmt.Sprintf("%09d"+extName, id))
@@ -146,12 +159,12 @@ func (wal *WAL) OpenNewActiveSegment() error {
 	wal.mu.Lock()
 	defer wal.mu.Unlock()
 	// sync the active segment file.
-	if err := wal.activeSegment.Sync(); err != nil {
+	if err := wal.syncActive(); err != nil {
 		return err
 	}
 	// create a new segment file and set it as the active one.
 	segment, err := openSegmentFile(wal.options.DirPath, wal.options.SegmentFileExt,
-		wal.activeSegment.id+1)
+		wal.activeSegment.id+1, wal.options.Compression)
 	if err != nil {
 		return err
 	}
@@ -168,6 +181,18 @@ func (wal *WAL) ActiveSegmentID() SegmentID {
 	return wal.activeSegment.id
 }
 
+// EndPosition returns the position the next chunk will be written at.
+func (wal *WAL) EndPosition() *ChunkPosition {
+	wal.mu.RLock()
+	defer wal.mu.RUnlock()
+
+	return &ChunkPosition{
+		SegmentId:   wal.activeSegment.id,
+		BlockNumber: wal.activeSegment.currentBlockNumber,
+		ChunkOffset: int64(wal.activeSegment.currentBlockSize),
+	}
+}
+
 // IsEmpty returns whether the WAL is empty.
 // Only there is only one empty active segment file, which means the WAL is empty.
 func (wal *WAL) IsEmpty() bool {
@@ -317,12 +342,12 @@ func (wal *WAL) PendingWrites(data []byte) {
 
 // rotateActiveSegment create a new segment file and replace the activeSegment.
 func (wal *WAL) rotateActiveSegment() error {
-	if err := wal.activeSegment.Sync(); err != nil {
+	if err := wal.syncActive(); err != nil {
 		return err
 	}
 	wal.bytesWrite = 0
 	segment, err := openSegmentFile(wal.options.DirPath, wal.options.SegmentFileExt,
-		wal.activeSegment.id+1)
+		wal.activeSegment.id+1, wal.options.Compression)
 	if err != nil {
 		return err
 	}
@@ -334,8 +359,41 @@ func (wal *WAL) rotateActiveSegment() error {
 // WriteAll write wal.pendingWrites to WAL and then clear pendingWrites,
 // it will not sync the segment file based on wal.options, you should call Sync() manually.
 func (wal *WAL) WriteAll() ([]*ChunkPosition, error) {
+	return wal.WriteAllWithDurability(DurabilityNone)
+}
+
+// WriteAllWithDurability write wal.pendingWrites to WAL and then clear pendingWrites,
+// and persists them as far as durability asks before it returns.
+func (wal *WAL) WriteAllWithDurability(durability Durability) ([]*ChunkPosition, error) {
+	positions, wait, err := wal.WriteAllDeferSync(durability)
+	if err != nil {
+		return nil, err
+	}
+	if err := wait(); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+// WriteAllDeferSync is WriteAllWithDurability without waiting for the sync,
+// the returned wait does. A caller holding its own lock around the write can
+// release it before calling wait, so concurrent writers share one fsync.
+func (wal *WAL) WriteAllDeferSync(durability Durability) ([]*ChunkPosition, func() error, error) {
+	positions, seq, needSync, err := wal.writeAll(durability)
+	if err != nil {
+		return nil, nil, err
+	}
+	return positions, func() error {
+		if !needSync {
+			return nil
+		}
+		return wal.syncTo(seq)
+	}, nil
+}
+
+func (wal *WAL) writeAll(durability Durability) ([]*ChunkPosition, uint64, bool, error) {
 	if len(wal.pendingWrites) == 0 {
-		return make([]*ChunkPosition, 0), nil
+		return make([]*ChunkPosition, 0), 0, false, nil
 	}
 
 	wal.mu.Lock()
@@ -346,63 +404,74 @@ func (wal *WAL) WriteAll() ([]*ChunkPosition, error) {
 
 	// if the pending size is still larger than segment size, return error
 	if wal.pendingSize > wal.options.SegmentSize {
-		return nil, ErrPendingSizeTooLarge
+		return nil, 0, false, ErrPendingSizeTooLarge
 	}
 
 	// if the active segment file is full, sync it and create a new one.
 	if wal.activeSegment.Size()+wal.pendingSize > wal.options.SegmentSize {
 		if err := wal.rotateActiveSegment(); err != nil {
-			return nil, err
+			return nil, 0, false, err
 		}
 	}
 
 	// write all data to the active segment file.
 	positions, err := wal.activeSegment.writeAll(wal.pendingWrites)
 	if err != nil {
-		return nil, err
+		return nil, 0, false, err
 	}
 
-	return positions, nil
+	var size uint32
+	for _, position := range positions {
+		size += position.ChunkSize
+	}
+	wal.writeSeq += uint64(len(positions))
+	return positions, wal.writeSeq, wal.needSync(durability, size), nil
 }
 
 // Write writes the data to the WAL.
 // Actually, it writes the data to the active segment file.
 // It returns the position of the data in the WAL, and an error if any.
 func (wal *WAL) Write(data []byte) (*ChunkPosition, error) {
+	return wal.WriteWithDurability(data, DurabilityDefault)
+}
+
+// WriteWithDurability writes the data to the WAL, and persists it as far as
+// durability asks before it returns.
+func (wal *WAL) WriteWithDurability(data []byte, durability Durability) (*ChunkPosition, error) {
+	position, seq, needSync, err := wal.write(data, durability)
+	if err != nil {
+		return nil, err
+	}
+	// wait for the sync without the lock, so concurrent writes share the fsync.
+	if needSync {
+		if err := wal.syncTo(seq); err != nil {
+			return nil, err
+		}
+	}
+	return position, nil
+}
+
+func (wal *WAL) write(data []byte, durability Durability) (*ChunkPosition, uint64, bool, error) {
 	wal.mu.Lock()
 	defer wal.mu.Unlock()
 	if int64(len(data))+chunkHeaderSize > wal.options.SegmentSize {
-		return nil, ErrValueTooLarge
+		return nil, 0, false, ErrValueTooLarge
 	}
 	// if the active segment file is full, sync it and create a new one.
 	if wal.isFull(int64(len(data))) {
 		if err := wal.rotateActiveSegment(); err != nil {
-			return nil, err
+			return nil, 0, false, err
 		}
 	}
 
 	// write the data to the active segment file.
 	position, err := wal.activeSegment.Write(data)
 	if err != nil {
-		return nil, err
+		return nil, 0, false, err
 	}
 
-	// update the bytesWrite field.
-	wal.bytesWrite += position.ChunkSize
-
-	// sync the active segment file if needed.
-	var needSync = wal.options.Sync
-	if !needSync && wal.options.BytesPerSync > 0 {
-		needSync = wal.bytesWrite >= wal.options.BytesPerSync
-	}
-	if needSync {
-		if err := wal.activeSegment.Sync(); err != nil {
-			return nil, err
-		}
-		wal.bytesWrite = 0
-	}
-
-	return position, nil
+	wal.writeSeq++
+	return position, wal.writeSeq, wal.needSync(durability, position.ChunkSize), nil
 }
 
 // Read reads the data from the WAL according to the given position.
@@ -428,6 +497,8 @@ func (wal *WAL) Read(pos *ChunkPosition) ([]byte, error) {
 
 // Close closes the WAL.
 func (wal *WAL) Close() error {
+	wal.syncLock.Lock()
+	defer wal.syncLock.Unlock()
 	wal.mu.Lock()
 	defer wal.mu.Unlock()
 
@@ -441,12 +512,17 @@ func (wal *WAL) Close() error {
 	wal.olderSegments = nil
 
 	wal.renameIds = append(wal.renameIds, wal.activeSegment.id)
-	// close the active segment file.
+	// sync the writes still waiting for a group sync, then close the active segment file.
+	if err := wal.syncActive(); err != nil {
+		return err
+	}
 	return wal.activeSegment.Close()
 }
 
 // Delete deletes all segment files of the WAL.
 func (wal *WAL) Delete() error {
+	wal.syncLock.Lock()
+	defer wal.syncLock.Unlock()
 	wal.mu.Lock()
 	defer wal.mu.Unlock()
 
@@ -462,12 +538,13 @@ func (wal *WAL) Delete() error {
 	return wal.activeSegment.Remove()
 }
 
-// Sync syncs the active segment file to stable storage like disk.
+// Sync syncs the writes so far to stable storage like disk,
+// concurrent callers share one fsync.
 func (wal *WAL) Sync() error {
-	wal.mu.Lock()
-	defer wal.mu.Unlock()
-
-	return wal.activeSegment.Sync()
+	wal.mu.RLock()
+	seq := wal.writeSeq
+	wal.mu.RUnlock()
+	return wal.syncTo(seq)
 }
 
 // RenameFileExt renames all segment files' extension name.
@@ -0,0 +1,140 @@
+// Licensed to sjy-dv under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. sjy-dv licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package wal
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func openTestWAL(t *testing.T, dirPath string, compression Compression) *WAL {
+	options := DefaultOptions
+	options.DirPath = dirPath
+	options.SegmentSize = 32 * KB
+	options.Compression = compression
+	wal, err := Open(options)
+	assert.Nil(t, err)
+	return wal
+}
+
+func testRecord(i int) []byte {
+	return bytes.Repeat([]byte(fmt.Sprintf("record-%d ", i)), 50)
+}
+
+func TestReadCompressedSegments(t *testing.T) {
+	dirPath := t.TempDir()
+
+	// segments written before the compression was enabled
+	wal := openTestWAL(t, dirPath, NoCompression)
+	var positions []*ChunkPosition
+	for i := 0; i < 100; i++ {
+		pos, err := wal.Write(testRecord(i))
+		assert.Nil(t, err)
+		positions = append(positions, pos)
+	}
+	assert.Nil(t, wal.Close())
+	plainSegments, err := segmentFileIDs(dirPath, DefaultOptions.SegmentFileExt)
+	assert.Nil(t, err)
+
+	wal = openTestWAL(t, dirPath, FlateCompression)
+	assert.Nil(t, wal.OpenNewActiveSegment())
+	compressedStart := wal.ActiveSegmentID()
+	for i := 100; i < 200; i++ {
+		pos, err := wal.Write(testRecord(i))
+		assert.Nil(t, err)
+		positions = append(positions, pos)
+	}
+	assert.Nil(t, wal.Close())
+	// the compressed records take less room than the plain ones
+	assert.True(t, positions[199].SegmentId-compressedStart < SegmentID(len(plainSegments)))
+
+	// both are readable whatever the compression of the reader
+	for _, compression := range []Compression{FlateCompression, NoCompression} {
+		wal = openTestWAL(t, dirPath, compression)
+		for i, pos := range positions {
+			data, err := wal.Read(pos)
+			assert.Nil(t, err)
+			assert.Equal(t, testRecord(i), data)
+		}
+		reader := wal.NewReader()
+		for i := 0; ; i++ {
+			data, _, err := reader.Next()
+			if err != nil {
+				assert.Equal(t, len(positions), i)
+				break
+			}
+			assert.Equal(t, testRecord(i), data)
+		}
+		assert.Nil(t, wal.Close())
+	}
+}
+
+func TestRepairTruncatesTornTail(t *testing.T) {
+	dirPath := t.TempDir()
+	wal := openTestWAL(t, dirPath, NoCompression)
+	var last *ChunkPosition
+	for i := 0; i < 10; i++ {
+		pos, err := wal.Write(testRecord(i))
+		assert.Nil(t, err)
+		last = pos
+	}
+	assert.Nil(t, wal.Close())
+
+	// a crash in the middle of the last write
+	path := SegmentFileName(dirPath, DefaultOptions.SegmentFileExt, last.SegmentId)
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Nil(t, os.Truncate(path, info.Size()-int64(last.ChunkSize)/2))
+
+	options := DefaultOptions
+	options.DirPath = dirPath
+	report, err := Verify(options)
+	assert.Nil(t, err)
+	assert.True(t, report.Corrupted())
+
+	report, err = Repair(options)
+	assert.Nil(t, err)
+	tail := report.Segments[len(report.Segments)-1]
+	assert.True(t, tail.Truncated)
+	assert.Equal(t, 9, tail.Records)
+
+	report, err = Verify(options)
+	assert.Nil(t, err)
+	assert.False(t, report.Corrupted())
+	info, err = os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, tail.ValidSize, info.Size())
+
+	// the records before the torn one are still there and new ones are appended after them
+	wal = openTestWAL(t, dirPath, NoCompression)
+	defer wal.Close()
+	_, err = wal.Write(testRecord(10))
+	assert.Nil(t, err)
+	reader := wal.NewReader()
+	for _, i := range []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 10} {
+		data, _, err := reader.Next()
+		assert.Nil(t, err)
+		assert.Equal(t, testRecord(i), data)
+	}
+	_, _, err = reader.Next()
+	assert.NotNil(t, err)
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/sjy-dv/coltt/gen/protoc/v4/edgepb"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -67,3 +68,13 @@ func (*edgeProtoConn) Search(ctx context.Context, req *edgepb.SearchIndex) (
 	*edgepb.SearchResponse, error) {
 	return edgelites.Edge.Search(ctx, req)
 }
+
+func (*edgeProtoConn) Query(ctx context.Context, req *edgepb.ScalarQuery) (
+	*edgepb.SearchResponse, error) {
+	return edgelites.Edge.Query(ctx, req)
+}
+
+func (*edgeProtoConn) Subscribe(req *edgepb.SubscribeRequest,
+	stream grpc.ServerStreamingServer[edgepb.ChangeEvent]) error {
+	return edgelites.Edge.Subscribe(req, stream)
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/sjy-dv/coltt/gen/protoc/v3/coreproto"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -53,6 +54,16 @@ func (xx *coreProtoConn) ReleaseCollection(ctx context.Context, req *coreproto.C
 	return rc.Core.ReleaseCollection(ctx, req)
 }
 
+func (xx *coreProtoConn) UpdateCollectionConfig(ctx context.Context, req *coreproto.CollectionConfigUpdate) (
+	*coreproto.CollectionMsg, error) {
+	return rc.Core.UpdateCollectionConfig(ctx, req)
+}
+
+func (xx *coreProtoConn) RebuildIndex(ctx context.Context, req *coreproto.RebuildIndexRequest) (
+	*coreproto.ResponseWithMessage, error) {
+	return rc.Core.RebuildIndex(ctx, req)
+}
+
 func (xx *coreProtoConn) Insert(ctx context.Context, req *coreproto.DatasetChange) (
 	*coreproto.Response, error) {
 	return rc.Core.Insert(ctx, req)
@@ -87,3 +98,8 @@ func (xx *coreProtoConn) CompareDist(ctx context.Context, req *coreproto.CompXyD
 	*coreproto.XyDist, error) {
 	return rc.Core.CompareDist(ctx, req)
 }
+
+func (xx *coreProtoConn) Subscribe(req *coreproto.SubscribeRequest,
+	stream grpc.ServerStreamingServer[coreproto.ChangeEvent]) error {
+	return rc.Core.Subscribe(req, stream)
+}